@@ -0,0 +1,291 @@
+package function
+
+import (
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Functions interface {
+	ReadInstructionByID(string, string) entities.Instruction
+	ReadPagesCount(string, entities.PageInstruction, entities.City) (int, error)
+	ParsePage(string, entities.PageInstruction, entities.City) ([]Product, error)
+}
+
+type Executor struct {
+	Functions Functions
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// DefaultConcurrency is a count of pages parsed at the same time if concurrency is not set
+const DefaultConcurrency = 4
+
+var (
+	// ErrInstructionCanNotBeWithoutID means that instruction can't be found in storage for crawl
+	ErrInstructionCanNotBeWithoutID = errors.New("instruction can not be without id")
+
+	// ErrInstructionDoesNotExist means than the instruction does not exist in database
+	ErrInstructionDoesNotExist = errors.New("instruction does not exist")
+
+	// ErrInstructionHasNoPages means that the instruction has no one page instruction for crawl
+	ErrInstructionHasNoPages = errors.New("instruction has no page instructions")
+
+	// ErrPageIsNotModified means that page-parser function found page not modified since last crawl
+	ErrPageIsNotModified = errors.New("page is not modified")
+
+	// ErrPaginationIsNotFound means that mvideo-pages-count-parser function found no pagination on page,
+	// so category has one page
+	ErrPaginationIsNotFound = errors.New("pagination is not found")
+
+	// ErrCityCanNotBeWithoutCode means that page instruction with city can't be crawled for city without code of it
+	ErrCityCanNotBeWithoutCode = errors.New("city can not be without code")
+)
+
+// City of price of product, Code is a value of city for shop of instruction
+type City struct {
+	ID, Name, Code string
+}
+
+type Price struct {
+	Value        float64
+	MaxValue     float64
+	OldValue     float64
+	Discount     float64
+	Currency     string
+	Availability string
+	DateTime     time.Time
+	City         City
+}
+
+// Product is a product parsed by page-parser function
+type Product struct {
+	Name             string
+	IRI              string
+	PreviewImageLink string
+	SKU              string
+	GTIN             string
+	Price            Price
+}
+
+// PageStatus is a result of parse of one page of category.
+// NotModified is true if page is not modified since last crawl, products of it are not returned then.
+type PageStatus struct {
+	PageInstructionID string
+	CityID            string
+	IRI               string
+	Page              int
+	ProductsCount     int
+	NotModified       bool
+	Error             string
+}
+
+// CrawlResult is a products of all pages of categories of instruction
+type CrawlResult struct {
+	InstructionID string
+	Products      []Product
+	Pages         []PageStatus
+}
+
+type page struct {
+	iri             string
+	number          int
+	pageInstruction entities.PageInstruction
+	city            entities.City
+}
+
+// CrawlCategory read instruction by id and parse all pages of all page instructions of it
+// for every city of instruction. Not more than concurrency pages are parsed at the same time.
+func (executor *Executor) CrawlCategory(instructionID, language string, concurrency int) (CrawlResult, error) {
+	result := CrawlResult{InstructionID: instructionID}
+
+	if instructionID == "" {
+		ExecutorLogger.Println(ErrInstructionCanNotBeWithoutID)
+		return result, ErrInstructionCanNotBeWithoutID
+	}
+
+	instruction := executor.Functions.ReadInstructionByID(instructionID, language)
+	if instruction.ID == "" {
+		ExecutorLogger.Printf("Instruction with ID: %v does not exist", instructionID)
+		return result, ErrInstructionDoesNotExist
+	}
+
+	// Deleted instruction is kept in database as not active and must not be crawled
+	if !instruction.IsActive {
+		ExecutorLogger.Printf("Instruction with ID: %v is not active", instructionID)
+		return result, ErrInstructionDoesNotExist
+	}
+
+	if len(instruction.PagesInstruction) == 0 {
+		ExecutorLogger.Printf("Instruction with ID: %v has no page instructions", instructionID)
+		return result, ErrInstructionHasNoPages
+	}
+
+	var companyIRI string
+	if len(instruction.Companies) > 0 {
+		companyIRI = instruction.Companies[0].IRI
+	}
+
+	cities := instruction.Cities
+	if len(cities) == 0 {
+		cities = []entities.City{{}}
+	}
+
+	var pages []page
+
+	for _, pageInstruction := range instruction.PagesInstruction {
+		for _, city := range cities {
+			pages = append(pages, executor.categoryPages(companyIRI, pageInstruction, city, &result)...)
+		}
+	}
+
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	statuses := make([]PageStatus, len(pages))
+	productsOfPages := make([][]Product, len(pages))
+
+	semaphore := make(chan struct{}, concurrency)
+	waitGroup := sync.WaitGroup{}
+
+	for index, pageForParse := range pages {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+
+		go func(index int, pageForParse page) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			status := PageStatus{
+				PageInstructionID: pageForParse.pageInstruction.ID,
+				CityID:            pageForParse.city.ID,
+				IRI:               pageForParse.iri,
+				Page:              pageForParse.number}
+
+			products, err := executor.Functions.ParsePage(pageForParse.iri, pageForParse.pageInstruction, pageForParse.city)
+			if err != nil && err.Error() == ErrPageIsNotModified.Error() {
+				ExecutorLogger.Printf("Page by IRI: %v is not modified", pageForParse.iri)
+				status.NotModified = true
+			} else if err != nil {
+				ExecutorLogger.Printf("Page by IRI: %v can not be parsed. Error: %v", pageForParse.iri, err)
+				status.Error = err.Error()
+			}
+
+			status.ProductsCount = len(products)
+			statuses[index] = status
+			productsOfPages[index] = products
+		}(index, pageForParse)
+	}
+
+	waitGroup.Wait()
+
+	result.Pages = append(result.Pages, statuses...)
+	for _, products := range productsOfPages {
+		result.Products = append(result.Products, products...)
+	}
+
+	return result, nil
+}
+
+// categoryPages make pages of category of page instruction for city.
+// Page instruction is skipped with status in result if count of pages can't be read
+// or if city has no code for param or cookie of city of page instruction.
+func (executor *Executor) categoryPages(companyIRI string, pageInstruction entities.PageInstruction, city entities.City, result *CrawlResult) []page {
+	categoryIRI, err := resolveIRI(companyIRI, pageInstruction.Path)
+	if err != nil {
+		ExecutorLogger.Printf("Path: %v of page instruction: %v is wrong. Error: %v", pageInstruction.Path, pageInstruction.ID, err)
+		result.Pages = append(result.Pages, PageStatus{
+			PageInstructionID: pageInstruction.ID,
+			CityID:            city.ID,
+			IRI:               pageInstruction.Path,
+			Error:             err.Error()})
+		return nil
+	}
+
+	if city.ID != "" && city.Code == "" && (pageInstruction.CityParamPath != "" || pageInstruction.CityInCookieKey != "") {
+		ExecutorLogger.Printf("City with ID: %v has no code for page instruction: %v", city.ID, pageInstruction.ID)
+		result.Pages = append(result.Pages, PageStatus{
+			PageInstructionID: pageInstruction.ID,
+			CityID:            city.ID,
+			IRI:               categoryIRI,
+			Error:             ErrCityCanNotBeWithoutCode.Error()})
+		return nil
+	}
+
+	pagesCount := 1
+
+	if pageInstruction.PageParamPath != "" && hasPagination(pageInstruction) {
+		pagesCount, err = executor.Functions.ReadPagesCount(categoryIRI, pageInstruction, city)
+		if err != nil && err.Error() != ErrPaginationIsNotFound.Error() {
+			ExecutorLogger.Printf("Count of pages by IRI: %v can not be read. Error: %v", categoryIRI, err)
+			result.Pages = append(result.Pages, PageStatus{
+				PageInstructionID: pageInstruction.ID,
+				CityID:            city.ID,
+				IRI:               categoryIRI,
+				Error:             err.Error()})
+			return nil
+		}
+
+		if pagesCount < 1 {
+			pagesCount = 1
+		}
+	}
+
+	var pages []page
+
+	for number := 1; number <= pagesCount; number++ {
+		pages = append(pages, page{
+			iri:             pageIRI(categoryIRI, pageInstruction.PageParamPath, number),
+			number:          number,
+			pageInstruction: pageInstruction,
+			city:            city})
+	}
+
+	return pages
+}
+
+// hasPagination return true if page instruction has any way of count of pages:
+// selector of page in pagination, strategies or selectors of them
+func hasPagination(pageInstruction entities.PageInstruction) bool {
+	return pageInstruction.PageInPaginationSelector != "" ||
+		len(pageInstruction.PaginationStrategies) > 0 ||
+		pageInstruction.LastPageSelector != "" ||
+		pageInstruction.NextPageSelector != "" ||
+		pageInstruction.TotalItemsSelector != ""
+}
+
+// resolveIRI make absolute IRI of category from IRI of company and path of page instruction
+func resolveIRI(companyIRI, path string) (string, error) {
+	pathURL, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+
+	if pathURL.IsAbs() || companyIRI == "" {
+		return pathURL.String(), nil
+	}
+
+	companyURL, err := url.Parse(companyIRI)
+	if err != nil {
+		return "", err
+	}
+
+	return companyURL.ResolveReference(pathURL).String(), nil
+}
+
+// pageIRI make IRI of page of category by param of page in path
+func pageIRI(categoryIRI, pageParamPath string, number int) string {
+	if pageParamPath == "" {
+		return categoryIRI
+	}
+
+	return categoryIRI + pageParamPath + strconv.Itoa(number)
+}
@@ -0,0 +1,394 @@
+package function
+
+import (
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"sync"
+	"testing"
+)
+
+// ------------------------------------------------------------------------------------------------------
+func TestCategoryCanBeCrawled(t *testing.T) {
+	functions := &MockFAASFunctions{PagesCount: 3, ParsedPages: map[string]int{}}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(result.Pages) != 3 {
+		t.Fatalf("Expect: %v pages, but got: %v", 3, len(result.Pages))
+	}
+
+	expectedIRI := "http://shop/category/?page=2"
+	if result.Pages[1].IRI != expectedIRI {
+		t.Errorf("Expect: %v, but got: %v", expectedIRI, result.Pages[1].IRI)
+	}
+
+	if functions.ParsedPages[expectedIRI] != 1 {
+		t.Errorf("Expect page: %v parsed once, but got: %v", expectedIRI, functions.ParsedPages[expectedIRI])
+	}
+
+	if len(result.Products) != 6 {
+		t.Errorf("Expect: %v products, but got: %v", 6, len(result.Products))
+	}
+
+	if result.Products[2].IRI != expectedIRI {
+		t.Errorf("Expect products in order of pages, but got: %v", result.Products[2].IRI)
+	}
+}
+
+func TestCategoryCanBeCrawledWithFailedPage(t *testing.T) {
+	functions := &MockFAASFunctions{
+		PagesCount:  2,
+		ParsedPages: map[string]int{},
+		FailedIRI:   "http://shop/category/?page=2"}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if result.Pages[1].Error == "" {
+		t.Errorf("Expect error of page: %v", result.Pages[1].IRI)
+	}
+
+	if len(result.Products) != 2 {
+		t.Errorf("Expect: %v products, but got: %v", 2, len(result.Products))
+	}
+}
+
+func TestCategoryCanBeCrawledWithNotModifiedPage(t *testing.T) {
+	functions := &MockFAASFunctions{
+		PagesCount:     2,
+		ParsedPages:    map[string]int{},
+		NotModifiedIRI: "http://shop/category/?page=2"}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !result.Pages[1].NotModified || result.Pages[1].Error != "" {
+		t.Errorf("Expect not modified page without error: %v", result.Pages[1])
+	}
+
+	if result.Pages[0].NotModified {
+		t.Errorf("Expect modified page: %v", result.Pages[0].IRI)
+	}
+
+	if len(result.Products) != 2 {
+		t.Errorf("Expect: %v products, but got: %v", 2, len(result.Products))
+	}
+}
+
+func TestCategoryCanBeCrawledForEveryCity(t *testing.T) {
+	functions := &MockFAASFunctions{
+		PagesCount:  2,
+		ParsedPages: map[string]int{},
+		Cities: []entities.City{
+			{City: storage.City{ID: "0x15", Name: "Moscow"}, Code: "moscow"},
+			{City: storage.City{ID: "0x16", Name: "Saint Petersburg"}, Code: "spb"}}}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(result.Pages) != 4 {
+		t.Fatalf("Expect: %v pages, but got: %v", 4, len(result.Pages))
+	}
+
+	if result.Pages[2].CityID != "0x16" {
+		t.Errorf("Expect: %v, but got: %v", "0x16", result.Pages[2].CityID)
+	}
+
+	if functions.ParsedPages["http://shop/category/?page=1"] != 2 {
+		t.Errorf("Expect page parsed for every city, but got: %v", functions.ParsedPages["http://shop/category/?page=1"])
+	}
+
+	if result.Products[4].Price.City.ID != "0x16" {
+		t.Errorf("Expect: %v, but got: %v", "0x16", result.Products[4].Price.City.ID)
+	}
+
+	if result.Products[4].Price.City.Code != "spb" {
+		t.Errorf("Expect: %v, but got: %v", "spb", result.Products[4].Price.City.Code)
+	}
+}
+
+func TestCategoryCanNotBeCrawledForCityWithoutCode(t *testing.T) {
+	functions := &MockFAASFunctions{
+		PagesCount:  2,
+		ParsedPages: map[string]int{},
+		Cities: []entities.City{
+			{City: storage.City{ID: "0x15", Name: "Moscow"}, Code: "moscow"},
+			{City: storage.City{ID: "0x16", Name: "Saint Petersburg"}}},
+		PagesInstruction: []entities.PageInstruction{
+			{
+				PageInstruction: storage.PageInstruction{
+					ID:                       "0x14",
+					Path:                     "category/",
+					PageParamPath:            "?page=",
+					CityParamPath:            "&city=",
+					PageInPaginationSelector: ".pagination"}}}}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(result.Pages) != 3 {
+		t.Fatalf("Expect: %v pages, but got: %v", 3, len(result.Pages))
+	}
+
+	if result.Pages[0].CityID != "0x16" || result.Pages[0].Error != ErrCityCanNotBeWithoutCode.Error() {
+		t.Errorf("Expect error: %v of city: %v, but got: %v", ErrCityCanNotBeWithoutCode, "0x16", result.Pages[0])
+	}
+
+	if len(functions.CountedPages) != 1 {
+		t.Errorf("Expect pages counted for city with code only, but got: %v", len(functions.CountedPages))
+	}
+
+	for _, product := range result.Products {
+		if product.Price.City.ID != "0x15" {
+			t.Errorf("Expect products of city: %v only, but got: %v", "0x15", product.Price.City)
+		}
+	}
+}
+
+func TestCategoryCanBeCrawledByPaginationStrategies(t *testing.T) {
+	functions := &MockFAASFunctions{
+		PagesCount:  3,
+		ParsedPages: map[string]int{},
+		PagesInstruction: []entities.PageInstruction{
+			{
+				PageInstruction: storage.PageInstruction{
+					ID:            "0x14",
+					Path:          "category/",
+					PageParamPath: "?page="},
+				PaginationStrategies: []string{"last_page_link"},
+				LastPageSelector:     ".pagination .last"},
+			{
+				PageInstruction: storage.PageInstruction{
+					ID:            "0x15",
+					Path:          "other-category/",
+					PageParamPath: "?page="},
+				TotalItemsSelector: ".found",
+				ItemsPerPage:       24},
+			{
+				PageInstruction: storage.PageInstruction{
+					ID:   "0x16",
+					Path: "single-page-category/"}}}}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(functions.CountedPages) != 2 {
+		t.Fatalf("Expect pages counted for: %v page instructions, but got: %v", 2, len(functions.CountedPages))
+	}
+
+	for _, countedPage := range functions.CountedPages {
+		if countedPage.ID == "0x14" && (countedPage.LastPageSelector != ".pagination .last" || len(countedPage.PaginationStrategies) != 1) {
+			t.Errorf("Expect pagination of page instruction sent for count of pages, but got: %v", countedPage)
+		}
+
+		if countedPage.ID == "0x15" && (countedPage.TotalItemsSelector != ".found" || countedPage.ItemsPerPage != 24) {
+			t.Errorf("Expect pagination of page instruction sent for count of pages, but got: %v", countedPage)
+		}
+	}
+
+	if len(result.Pages) != 7 {
+		t.Fatalf("Expect: %v pages, but got: %v", 7, len(result.Pages))
+	}
+
+	if functions.ParsedPages["http://shop/other-category/?page=3"] != 1 {
+		t.Errorf("Expect page: %v parsed once, but got: %v", "http://shop/other-category/?page=3", functions.ParsedPages)
+	}
+
+	if functions.ParsedPages["http://shop/single-page-category/"] != 1 {
+		t.Errorf("Expect page: %v parsed once, but got: %v", "http://shop/single-page-category/", functions.ParsedPages)
+	}
+}
+
+func TestCategoryWithoutPaginationCanBeCrawled(t *testing.T) {
+	// Error of mvideo-pages-count-parser function is decoded from response, so it is not the same value
+	functions := &MockFAASFunctions{
+		PagesCountError: errors.New(ErrPaginationIsNotFound.Error()),
+		ParsedPages:     map[string]int{}}
+
+	executor := Executor{Functions: functions}
+
+	result, err := executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(result.Pages) != 1 || result.Pages[0].Error != "" {
+		t.Fatalf("Expect: %v page without error, but got: %v", 1, result.Pages)
+	}
+
+	if functions.ParsedPages["http://shop/category/?page=1"] != 1 {
+		t.Errorf("Expect page: %v parsed once, but got: %v", "http://shop/category/?page=1", functions.ParsedPages)
+	}
+
+	functions = &MockFAASFunctions{
+		PagesCountError: errors.New("pages count can not be found"),
+		ParsedPages:     map[string]int{}}
+
+	executor = Executor{Functions: functions}
+
+	result, err = executor.CrawlCategory("0x12", "ru", 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(result.Pages) != 1 || result.Pages[0].Error != "pages count can not be found" || len(functions.ParsedPages) != 0 {
+		t.Errorf("Expect page with error of count of pages, but got: %v", result.Pages)
+	}
+}
+
+/// Mock FAAS functions
+type MockFAASFunctions struct {
+	sync.Mutex
+	PagesCount     int
+	FailedIRI      string
+	NotModifiedIRI string
+	ParsedPages    map[string]int
+	Cities         []entities.City
+
+	// PagesInstruction of instruction, page instruction with PageInPaginationSelector is used if it is empty
+	PagesInstruction []entities.PageInstruction
+
+	// CountedPages are page instructions of ReadPagesCount calls
+	CountedPages []entities.PageInstruction
+
+	// PagesCountError is returned by ReadPagesCount instead of PagesCount if it is not nil
+	PagesCountError error
+}
+
+func (functions *MockFAASFunctions) ReadInstructionByID(instructionID, language string) entities.Instruction {
+	if functions.PagesInstruction != nil {
+		return entities.Instruction{
+			Instruction: storage.Instruction{
+				ID:       instructionID,
+				Language: language,
+				IsActive: true,
+				Companies: []storage.Company{
+					{ID: "0x13", IRI: "http://shop/"}}},
+			PagesInstruction: functions.PagesInstruction,
+			Cities:           functions.Cities}
+	}
+
+	return entities.Instruction{
+		Instruction: storage.Instruction{
+			ID:       instructionID,
+			Language: language,
+			IsActive: true,
+			Companies: []storage.Company{
+				{ID: "0x13", IRI: "http://shop/"}}},
+		PagesInstruction: []entities.PageInstruction{
+			{
+				PageInstruction: storage.PageInstruction{
+					ID:                       "0x14",
+					Path:                     "category/",
+					PageParamPath:            "?page=",
+					PageInPaginationSelector: ".pagination"}}},
+		Cities: functions.Cities}
+}
+
+func (functions *MockFAASFunctions) ReadPagesCount(iri string, pageInstruction entities.PageInstruction, city entities.City) (int, error) {
+	functions.Lock()
+	functions.CountedPages = append(functions.CountedPages, pageInstruction)
+	functions.Unlock()
+
+	if functions.PagesCountError != nil {
+		return 0, functions.PagesCountError
+	}
+
+	return functions.PagesCount, nil
+}
+
+func (functions *MockFAASFunctions) ParsePage(iri string, pageInstruction entities.PageInstruction, city entities.City) ([]Product, error) {
+	functions.Lock()
+	functions.ParsedPages[iri]++
+	functions.Unlock()
+
+	if iri == functions.FailedIRI {
+		return nil, errors.New("page can not be parsed")
+	}
+
+	if iri == functions.NotModifiedIRI {
+		return nil, errors.New("page is not modified")
+	}
+
+	price := Price{City: City{ID: city.ID, Name: city.Name, Code: city.Code}}
+
+	return []Product{{Name: "First", IRI: iri, Price: price}, {Name: "Second", IRI: iri, Price: price}}, nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestCategoryCanNotBeCrawledWithoutInstruction(t *testing.T) {
+	executor := Executor{Functions: EmptyInstructionFAASFunctions{}}
+
+	_, err := executor.CrawlCategory("", "ru", 1)
+	if err != ErrInstructionCanNotBeWithoutID {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = executor.CrawlCategory("0x12", "ru", 1)
+	if err != ErrInstructionDoesNotExist {
+		t.Fatalf(err.Error())
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestCategoryCanNotBeCrawledWithNotActiveInstruction(t *testing.T) {
+	executor := Executor{Functions: NotActiveInstructionFAASFunctions{}}
+
+	_, err := executor.CrawlCategory("0x12", "ru", 1)
+	if err != ErrInstructionDoesNotExist {
+		t.Fatalf("Expected error: %v, actual: %v", ErrInstructionDoesNotExist, err)
+	}
+}
+
+type NotActiveInstructionFAASFunctions struct {
+	EmptyInstructionFAASFunctions
+}
+
+func (functions NotActiveInstructionFAASFunctions) ReadInstructionByID(instructionID, language string) entities.Instruction {
+	return entities.Instruction{
+		Instruction: storage.Instruction{ID: instructionID, Language: language, IsActive: false},
+		PagesInstruction: []entities.PageInstruction{
+			{PageInstruction: storage.PageInstruction{ID: "0x14", Path: "category/"}}}}
+}
+
+type EmptyInstructionFAASFunctions struct{}
+
+func (functions EmptyInstructionFAASFunctions) ReadInstructionByID(instructionID, language string) entities.Instruction {
+	return entities.Instruction{}
+}
+
+func (functions EmptyInstructionFAASFunctions) ReadPagesCount(iri string, pageInstruction entities.PageInstruction, city entities.City) (int, error) {
+	return 0, nil
+}
+
+func (functions EmptyInstructionFAASFunctions) ParsePage(iri string, pageInstruction entities.PageInstruction, city entities.City) ([]Product, error) {
+	return nil, nil
+}
+
+// --------------------------------------------------------------------------------------------------------
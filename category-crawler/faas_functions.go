@@ -0,0 +1,185 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+)
+
+var FAASLogger = log.New(os.Stdout, "FAASFunctions: ", log.Lshortfile)
+
+// Politeness is a settings of requests of parsers to shop, delays are in milliseconds
+type Politeness struct {
+	Parallelism      int
+	Delay            int
+	RandomDelay      int
+	Retries          int
+	RetryDelay       int
+	RespectRobotsTxt bool
+	Rotation         Rotation
+}
+
+// Rotation is a settings of proxies and user agents of requests of parsers
+type Rotation struct {
+	Mode        string
+	Proxies     []string
+	UserAgents  []string
+	MaxFailures int
+	Cooldown    int
+}
+
+// PageParserOptions is a settings of page-parser function that are not a part of page instruction.
+// Politeness is sent to mvideo-pages-count-parser function too.
+// If StructuredData is true, page-parser use JSON-LD, microdata and OpenGraph of pages too.
+// Cache is sent to mvideo-pages-count-parser function too.
+type PageParserOptions struct {
+	LinkAttribute   string
+	ImageAttributes []string
+	PricePattern    string
+	Availability    map[string]string
+	Politeness      Politeness
+	StructuredData  bool
+	Cache           Cache
+}
+
+// Cache is a directory of cache of pages of parsers.
+// Not modified pages are parsed by page-parser function only if ParseNotModified is true.
+type Cache struct {
+	Directory        string
+	ParseNotModified bool
+}
+
+type FAASFunctions struct {
+	FunctionsGateway  string
+	DatabaseGateway   string
+	PageParserOptions PageParserOptions
+}
+
+func (functions FAASFunctions) ReadInstructionByID(instructionID, language string) entities.Instruction {
+	body := struct {
+		Language        string
+		InstructionID   string
+		DatabaseGateway string
+	}{
+		Language:        language,
+		InstructionID:   instructionID,
+		DatabaseGateway: functions.DatabaseGateway}
+
+	response, err := functions.call("storage-instruction-read-by-id", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.Instruction{}
+	}
+
+	var existInstruction entities.Instruction
+
+	err = json.Unmarshal([]byte(response.Data), &existInstruction)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.Instruction{}
+	}
+
+	return existInstruction
+}
+
+func (functions FAASFunctions) ReadPagesCount(iri string, pageInstruction entities.PageInstruction, city entities.City) (int, error) {
+	body := struct {
+		IRI          string
+		Instructions entities.PageInstruction
+		City         City
+		Politeness   Politeness
+		Cache        Cache
+	}{
+		IRI:          iri,
+		Instructions: pageInstruction,
+		City:         City{ID: city.ID, Name: city.Name, Code: city.Code},
+		Politeness:   functions.PageParserOptions.Politeness,
+		Cache:        functions.PageParserOptions.Cache}
+
+	response, err := functions.call("mvideo-pages-count-parser", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return 0, err
+	}
+
+	var pagesCount int
+
+	err = json.Unmarshal([]byte(response.Data), &pagesCount)
+	if err != nil {
+		FAASLogger.Println(err)
+		return 0, err
+	}
+
+	return pagesCount, nil
+}
+
+func (functions FAASFunctions) ParsePage(iri string, pageInstruction entities.PageInstruction, city entities.City) ([]Product, error) {
+	body := struct {
+		IRI             string
+		PageInstruction entities.PageInstruction
+		City            City
+		PageParserOptions
+	}{
+		IRI:               iri,
+		PageInstruction:   pageInstruction,
+		City:              City{ID: city.ID, Name: city.Name, Code: city.Code},
+		PageParserOptions: functions.PageParserOptions}
+
+	response, err := functions.call("page-parser", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil, err
+	}
+
+	var products []Product
+
+	err = json.Unmarshal([]byte(response.Data), &products)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// call send body to function and decode response of it
+func (functions FAASFunctions) call(functionName string, body interface{}) (Response, error) {
+	functionPath := fmt.Sprintf(
+		"%v/%v", functions.FunctionsGateway, functionName)
+
+	encodedResponse := Response{}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	defer response.Body.Close()
+
+	decodedResponse, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	if encodedResponse.Error != "" {
+		return encodedResponse, errors.New(encodedResponse.Error)
+	}
+
+	return encodedResponse, nil
+}
@@ -0,0 +1,196 @@
+package function
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFAASFunctions_ReadInstructionByID(t *testing.T) {
+	LanguageForTest := "ru"
+	InstructionIDForTest := "0x12"
+	DatabaseGatewayForTest := "http://TestDatabaseGateway"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/storage-instruction-read-by-id", func(w http.ResponseWriter, r *http.Request) {
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		var responseBodyEncoded map[string]string
+		err = json.Unmarshal(encodedBody, &responseBodyEncoded)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if responseBodyEncoded["InstructionID"] != InstructionIDForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", InstructionIDForTest, responseBodyEncoded["InstructionID"])
+		}
+
+		if responseBodyEncoded["DatabaseGateway"] != DatabaseGatewayForTest {
+			t.Fatalf(
+				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
+		}
+
+		existedInstructionInStorage := entities.Instruction{
+			Instruction: storage.Instruction{
+				ID:       "0x12",
+				Language: LanguageForTest},
+			PagesInstruction: []entities.PageInstruction{{
+				PageInstruction:            storage.PageInstruction{ID: "0x13"},
+				AvailabilityOfItemSelector: ".availability"}}}
+
+		encodedExistedInstructionInStorage, err := json.Marshal(existedInstructionInStorage)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		response := Response{Data: string(encodedExistedInstructionInStorage)}
+
+		encodedResponse, err := json.Marshal(response)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL, DatabaseGateway: DatabaseGatewayForTest}
+	instruction := faas.ReadInstructionByID(InstructionIDForTest, LanguageForTest)
+
+	if instruction.ID != "0x12" {
+		t.Fatalf("Expect instruction id: %v, but got: %v", InstructionIDForTest, instruction.ID)
+	}
+
+	if len(instruction.PagesInstruction) != 1 || instruction.PagesInstruction[0].AvailabilityOfItemSelector != ".availability" {
+		t.Fatalf("Expect page instruction with availability selector, but got: %v", instruction.PagesInstruction)
+	}
+}
+
+func TestFAASFunctions_ReadPagesCountAndParsePage(t *testing.T) {
+	IRIForTest := "http://shop/category/?page=1"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/mvideo-pages-count-parser", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"Data": "3"}`)
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	mux.HandleFunc("/page-parser", func(w http.ResponseWriter, r *http.Request) {
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		var requestBody struct {
+			IRI             string
+			PageInstruction map[string]string
+			ImageAttributes []string
+			City            City
+		}
+
+		err = json.Unmarshal(encodedBody, &requestBody)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if requestBody.IRI != IRIForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", IRIForTest, requestBody.IRI)
+		}
+
+		if requestBody.PageInstruction["itemSelector"] != ".item" {
+			t.Fatalf("Expected: \"%v\", but got: %v", ".item", requestBody.PageInstruction["itemSelector"])
+		}
+
+		if requestBody.PageInstruction["availabilityOfItemSelector"] != ".availability" {
+			t.Fatalf("Expected: \"%v\", but got: %v", ".availability", requestBody.PageInstruction["availabilityOfItemSelector"])
+		}
+
+		if requestBody.City.ID != "0x15" || requestBody.City.Code != "moscow" {
+			t.Fatalf("Expected city with code: \"%v\", but got: %v", "moscow", requestBody.City)
+		}
+
+		if len(requestBody.ImageAttributes) != 1 || requestBody.ImageAttributes[0] != "data-original" {
+			t.Fatalf("Expected: \"%v\", but got: %v", "data-original", requestBody.ImageAttributes)
+		}
+
+		encodedProducts, err := json.Marshal([]Product{{Name: "Test product", IRI: "http://shop/product"}})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedProducts)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{
+		FunctionsGateway:  testServer.URL,
+		PageParserOptions: PageParserOptions{ImageAttributes: []string{"data-original"}}}
+
+	pageInstruction := entities.PageInstruction{
+		PageInstruction:            storage.PageInstruction{ItemSelector: ".item"},
+		AvailabilityOfItemSelector: ".availability"}
+
+	pagesCount, err := faas.ReadPagesCount(IRIForTest, pageInstruction, entities.City{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if pagesCount != 3 {
+		t.Fatalf("Expect pages count: %v, but got: %v", 3, pagesCount)
+	}
+
+	products, err := faas.ParsePage(IRIForTest, pageInstruction, entities.City{City: storage.City{ID: "0x15"}, Code: "moscow"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 || products[0].Name != "Test product" {
+		t.Fatalf("Expect one product: %v, but got: %v", "Test product", products)
+	}
+}
+
+func TestFAASFunctions_ParsePageWithError(t *testing.T) {
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"Error": "page can not be parsed"}`)
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(testHandler)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL}
+
+	_, err := faas.ParsePage("http://", entities.PageInstruction{}, entities.City{})
+	if err == nil || err.Error() != "page can not be parsed" {
+		t.Fatalf("Expect error of page-parser, but got: %v", err)
+	}
+}
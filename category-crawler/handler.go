@@ -0,0 +1,88 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Request struct {
+	Language,
+	InstructionID,
+	DatabaseGateway,
+	FunctionsGateway string
+	Concurrency       int
+	PageParserOptions PageParserOptions
+}
+
+type Response struct{ Message, Data, Error string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{
+		Functions: &FAASFunctions{
+			DatabaseGateway:   request.DatabaseGateway,
+			FunctionsGateway:  request.FunctionsGateway,
+			PageParserOptions: request.PageParserOptions}}
+
+	crawlResult, err := executor.CrawlCategory(request.InstructionID, request.Language, request.Concurrency)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"CrawlCategory error: %v", err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedCrawlResult, err := json.Marshal(crawlResult)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal crawl result error: %v. Error: %v", crawlResult, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedCrawlResult)}
+
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
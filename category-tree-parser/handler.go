@@ -0,0 +1,213 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"golang.org/x/net/html"
+	"net/url"
+)
+
+var (
+	// ErrCompanyIRICanNotBeEmpty means that menu can't be loaded without IRI of company
+	ErrCompanyIRICanNotBeEmpty = errors.New("company IRI can not be empty")
+
+	// ErrCategorySelectorCanNotBeEmpty means that categories of menu can't be found without selector
+	ErrCategorySelectorCanNotBeEmpty = errors.New("category selector can not be empty")
+
+	// ErrCategoriesAreNotFound means that no one category with link is found in menu of page
+	ErrCategoriesAreNotFound = errors.New("categories are not found")
+)
+
+// Menu is a structure of selectors of navigation menu of shop.
+// Selectors are CSS selectors or XPath expressions with selectors.XPathPrefix, like "xpath://nav//li".
+// MenuSelector is optional, categories are searched in all page if it is empty.
+// CategorySelector match every category of all levels, like "li", parent of category
+// is the nearest category element which contains it. LinkSelector and NameSelector
+// are searched inside of category, so XPath of them must be relative, like "xpath:./a".
+// DefaultLinkSelector is used if LinkSelector is empty and text of link is a name of category if NameSelector is empty.
+type Menu struct {
+	MenuSelector     string `json:"menuSelector,omitempty"`
+	CategorySelector string `json:"categorySelector,omitempty"`
+	LinkSelector     string `json:"linkSelector,omitempty"`
+	NameSelector     string `json:"nameSelector,omitempty"`
+}
+
+// Request for discovery of categories of company.
+// PageInstruction is a template of draft page instructions, path of category is set to copy of it.
+// Drafts are made for last level categories only, if AllCategories is false.
+// Politeness is optional, defaults are used for empty values.
+type Request struct {
+	CompanyIRI      string
+	Menu            Menu
+	PageInstruction entities.PageInstruction
+	AllCategories   bool
+	Politeness      crawl.Politeness
+}
+
+// Category of menu of shop. Path is a path of IRI with query for page instruction,
+// it is empty for groups of menu without links.
+type Category struct {
+	Name     string
+	IRI      string
+	Path     string
+	Children []Category
+}
+
+// Draft is a page instruction of category for review before save by storage-page-instruction-create.
+// Breadcrumbs are names of parents of category with name of it.
+type Draft struct {
+	Breadcrumbs     []string
+	PageInstruction entities.PageInstruction
+}
+
+// CategoryTree is a hierarchy of categories of menu of company with drafts of page instructions
+type CategoryTree struct {
+	CompanyIRI string
+	Categories []Category
+	Drafts     []Draft
+}
+
+type Response struct{ Message, Data, Error string }
+
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+		fmt.Println(warning)
+	}
+
+	tree, err := discoverCategories(request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Discover categories error by company IRI: %v. Error: %v",
+			request.CompanyIRI,
+			err)
+
+		fmt.Println(warning)
+
+		encodedResponse := Response{
+			Message: warning,
+			Data:    string(req),
+			Error:   err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedTree, err := json.Marshal(tree)
+	if err != nil {
+		encodedResponse := Response{
+			Data:  string(req),
+			Error: err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedResponse := Response{Data: string(encodedTree)}
+
+	response, err := json.Marshal(encodedResponse)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return string(response)
+}
+
+// discoverCategories load page of company and return tree of categories of menu of it with drafts
+func discoverCategories(request Request) (CategoryTree, error) {
+	tree := CategoryTree{CompanyIRI: request.CompanyIRI}
+
+	if request.CompanyIRI == "" {
+		return tree, ErrCompanyIRICanNotBeEmpty
+	}
+
+	if request.Menu.CategorySelector == "" {
+		return tree, ErrCategorySelectorCanNotBeEmpty
+	}
+
+	// htmlquery panics on invalid XPath expression, so XPath selectors are checked before parse
+	err := selectors.CompileXPaths(
+		request.Menu.MenuSelector,
+		request.Menu.CategorySelector,
+		request.Menu.LinkSelector,
+		request.Menu.NameSelector)
+	if err != nil {
+		return tree, err
+	}
+
+	collector, failedRequests, err := crawl.NewCollector(request.Politeness)
+	if err != nil {
+		return tree, err
+	}
+
+	var page *html.Node
+	var pageURL *url.URL
+
+	collector.OnHTML("html", func(element *colly.HTMLElement) {
+		page = element.DOM.Nodes[0]
+		pageURL = element.Request.URL
+	})
+
+	err = collector.Visit(request.CompanyIRI)
+	if err != nil {
+		return tree, err
+	}
+
+	collector.Wait()
+
+	err = failedRequests.Err()
+	if err != nil {
+		return tree, err
+	}
+
+	if page == nil {
+		return tree, ErrCategoriesAreNotFound
+	}
+
+	tree.Categories = categoriesOf(page, request.Menu, pageURL)
+	tree.Drafts = draftsOf(tree.Categories, nil, request.PageInstruction, request.AllCategories)
+
+	if len(tree.Drafts) == 0 {
+		return tree, ErrCategoriesAreNotFound
+	}
+
+	return tree, nil
+}
+
+// draftsOf return drafts of page instructions for categories with links by template
+func draftsOf(categories []Category, breadcrumbs []string, template entities.PageInstruction, allCategories bool) []Draft {
+	var drafts []Draft
+
+	for _, category := range categories {
+		categoryBreadcrumbs := append(append([]string{}, breadcrumbs...), category.Name)
+
+		if category.Path != "" && (allCategories || len(category.Children) == 0) {
+			pageInstruction := template
+			pageInstruction.ID = ""
+			pageInstruction.Path = category.Path
+
+			drafts = append(drafts, Draft{Breadcrumbs: categoryBreadcrumbs, PageInstruction: pageInstruction})
+		}
+
+		drafts = append(drafts, draftsOf(category.Children, categoryBreadcrumbs, template, allCategories)...)
+	}
+
+	return drafts
+}
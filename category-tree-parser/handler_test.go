@@ -0,0 +1,142 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCategoriesCanBeDiscoveredByMenu(t *testing.T) {
+	testPageContent := `
+		<html><body>
+			<nav class="menu">
+				<ul>
+					<li><a href="/catalog/electronics/">Electronics</a>
+						<ul>
+							<li><a href="/catalog/phones/?utm_source=menu#top"> Phones </a></li>
+							<li><span>Computers</span>
+								<ul>
+									<li><a href="https://SHOP/catalog/laptops/">Laptops</a></li>
+									<li><a href="/catalog/tablets/">Tablets</a></li>
+								</ul>
+							</li>
+						</ul>
+					</li>
+					<li><span>Empty group</span></li>
+					<li><a href="https://blog.example.com/">Blog</a></li>
+					<li><a href="#">Anchor</a></li>
+				</ul>
+			</nav>
+			<nav class="menu mobile">
+				<ul><li><a href="/catalog/phones/?utm_source=menu">Phones</a></li></ul>
+			</nav>
+		</body></html>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		CompanyIRI:      server.URL + "/",
+		Menu:            Menu{MenuSelector: "nav.menu", CategorySelector: "li", LinkSelector: "xpath:./a"},
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x1", ItemSelector: ".product"}}}
+
+	encodedRequest, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(encodedRequest)), &response)
+	if err != nil || response.Error != "" {
+		t.Fatalf("expected no errors but got: %v, %v", err, response.Error)
+	}
+
+	tree := CategoryTree{}
+
+	err = json.Unmarshal([]byte(response.Data), &tree)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(tree.Categories) != 1 || tree.Categories[0].Name != "Electronics" {
+		t.Fatalf("expected one root category but got: %v", tree.Categories)
+	}
+
+	electronics := tree.Categories[0]
+	if len(electronics.Children) != 2 {
+		t.Fatalf("expected 2 children categories but got: %v", electronics.Children)
+	}
+
+	phones := electronics.Children[0]
+	if phones.Name != "Phones" || phones.IRI != server.URL+"/catalog/phones/?utm_source=menu" {
+		t.Errorf("expected '%v' but got '%v'", "Phones", phones)
+	}
+
+	computers := electronics.Children[1]
+	if computers.Name != "Computers" || computers.IRI != "" || len(computers.Children) != 1 {
+		t.Errorf("expected group of computers but got '%v'", computers)
+	}
+
+	expectedDrafts := []Draft{
+		{Breadcrumbs: []string{"Electronics", "Phones"},
+			PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{Path: "/catalog/phones/?utm_source=menu", ItemSelector: ".product"}}},
+		{Breadcrumbs: []string{"Electronics", "Computers", "Tablets"},
+			PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{Path: "/catalog/tablets/", ItemSelector: ".product"}}}}
+
+	if fmt.Sprint(tree.Drafts) != fmt.Sprint(expectedDrafts) {
+		t.Errorf("expected '%v' but got '%v'", expectedDrafts, tree.Drafts)
+	}
+
+	request.AllCategories = true
+
+	tree, err = discoverCategories(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(tree.Drafts) != 3 || tree.Drafts[0].PageInstruction.Path != "/catalog/electronics/" {
+		t.Errorf("expected drafts of all categories but got: %v", tree.Drafts)
+	}
+}
+
+func TestCategoriesCanNotBeDiscoveredWithoutMenu(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<html><body><p>No menu</p></body></html>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	_, err := discoverCategories(Request{CompanyIRI: server.URL, Menu: Menu{CategorySelector: "nav li"}})
+	if err != ErrCategoriesAreNotFound {
+		t.Errorf("expected '%v' but got '%v'", ErrCategoriesAreNotFound, err)
+	}
+
+	_, err = discoverCategories(Request{CompanyIRI: server.URL})
+	if err != ErrCategorySelectorCanNotBeEmpty {
+		t.Errorf("expected '%v' but got '%v'", ErrCategorySelectorCanNotBeEmpty, err)
+	}
+}
@@ -0,0 +1,184 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"golang.org/x/net/html"
+	"net/url"
+	"strings"
+)
+
+// DefaultLinkSelector is a selector of link of category inside of category element
+const DefaultLinkSelector = "a"
+
+// menuNode is a category of menu with children categories which are found inside of it
+type menuNode struct {
+	category Category
+	children []*menuNode
+}
+
+// categoriesOf return tree of categories of menu of page. Parent of category is the nearest
+// category element which contains it. Links are resolved by URL of page, links of other hosts,
+// links without path and repeated links are skipped, so the second copy of menu, like a mobile one,
+// is skipped too. Categories without links are kept only as groups of children categories.
+func categoriesOf(page *html.Node, menu Menu, pageURL *url.URL) []Category {
+	roots := []*html.Node{page}
+	if menu.MenuSelector != "" {
+		roots = selectors.Find(page, menu.MenuSelector)
+	}
+
+	linkSelector := menu.LinkSelector
+	if linkSelector == "" {
+		linkSelector = DefaultLinkSelector
+	}
+
+	var elements []*html.Node
+	isCategory := map[*html.Node]bool{}
+
+	for _, root := range roots {
+		for _, element := range selectors.Find(root, menu.CategorySelector) {
+			if !isCategory[element] {
+				isCategory[element] = true
+				elements = append(elements, element)
+			}
+		}
+	}
+
+	var tree []*menuNode
+	nodes := map[*html.Node]*menuNode{}
+	seen := map[string]bool{}
+
+	for _, element := range elements {
+		category := Category{}
+
+		links := selectors.Find(element, linkSelector)
+		if len(links) > 0 && nearest(links[0], func(node *html.Node) bool { return isCategory[node] }) == element {
+			category.IRI = categoryIRI(selectors.AttributeOf(links[0], "href"), pageURL)
+			category.Name = textOf(links[0])
+		}
+
+		if menu.NameSelector != "" {
+			if names := selectors.Find(element, menu.NameSelector); len(names) > 0 {
+				category.Name = textOf(names[0])
+			}
+		}
+
+		if category.Name == "" {
+			category.Name = ownTextOf(element, isCategory)
+		}
+
+		if category.IRI != "" {
+			if seen[category.IRI] {
+				continue
+			}
+
+			seen[category.IRI] = true
+			category.Path = pathOf(category.IRI)
+		}
+
+		node := &menuNode{category: category}
+		nodes[element] = node
+
+		parent := nodes[nearest(element.Parent, func(node *html.Node) bool { return nodes[node] != nil })]
+		if parent == nil {
+			tree = append(tree, node)
+			continue
+		}
+
+		parent.children = append(parent.children, node)
+	}
+
+	return categoriesOfNodes(tree)
+}
+
+// nearest return node or the nearest ancestor of it which is matched
+func nearest(node *html.Node, matched func(node *html.Node) bool) *html.Node {
+	for ; node != nil; node = node.Parent {
+		if matched(node) {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// categoriesOfNodes return categories of menu nodes, categories without links and children are skipped
+func categoriesOfNodes(nodes []*menuNode) []Category {
+	var categories []Category
+
+	for _, node := range nodes {
+		category := node.category
+		category.Children = categoriesOfNodes(node.children)
+
+		if category.IRI == "" && len(category.Children) == 0 {
+			continue
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories
+}
+
+// ownTextOf return text of element without texts of categories inside of it
+func ownTextOf(element *html.Node, isCategory map[*html.Node]bool) string {
+	var words []string
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node != element && isCategory[node] {
+			return
+		}
+
+		if node.Type == html.TextNode {
+			words = append(words, strings.Fields(node.Data)...)
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+
+	walk(element)
+
+	return strings.Join(words, " ")
+}
+
+// categoryIRI resolve link of category by URL of page without fragment.
+// Empty string is returned for links of other hosts, scripts and anchors of page.
+func categoryIRI(link string, pageURL *url.URL) string {
+	if link == "" || strings.HasPrefix(link, "#") {
+		return ""
+	}
+
+	reference, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	resolved := pageURL.ResolveReference(reference)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+
+	if !strings.EqualFold(strings.TrimPrefix(resolved.Host, "www."), strings.TrimPrefix(pageURL.Host, "www.")) {
+		return ""
+	}
+
+	resolved.Fragment = ""
+
+	if resolved.Path == "" || resolved.Path == "/" && resolved.RawQuery == "" {
+		return ""
+	}
+
+	return resolved.String()
+}
+
+// pathOf return path of IRI with query, like "/catalog/phones/?sort=price"
+func pathOf(iri string) string {
+	parsedIRI, err := url.Parse(iri)
+	if err != nil {
+		return iri
+	}
+
+	return parsedIRI.RequestURI()
+}
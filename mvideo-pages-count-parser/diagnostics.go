@@ -0,0 +1,55 @@
+package function
+
+import "fmt"
+
+// DiagnosticsSamplesCount is a count of texts of matched elements saved as samples
+const DiagnosticsSamplesCount = 5
+
+// Diagnostics is a report of count of pages by PageInPaginationSelector.
+// Failures contains texts of matched elements which are not a number.
+// Strategy is a pagination strategy which counted pages.
+type Diagnostics struct {
+	IRI        string
+	StatusCode int
+	Selector   string
+	Matched    int
+	Samples    []string
+	Failures   []string
+	Strategy   string
+	PagesCount int
+	Warnings   []string
+}
+
+// pagesCountDiagnose count pages by request and return report of PageInPaginationSelector
+func pagesCountDiagnose(request Request) (Diagnostics, error) {
+	diagnostics := Diagnostics{
+		IRI:      request.IRI,
+		Selector: request.Instructions.PageInPaginationSelector}
+
+	pagesCount, err := countPages(
+		request.IRI, request.Instructions, request.City, request.Politeness, request.Fixtures, request.Cache, &diagnostics)
+	if err != nil && err != ErrPagesCountCanNotBeFound && err != ErrPaginationIsNotFound {
+		return diagnostics, err
+	}
+
+	diagnostics.PagesCount = pagesCount
+
+	switch {
+	case diagnostics.Selector == "":
+		diagnostics.Warnings = append(diagnostics.Warnings, "pageInPaginationSelector is not set")
+	case diagnostics.Matched == 0:
+		diagnostics.Warnings = append(diagnostics.Warnings,
+			fmt.Sprintf("pageInPaginationSelector: %v matched nothing", diagnostics.Selector))
+	}
+
+	return diagnostics, nil
+}
+
+// addMatch count matched element and save text of it as sample
+func (diagnostics *Diagnostics) addMatch(text string) {
+	diagnostics.Matched++
+
+	if len(diagnostics.Samples) < DiagnosticsSamplesCount {
+		diagnostics.Samples = append(diagnostics.Samples, text)
+	}
+}
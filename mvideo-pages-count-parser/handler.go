@@ -4,17 +4,52 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gocolly/colly"
-	"strconv"
+	"github.com/hecatoncheir/Functions/shared/cache"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/fixtures"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"net/http"
 )
 
-// Instructions is a structure of settings for pages count parse
-type Instructions struct {
-	PageInPaginationSelector string `json:"pageInPaginationSelector,omitempty"`
+// ErrCityCanNotBeWithoutCode means that city can't be applied to page without code of it for shop
+var ErrCityCanNotBeWithoutCode = crawl.ErrCityCanNotBeWithoutCode
+
+// City for count of pages.
+// Code is a value of city for shop from facet of has_city edge of instruction.
+type City struct {
+	ID, Name, Code string
 }
 
+// Request for count of pages by Instructions of page instruction.
+// Selectors are CSS selectors or XPath expressions with selectors.XPathPrefix, like "xpath://div[@class='pagination']/a".
+// PaginationStrategies are tried in order until one of them count pages,
+// StrategyMaxNumber is used if they are empty.
+// PageParamPath is used by StrategyLastPageLink for read of number of page from link.
+// ItemsPerPage is optional for StrategyTotalItems, count of ItemSelector elements is used if it is zero.
+// If Diagnostics is true, report of PageInPaginationSelector is returned instead of count of pages.
+// Politeness is optional, defaults are used for empty values.
+// Fixtures is optional, pages are recorded to directory or replayed from it by mode of it.
+// Cache is optional, pages are saved to directory of it and revalidated by ETag and Last-Modified,
+// pages are counted by cached page if it is not modified.
 type Request struct {
 	IRI          string
-	Instructions Instructions
+	Instructions entities.PageInstruction
+	City         City
+	Diagnostics  bool
+	Politeness   crawl.Politeness
+	Fixtures     Fixtures
+	Cache        Cache
+}
+
+// Cache is a directory of cache of pages
+type Cache struct {
+	Directory string
+}
+
+// Fixtures is a mode of fixtures: "record" or "replay", and directory of them
+type Fixtures struct {
+	Mode, Directory string
 }
 
 type Response struct{ Message, Data, Error string }
@@ -29,7 +64,15 @@ func Handle(req []byte) string {
 		fmt.Println(warning)
 	}
 
-	pagesCount, err := getPagesCount(request.IRI, request.Instructions)
+	var result interface{}
+
+	if request.Diagnostics {
+		result, err = pagesCountDiagnose(request)
+	} else {
+		result, err = getPagesCount(
+			request.IRI, request.Instructions, request.City, request.Politeness, request.Fixtures, request.Cache)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"Get count of pages error by IRI: %v. Error: %v",
@@ -51,12 +94,12 @@ func Handle(req []byte) string {
 		return string(encodedResponse)
 	}
 
-	encodedPagesCount, err := json.Marshal(pagesCount)
+	encodedResult, err := json.Marshal(result)
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	response := Response{Data: string(encodedPagesCount)}
+	response := Response{Data: string(encodedResult)}
 
 	encodedResponse, err := json.Marshal(response)
 	if err != nil {
@@ -66,23 +109,85 @@ func Handle(req []byte) string {
 	return string(encodedResponse)
 }
 
-func getPagesCount(pageIRI string, instructions Instructions) (pagesCount int, err error) {
-	collector := colly.NewCollector(colly.Async(true))
+func getPagesCount(pageIRI string, instructions entities.PageInstruction, city City, politeness crawl.Politeness, fixturesOfPages Fixtures, cacheOfPages Cache) (int, error) {
+	return countPages(pageIRI, instructions, city, politeness, fixturesOfPages, cacheOfPages, nil)
+}
+
+// countPages visit page and get count of pages, report of selector is written to diagnostics if it is not nil
+func countPages(pageIRI string, instructions entities.PageInstruction, city City, politeness crawl.Politeness, fixturesOfPages Fixtures, cacheOfPages Cache, diagnostics *Diagnostics) (int, error) {
+	strategies, err := strategiesOf(instructions)
+	if err != nil {
+		return 0, err
+	}
 
-	collector.OnHTML(instructions.PageInPaginationSelector,
-		func(element *colly.HTMLElement) {
-			pagesCount, err = strconv.Atoi(element.Text)
+	// htmlquery panics on invalid XPath expression, so XPath selectors are checked before count
+	err = selectors.CompileXPaths(
+		instructions.PageInPaginationSelector,
+		instructions.LastPageSelector,
+		instructions.NextPageSelector,
+		instructions.TotalItemsSelector,
+		instructions.ItemSelector)
+	if err != nil {
+		return 0, err
+	}
+
+	var wrappers []func(http.RoundTripper) http.RoundTripper
+
+	if cacheOfPages.Directory != "" {
+		wrappers = append(wrappers, func(transport http.RoundTripper) http.RoundTripper {
+			return &cache.Transport{
+				Directory: cacheOfPages.Directory,
+				Transport: transport,
+				Cookies:   []string{instructions.CityInCookieKey}}
+		})
+	}
+
+	// Fixtures wrap cache, so recorded pages are the same as parsed pages
+	if fixturesOfPages.Mode != "" {
+		wrap, err := fixtures.Wrap(fixturesOfPages.Mode, fixturesOfPages.Directory)
+		if err != nil {
+			return 0, err
+		}
+
+		wrappers = append(wrappers, wrap)
+	}
 
-			if err != nil {
-				warning := fmt.Sprintf(
-					"Get count of pages from: %v failed with response: %v. Error: %v",
-					element.Request.URL,
-					element.Response.Body,
-					err)
+	collector, failedRequests, err := crawl.NewCollector(politeness, wrappers...)
+	if err != nil {
+		return 0, err
+	}
 
-				fmt.Println(warning)
-			}
+	if diagnostics != nil {
+		collector.OnResponse(func(response *colly.Response) {
+			diagnostics.StatusCode = response.StatusCode
 		})
+	}
+
+	pageIRI, err = crawl.ApplyCity(collector, pageIRI, crawl.CitySettings{
+		ParamPath:   instructions.CityParamPath,
+		InCookieKey: instructions.CityInCookieKey,
+		IDForCookie: instructions.CityIDForCookie}, city.ID, city.Code)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Error set city: %v for URL: %v. Error: %v",
+			city,
+			pageIRI,
+			err)
+
+		fmt.Println(warning)
+
+		return 0, err
+	}
+
+	pagination := &pagination{
+		instructions:   instructions,
+		collector:      collector,
+		failedRequests: failedRequests,
+		diagnostics:    diagnostics}
+
+	collector.OnHTML("html", func(page *colly.HTMLElement) {
+		pagination.page = page
+	})
 
 	collector.OnError(func(response *colly.Response, err error) {
 		warning := fmt.Sprintf(
@@ -92,6 +197,11 @@ func getPagesCount(pageIRI string, instructions Instructions) (pagesCount int, e
 			err)
 
 		fmt.Println(warning)
+
+		if diagnostics != nil {
+			diagnostics.StatusCode = response.StatusCode
+			diagnostics.Warnings = append(diagnostics.Warnings, warning)
+		}
 	})
 
 	err = collector.Visit(pageIRI)
@@ -108,5 +218,14 @@ func getPagesCount(pageIRI string, instructions Instructions) (pagesCount int, e
 
 	collector.Wait()
 
-	return pagesCount, nil
+	err = failedRequests.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	if pagination.page == nil {
+		return 0, ErrPaginationIsNotFound
+	}
+
+	return pagination.count(pagination.page, strategies)
 }
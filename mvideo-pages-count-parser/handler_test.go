@@ -3,9 +3,16 @@ package function
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/fixtures"
+	"github.com/hecatoncheir/Storage"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -30,8 +37,8 @@ func TestParserCanParsePagesCount(t *testing.T) {
 
 	request := Request{
 		IRI: fmt.Sprint(server.URL, "/test"),
-		Instructions: Instructions{
-			PageInPaginationSelector: ".c-pagination > .c-pagination__num"},
+		Instructions: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			PageInPaginationSelector: ".c-pagination > .c-pagination__num"}},
 	}
 
 	bytes, err := json.Marshal(request)
@@ -59,3 +66,345 @@ func TestParserCanParsePagesCount(t *testing.T) {
 		t.Errorf("expected '%d' but got '%d'", expectedPagesCount, pagesCount)
 	}
 }
+
+func TestParserCanParsePagesCountForCity(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		pagesCount := "1"
+
+		cookie, err := r.Cookie("CITY_ID")
+		if err == nil && cookie.Value == "spb" {
+			pagesCount = "7"
+		}
+
+		w.WriteHeader(200)
+		_, err = w.Write([]byte(`<div class="pagination"><a class="page">` + pagesCount + `</a></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/test"),
+		Instructions: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			PageInPaginationSelector: ".pagination .page",
+			CityInCookieKey:          "CITY_ID"}},
+		City: City{ID: "0x12", Code: "spb"},
+	}
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	var response Response
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	var pagesCount int
+	err = json.Unmarshal([]byte(response.Data), &pagesCount)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if pagesCount != 7 {
+		t.Errorf("expected '%d' but got '%d'", 7, pagesCount)
+	}
+
+	_, err = getPagesCount(request.IRI, request.Instructions, City{ID: "0x12"}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != ErrCityCanNotBeWithoutCode {
+		t.Errorf("expected '%v' but got '%v'", ErrCityCanNotBeWithoutCode, err)
+	}
+}
+
+func TestParserCanDiagnosePaginationSelector(t *testing.T) {
+	testFileContent, err := ioutil.ReadFile("handler_test_page.html")
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write(testFileContent)
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/test"),
+		Instructions: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			PageInPaginationSelector: ".c-pagination > .c-pagination__num"}},
+		Diagnostics: true}
+
+	diagnostics, err := pagesCountDiagnose(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if diagnostics.StatusCode != 200 || diagnostics.PagesCount != 68 {
+		t.Errorf("expected '%v' but got '%v'", "status 200 and 68 pages", diagnostics)
+	}
+
+	if diagnostics.Matched == 0 || len(diagnostics.Samples) == 0 || len(diagnostics.Warnings) != 0 {
+		t.Errorf("expected matched elements but got '%v'", diagnostics)
+	}
+
+	request.Instructions.PageInPaginationSelector = ".pagination"
+
+	diagnostics, err = pagesCountDiagnose(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if diagnostics.Matched != 0 || len(diagnostics.Warnings) != 1 {
+		t.Errorf("expected warning but got '%v'", diagnostics)
+	}
+}
+
+func TestParserReturnErrorOfFailedRequest(t *testing.T) {
+	requestsCount := 0
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		requestsCount++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/test"),
+		Instructions: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			PageInPaginationSelector: ".c-pagination > .c-pagination__num"}},
+		Politeness: crawl.Politeness{Retries: 2, RetryDelay: 1}}
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	var response Response
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if !strings.HasPrefix(response.Error, crawl.ErrPageCanNotBeLoaded.Error()) {
+		t.Errorf("expected '%v' but got '%v'", crawl.ErrPageCanNotBeLoaded, response.Error)
+	}
+
+	if requestsCount != 3 {
+		t.Errorf("expected '%d' but got '%d'", 3, requestsCount)
+	}
+}
+
+func TestParserCanRecordAndReplayPages(t *testing.T) {
+	testFileContent, err := ioutil.ReadFile("handler_test_page.html")
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write(testFileContent)
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+
+	directory, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer os.RemoveAll(directory)
+
+	instructions := entities.PageInstruction{PageInstruction: storage.PageInstruction{PageInPaginationSelector: ".c-pagination > .c-pagination__num"}}
+
+	_, err = getPagesCount(fmt.Sprint(server.URL, "/test"), instructions, City{}, crawl.Politeness{},
+		Fixtures{Mode: fixtures.ModeRecord, Directory: directory}, Cache{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	server.Close()
+
+	pagesCount, err := getPagesCount(fmt.Sprint(server.URL, "/test"), instructions, City{}, crawl.Politeness{},
+		Fixtures{Mode: fixtures.ModeReplay, Directory: directory}, Cache{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if pagesCount != 68 {
+		t.Errorf("expected '%d' but got '%d'", 68, pagesCount)
+	}
+}
+
+func TestParserCanParsePagesCountByXPath(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="pages"><a>1</a><a>2</a><a>12</a><a>Next</a></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	instructions := entities.PageInstruction{PageInstruction: storage.PageInstruction{PageInPaginationSelector: "xpath://div[@class='pages']/a[last()-1]"}}
+
+	pagesCount, err := getPagesCount(fmt.Sprint(server.URL, "/test"), instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if pagesCount != 12 {
+		t.Errorf("expected '%d' but got '%d'", 12, pagesCount)
+	}
+
+	instructions.PageInPaginationSelector = "xpath://div["
+
+	_, err = getPagesCount(fmt.Sprint(server.URL, "/test"), instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != ErrSelectorCanNotBeCompiled {
+		t.Errorf("expected '%v' but got '%v'", ErrSelectorCanNotBeCompiled, err)
+	}
+}
+
+func TestParserCanParsePagesCountByStrategies(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+
+		next := ""
+		if page != "3" {
+			number, _ := strconv.Atoi(page)
+			next = fmt.Sprintf(`<a class="next" href="/catalog?page=%d">Next</a>`, number+1)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(fmt.Sprintf(`
+			<div class="found">Найдено 1 234 товара</div>
+			<div class="item"></div><div class="item"></div>
+			<div class="pages">
+				<a href="/catalog?page=1">1</a><a href="/catalog?page=2">2</a><span>…</span>
+				<a class="last" href="/catalog?page=12">12</a>%v
+			</div>`, next)))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		instructions entities.PageInstruction
+		expected     int
+	}{
+		{
+			instructions: entities.PageInstruction{PageInstruction: storage.PageInstruction{PageInPaginationSelector: ".pages > *"}},
+			expected:     12},
+		{
+			instructions: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{
+					PageParamPath: "?page="},
+				PaginationStrategies: []string{StrategyLastPageLink},
+				LastPageSelector:     ".pages a.last"},
+			expected: 12},
+		{
+			instructions: entities.PageInstruction{
+				PaginationStrategies: []string{StrategyNextLink},
+				NextPageSelector:     "xpath://a[@class='next']"},
+			expected: 3},
+		{
+			instructions: entities.PageInstruction{
+				PaginationStrategies: []string{StrategyTotalItems},
+				TotalItemsSelector:   ".found",
+				ItemsPerPage:         24},
+			expected: 52},
+		{
+			instructions: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{
+					ItemSelector: ".item"},
+				PaginationStrategies: []string{StrategyTotalItems},
+				TotalItemsSelector:   ".found"},
+			expected: 617},
+		{
+			instructions: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{
+					PageInPaginationSelector: ".pages a.last",
+					PageParamPath:            "?page="},
+				PaginationStrategies: []string{StrategyMaxNumber, StrategyLastPageLink}},
+			expected: 12},
+		{
+			instructions: entities.PageInstruction{
+				TotalItemsSelector: ".found",
+				ItemsPerPage:       24},
+			expected: 52},
+		{
+			instructions: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{
+					PageParamPath: "?page="},
+				LastPageSelector: ".pages a.last"},
+			expected: 12},
+	}
+
+	for _, test := range tests {
+		pagesCount, err := getPagesCount(fmt.Sprint(server.URL, "/catalog"), test.instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if pagesCount != test.expected {
+			t.Errorf("expected '%d' but got '%d' for '%v'", test.expected, pagesCount, test.instructions)
+		}
+	}
+
+	instructions := entities.PageInstruction{PageInstruction: storage.PageInstruction{PageInPaginationSelector: ".pages a.next"}}
+
+	_, err := getPagesCount(fmt.Sprint(server.URL, "/catalog"), instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != ErrPagesCountCanNotBeFound {
+		t.Errorf("expected '%v' but got '%v'", ErrPagesCountCanNotBeFound, err)
+	}
+
+	instructions = entities.PageInstruction{PageInstruction: storage.PageInstruction{PageInPaginationSelector: ".catalog-pagination a"}}
+
+	_, err = getPagesCount(fmt.Sprint(server.URL, "/catalog"), instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != ErrPaginationIsNotFound {
+		t.Errorf("expected '%v' but got '%v'", ErrPaginationIsNotFound, err)
+	}
+
+	instructions.PaginationStrategies = []string{"first_page"}
+
+	_, err = getPagesCount(fmt.Sprint(server.URL, "/catalog"), instructions, City{}, crawl.Politeness{}, Fixtures{}, Cache{})
+	if err != ErrPaginationStrategyIsUnknown {
+		t.Errorf("expected '%v' but got '%v'", ErrPaginationStrategyIsUnknown, err)
+	}
+}
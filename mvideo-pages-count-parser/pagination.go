@@ -0,0 +1,311 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// StrategyMaxNumber take maximum number of texts of PageInPaginationSelector elements,
+	// texts which are not a number, like "…" or "Next", are skipped
+	StrategyMaxNumber = "max_number"
+
+	// StrategyLastPageLink take page param of href of LastPageSelector elements,
+	// PageInPaginationSelector elements are used if LastPageSelector is empty
+	StrategyLastPageLink = "last_page_link"
+
+	// StrategyNextLink follow href of NextPageSelector element until it runs out and count visited pages
+	StrategyNextLink = "next_link"
+
+	// StrategyTotalItems divide number of TotalItemsSelector element by ItemsPerPage
+	// or by count of ItemSelector elements on first page
+	StrategyTotalItems = "total_items"
+
+	// MaxFollowedPages is a limit of pages visited by StrategyNextLink
+	MaxFollowedPages = 1000
+)
+
+var (
+	// ErrPagesCountCanNotBeFound means that pagination is found on page but no one strategy can count pages by it
+	ErrPagesCountCanNotBeFound = errors.New("pages count can not be found")
+
+	// ErrPaginationIsNotFound means that no one strategy matched something on page, so page has no pagination
+	ErrPaginationIsNotFound = errors.New("pagination is not found")
+
+	// ErrPaginationStrategyIsUnknown means that strategy of instructions is not one of strategies of parser
+	ErrPaginationStrategyIsUnknown = errors.New("pagination strategy is unknown")
+)
+
+var numberPattern = regexp.MustCompile(`\d+`)
+
+// groupedNumberPattern match number with groups of digits separated by spaces, like "1 234"
+var groupedNumberPattern = regexp.MustCompile(`\d+(?:[ \x{00a0}\x{202f}]\d{3})*`)
+
+// pagination count pages of category by strategies of instructions
+type pagination struct {
+	instructions   entities.PageInstruction
+	collector      *colly.Collector
+	failedRequests *crawl.Failures
+	diagnostics    *Diagnostics
+
+	// page is a last visited page
+	page *colly.HTMLElement
+}
+
+// strategiesOf return strategies of instructions. If they are empty, strategies of set selectors
+// are returned, StrategyMaxNumber is default for instructions without selectors of strategies.
+func strategiesOf(instructions entities.PageInstruction) ([]string, error) {
+	if len(instructions.PaginationStrategies) == 0 {
+		var strategies []string
+
+		if instructions.PageInPaginationSelector != "" {
+			strategies = append(strategies, StrategyMaxNumber)
+		}
+
+		if instructions.LastPageSelector != "" {
+			strategies = append(strategies, StrategyLastPageLink)
+		}
+
+		if instructions.NextPageSelector != "" {
+			strategies = append(strategies, StrategyNextLink)
+		}
+
+		if instructions.TotalItemsSelector != "" {
+			strategies = append(strategies, StrategyTotalItems)
+		}
+
+		if len(strategies) == 0 {
+			return []string{StrategyMaxNumber}, nil
+		}
+
+		return strategies, nil
+	}
+
+	for _, strategy := range instructions.PaginationStrategies {
+		switch strategy {
+		case StrategyMaxNumber, StrategyLastPageLink, StrategyNextLink, StrategyTotalItems:
+		default:
+			return nil, ErrPaginationStrategyIsUnknown
+		}
+	}
+
+	return instructions.PaginationStrategies, nil
+}
+
+// count try strategies in order and return count of pages of first succeeded strategy.
+// ErrPaginationIsNotFound is returned if no one strategy matched something on page.
+func (pagination *pagination) count(firstPage *colly.HTMLElement, strategies []string) (int, error) {
+	anyMatched := false
+
+	for _, strategy := range strategies {
+		var pagesCount int
+		var matched bool
+		var err error
+
+		switch strategy {
+		case StrategyMaxNumber:
+			pagesCount, matched = pagination.maxNumber(firstPage)
+		case StrategyLastPageLink:
+			pagesCount, matched = pagination.lastPageLink(firstPage)
+		case StrategyNextLink:
+			pagesCount, matched, err = pagination.nextLink(firstPage)
+		case StrategyTotalItems:
+			pagesCount, matched = pagination.totalItems(firstPage)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if pagesCount > 0 {
+			if pagination.diagnostics != nil {
+				pagination.diagnostics.Strategy = strategy
+			}
+
+			return pagesCount, nil
+		}
+
+		if matched {
+			anyMatched = true
+
+			warning := fmt.Sprintf("Strategy: %v can not count pages of: %v", strategy, firstPage.Request.URL)
+
+			fmt.Println(warning)
+
+			if pagination.diagnostics != nil {
+				pagination.diagnostics.Warnings = append(pagination.diagnostics.Warnings, warning)
+			}
+		}
+	}
+
+	if anyMatched {
+		return 0, ErrPagesCountCanNotBeFound
+	}
+
+	return 0, ErrPaginationIsNotFound
+}
+
+func (pagination *pagination) maxNumber(page *colly.HTMLElement) (int, bool) {
+	elements := selectors.Find(page.DOM.Nodes[0], pagination.instructions.PageInPaginationSelector)
+
+	maxNumber := 0
+
+	for _, element := range elements {
+		text := textOf(element)
+
+		number, err := strconv.Atoi(text)
+
+		if pagination.diagnostics != nil {
+			pagination.diagnostics.addMatch(text)
+
+			if err != nil {
+				pagination.diagnostics.Failures = append(pagination.diagnostics.Failures, text)
+			}
+		}
+
+		if err == nil && number > maxNumber {
+			maxNumber = number
+		}
+	}
+
+	return maxNumber, len(elements) > 0
+}
+
+func (pagination *pagination) lastPageLink(page *colly.HTMLElement) (int, bool) {
+	selector := pagination.instructions.LastPageSelector
+	if selector == "" {
+		selector = pagination.instructions.PageInPaginationSelector
+	}
+
+	elements := selectors.Find(page.DOM.Nodes[0], selector)
+
+	maxNumber := 0
+
+	for _, element := range elements {
+		number := pageNumberOf(selectors.AttributeOf(element, "href"), pagination.instructions.PageParamPath)
+		if number > maxNumber {
+			maxNumber = number
+		}
+	}
+
+	return maxNumber, len(elements) > 0
+}
+
+// pageNumberOf return number of page from link by PageParamPath, like "?page=" or "/page-".
+// Last number of path of link is used if PageParamPath is empty.
+func pageNumberOf(link, pageParamPath string) int {
+	parsedLink, err := url.Parse(link)
+	if err != nil || link == "" {
+		return 0
+	}
+
+	numberText := ""
+
+	paramName := strings.TrimSuffix(strings.TrimLeft(pageParamPath, "?&"), "=")
+
+	switch {
+	case pageParamPath == "":
+		numbers := numberPattern.FindAllString(parsedLink.Path, -1)
+		if len(numbers) > 0 {
+			numberText = numbers[len(numbers)-1]
+		}
+
+	case paramName != "" && parsedLink.Query().Get(paramName) != "":
+		numberText = parsedLink.Query().Get(paramName)
+
+	case strings.Contains(link, pageParamPath):
+		rest := link[strings.LastIndex(link, pageParamPath)+len(pageParamPath):]
+		numberText = numberPattern.FindString(rest)
+		if !strings.HasPrefix(rest, numberText) {
+			numberText = ""
+		}
+	}
+
+	number, err := strconv.Atoi(numberText)
+	if err != nil {
+		return 0
+	}
+
+	return number
+}
+
+// nextLink visit pages by next links, count of visited pages with first page is returned.
+// Links are visited once, so loop of links is stopped too.
+func (pagination *pagination) nextLink(firstPage *colly.HTMLElement) (int, bool, error) {
+	page := firstPage
+	pagesCount := 1
+
+	for pagesCount < MaxFollowedPages {
+		links := selectors.Find(page.DOM.Nodes[0], pagination.instructions.NextPageSelector)
+		if len(links) == 0 {
+			break
+		}
+
+		link := selectors.AttributeOf(links[0], "href")
+		if link == "" {
+			break
+		}
+
+		pagination.page = nil
+
+		err := pagination.collector.Visit(page.Request.AbsoluteURL(link))
+		if err != nil {
+			break
+		}
+
+		pagination.collector.Wait()
+
+		err = pagination.failedRequests.Err()
+		if err != nil {
+			return 0, true, err
+		}
+
+		if pagination.page == nil {
+			break
+		}
+
+		page = pagination.page
+		pagesCount++
+	}
+
+	if pagesCount == 1 {
+		return 0, false, nil
+	}
+
+	return pagesCount, true, nil
+}
+
+func (pagination *pagination) totalItems(page *colly.HTMLElement) (int, bool) {
+	elements := selectors.Find(page.DOM.Nodes[0], pagination.instructions.TotalItemsSelector)
+	if len(elements) == 0 {
+		return 0, false
+	}
+
+	totalText := groupedNumberPattern.FindString(textOf(elements[0]))
+	totalText = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "").Replace(totalText)
+
+	total, err := strconv.Atoi(totalText)
+	if err != nil {
+		return 0, true
+	}
+
+	itemsPerPage := pagination.instructions.ItemsPerPage
+	if itemsPerPage <= 0 {
+		itemsPerPage = len(selectors.Find(page.DOM.Nodes[0], pagination.instructions.ItemSelector))
+	}
+
+	if itemsPerPage <= 0 {
+		return 0, true
+	}
+
+	return int(math.Ceil(float64(total) / float64(itemsPerPage))), true
+}
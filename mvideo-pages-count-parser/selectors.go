@@ -0,0 +1,16 @@
+package function
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// ErrSelectorCanNotBeCompiled means that XPath of selector is not a valid expression
+var ErrSelectorCanNotBeCompiled = selectors.ErrSelectorCanNotBeCompiled
+
+// textOf return text of element without spaces around
+func textOf(node *html.Node) string {
+	return strings.TrimSpace(goquery.NewDocumentFromNode(node).Text())
+}
@@ -0,0 +1,15 @@
+package function
+
+func init() {
+	registerAdapter(mvideoAdapter{}, "mvideo.ru")
+}
+
+// mvideoAdapter is an adapter of M.Video. Images of tiles are lazy loaded from data-original,
+// which is first of DefaultImageAttributes, and prices have "¤" sign of font of ruble, which is cut before normalization.
+type mvideoAdapter struct{ noAdapter }
+
+func (mvideoAdapter) Request(request *Request) {
+	if request.PricePattern == "" {
+		request.PricePattern = "[ ¤]*"
+	}
+}
@@ -0,0 +1,71 @@
+package function
+
+import (
+	"golang.org/x/net/html"
+	"net/url"
+	"strings"
+)
+
+// Adapter is a cleanup of pages of one shop, like bundle names, marketing prefixes of names
+// or prices split across several elements. Adapters are registered by hosts of shops
+// in init of their files, like adapter_mvideo.go, so parser has no code of shops.
+type Adapter interface {
+	// Request set defaults of shop to empty settings of request
+	Request(request *Request)
+
+	// Item change texts of item of page before normalization of price and links.
+	// Node is an element of ItemSelector, it is nil for JSON pages and items of structured data.
+	Item(itemOfPage *item, node *html.Node)
+
+	// Product change product before it is returned
+	Product(product *Product)
+}
+
+// noAdapter change nothing, adapters embed it for hooks which they don't need
+type noAdapter struct{}
+
+func (noAdapter) Request(request *Request)               {}
+func (noAdapter) Item(itemOfPage *item, node *html.Node) {}
+func (noAdapter) Product(product *Product)               {}
+
+// adapters of shops by hosts
+var adapters = map[string]Adapter{}
+
+// registerAdapter add adapter for hosts of shop, it panics if host already has adapter
+func registerAdapter(adapter Adapter, hosts ...string) {
+	for _, host := range hosts {
+		host = strings.ToLower(host)
+
+		if _, registered := adapters[host]; registered {
+			panic("adapter of host is already registered: " + host)
+		}
+
+		adapters[host] = adapter
+	}
+}
+
+// adapterOf return adapter of host of page or of parent domain of it,
+// so adapter of "shop.ru" is used for "www.shop.ru" too. noAdapter is returned if there is no one.
+func adapterOf(pageIRI string) Adapter {
+	parsedIRI, err := url.Parse(pageIRI)
+	if err != nil {
+		return noAdapter{}
+	}
+
+	host := strings.ToLower(parsedIRI.Hostname())
+
+	for host != "" {
+		if adapter, registered := adapters[host]; registered {
+			return adapter
+		}
+
+		dot := strings.Index(host, ".")
+		if dot < 0 {
+			break
+		}
+
+		host = host[dot+1:]
+	}
+
+	return noAdapter{}
+}
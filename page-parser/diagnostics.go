@@ -0,0 +1,126 @@
+package function
+
+import (
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+)
+
+// DiagnosticsSamplesCount is a count of extracted values saved as samples for every selector
+const DiagnosticsSamplesCount = 3
+
+// SelectorDiagnostics is a report of one selector of page instruction.
+// Matched is a count of elements on page for ItemSelector
+// and a count of elements in all items for other selectors.
+type SelectorDiagnostics struct {
+	Name     string
+	Selector string
+	Matched  int
+	Samples  []string
+}
+
+// PriceFailure is a price of product which can not be parsed
+type PriceFailure struct {
+	ProductName string
+	Text        string
+	Error       string
+}
+
+// Diagnostics is a report of parse of page by page instruction without save of products.
+// Warnings contains selectors which are not set or matched nothing and mismatches with structured data.
+// StructuredProductsCount is a count of products of structured data if StructuredData of request is true.
+type Diagnostics struct {
+	IRI                     string
+	StatusCode              int
+	ProductsCount           int
+	StructuredProductsCount int
+	Selectors               []SelectorDiagnostics
+	PriceFailures           []PriceFailure
+	Warnings                []string
+}
+
+// pageDiagnose parse page by request and return report of every selector of page instruction
+func pageDiagnose(request Request) (Diagnostics, error) {
+	diagnostics := Diagnostics{IRI: request.IRI}
+
+	products, err := parse(request, &diagnostics)
+	if err != nil {
+		return diagnostics, err
+	}
+
+	diagnostics.ProductsCount = len(products)
+
+	for _, selector := range diagnostics.Selectors {
+		switch {
+		case selector.Selector == "":
+			diagnostics.Warnings = append(diagnostics.Warnings,
+				fmt.Sprintf("%v is not set", selector.Name))
+		case selector.Matched == 0:
+			diagnostics.Warnings = append(diagnostics.Warnings,
+				fmt.Sprintf("%v: %v matched nothing", selector.Name, selector.Selector))
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// selectorsOf return selectors of page instruction for diagnostics, ItemSelector is first
+func selectorsOf(instruction entities.PageInstruction) []SelectorDiagnostics {
+	selectors := []SelectorDiagnostics{
+		{Name: "itemSelector", Selector: instruction.ItemSelector},
+		{Name: "nameOfItemSelector", Selector: instruction.NameOfItemSelector},
+		{Name: "linkOfItemSelector", Selector: instruction.LinkOfItemSelector},
+		{Name: "previewImageOfSelector", Selector: instruction.PreviewImageOfSelector},
+		{Name: "priceOfItemSelector", Selector: instruction.PriceOfItemSelector}}
+
+	if instruction.OldPriceOfItemSelector != "" {
+		selectors = append(selectors,
+			SelectorDiagnostics{Name: "oldPriceOfItemSelector", Selector: instruction.OldPriceOfItemSelector})
+	}
+
+	if instruction.AvailabilityOfItemSelector != "" {
+		selectors = append(selectors,
+			SelectorDiagnostics{Name: "availabilityOfItemSelector", Selector: instruction.AvailabilityOfItemSelector})
+	}
+
+	return selectors
+}
+
+// countSelectors count elements of every selector on page.
+// Selectors except ItemSelector are counted inside of items.
+func (diagnostics *Diagnostics) countSelectors(page *colly.HTMLElement) {
+	items := selectors.Find(page.DOM.Nodes[0], diagnostics.Selectors[0].Selector)
+	diagnostics.Selectors[0].Matched += len(items)
+
+	for index := range diagnostics.Selectors[1:] {
+		selector := &diagnostics.Selectors[index+1]
+
+		for _, itemNode := range items {
+			selector.Matched += len(selectors.Find(itemNode, selector.Selector))
+		}
+	}
+}
+
+// addSample save extracted value of selector with name if count of samples is less than DiagnosticsSamplesCount
+func (diagnostics *Diagnostics) addSample(name, value string) {
+	for index := range diagnostics.Selectors {
+		selector := &diagnostics.Selectors[index]
+		if selector.Name != name || selector.Selector == "" {
+			continue
+		}
+
+		if len(selector.Samples) < DiagnosticsSamplesCount {
+			selector.Samples = append(selector.Samples, value)
+		}
+	}
+}
+
+// addMatches add count of matched elements to selector with name
+func (diagnostics *Diagnostics) addMatches(name string, count int) {
+	for index := range diagnostics.Selectors {
+		if diagnostics.Selectors[index].Name == name {
+			diagnostics.Selectors[index].Matched += count
+		}
+	}
+}
@@ -0,0 +1,548 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/cache"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/fixtures"
+	"github.com/hecatoncheir/Functions/shared/prices"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Functions/shared/structured"
+	"golang.org/x/net/html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// City of prices of page.
+// Code is a value of city for shop from facet of has_city edge of instruction.
+type City struct {
+	ID, Name, Code string
+}
+
+// DefaultLinkAttribute is an attribute of LinkOfItemSelector element with link of product
+const DefaultLinkAttribute = "href"
+
+var (
+	// ErrPageInstructionCanNotBeWithoutItemSelector means that products can't be found on page without ItemSelector
+	ErrPageInstructionCanNotBeWithoutItemSelector = errors.New("page instruction can not be without item selector")
+
+	// ErrPricePatternCanNotBeCompiled means that pattern for cut price is not a valid regular expression
+	ErrPricePatternCanNotBeCompiled = errors.New("price pattern can not be compiled")
+
+	// ErrFormatOfPageIsUnknown means that format of page instruction is not html or json
+	ErrFormatOfPageIsUnknown = errors.New("format of page is unknown")
+
+	// ErrPageIsNotModified means that cached page is not modified since last parse,
+	// so products of it are the same and can be skipped
+	ErrPageIsNotModified = errors.New("page is not modified")
+
+	// ErrCityCanNotBeWithoutCode means that city can't be applied to page without code of it for shop
+	ErrCityCanNotBeWithoutCode = crawl.ErrCityCanNotBeWithoutCode
+)
+
+// Request for parse one page of shop.
+// PageInstruction is the same as read from storage-page-instruction-read-by-id.
+// Selectors of it are CSS selectors or XPath expressions with selectors.XPathPrefix, like "xpath://div[@class='item']".
+// XPath of value of item is searched from item, so it must be relative, like "xpath:.//a".
+// If Format of it is entities.FormatJSON, selectors are JSONPath expressions:
+// ItemSelector for list of items and other selectors for values of item.
+// IRI can be empty, then Path of PageInstruction will be parsed.
+// LinkAttribute is optional, DefaultLinkAttribute is used for empty value.
+// ImageAttributes is an optional chain of attributes with link of image, first not empty link is taken.
+// DefaultImageAttributes are used if it is empty.
+// Links of products and images are resolved by URL of page,
+// fragment and TrackingParams are removed from links of products.
+// PricePattern is optional too, all matches of it are cut from price before normalization.
+// City is optional too, it is sent to shop by CityParamPath or
+// CityInCookieKey of page instruction and set to every price of page.
+// Availability is a mapping of text of AvailabilityOfItemSelector element
+// to status of availability, prices.DefaultAvailability is used if it is empty.
+// If Diagnostics is true, report of every selector of page instruction is returned instead of products.
+// Politeness is optional, defaults are used for empty values.
+// Fixtures is optional, pages are recorded to directory or replayed from it by mode of it.
+// If StructuredData is true, JSON-LD, microdata and OpenGraph of page are used for values
+// which selectors of page instruction are not set for or found nothing,
+// and values which differ from them are returned in Mismatches of product.
+// ItemSelector can be empty then, all products of structured data of page are returned.
+// Cache is optional, pages are saved to directory of it and revalidated by ETag and Last-Modified.
+// Adapter of host of IRI is applied to request, items and products, see Adapter.
+type Request struct {
+	IRI             string
+	PageInstruction entities.PageInstruction
+	City            City
+	LinkAttribute   string
+	ImageAttributes []string
+	PricePattern    string
+	Availability    map[string]string
+	Diagnostics     bool
+	Politeness      crawl.Politeness
+	Fixtures        Fixtures
+	StructuredData  bool
+	Cache           Cache
+}
+
+// Cache is a directory of cache of pages. If page is not modified since it was cached,
+// ErrPageIsNotModified is returned, or products of cached page if ParseNotModified is true.
+type Cache struct {
+	Directory        string
+	ParseNotModified bool
+}
+
+// Fixtures is a mode of fixtures: "record" or "replay", and directory of them
+type Fixtures struct {
+	Mode, Directory string
+}
+
+type Response struct{ Error, Data, Message string }
+
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+		fmt.Println(warning)
+	}
+
+	if request.IRI == "" {
+		request.IRI = request.PageInstruction.Path
+	}
+
+	var result interface{}
+
+	if request.Diagnostics {
+		result, err = pageDiagnose(request)
+	} else {
+		result, err = pageParse(request)
+	}
+
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Parse page error by IRI: %v. Error: %v",
+			request.IRI,
+			err)
+
+		fmt.Println(warning)
+
+		encodedResponse := Response{
+			Message: warning,
+			Data:    string(req),
+			Error:   err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedResult, err := json.Marshal(result)
+	if err != nil {
+		encodedResponse := Response{
+			Data:  string(req),
+			Error: err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	fmt.Println(string(encodedResult))
+
+	encodedResponse := Response{Data: string(encodedResult)}
+
+	response, err := json.Marshal(encodedResponse)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return string(response)
+}
+
+// Price of product.
+// MaxValue is set for range of price, OldValue and Discount in percents
+// are set if OldPriceOfItemSelector of page instruction is found.
+// Availability is empty if AvailabilityOfItemSelector of page instruction is not set or text of it is unknown.
+// Value is zero for product with availability and price which can not be parsed.
+type Price struct {
+	Value        float64
+	MaxValue     float64
+	OldValue     float64
+	Discount     float64
+	Currency     string
+	Availability string
+	DateTime     time.Time
+	City         City
+}
+
+// Product of page.
+// SKU and GTIN are set only from structured data of page.
+type Product struct {
+	Name             string
+	IRI              string
+	PreviewImageLink string
+	SKU              string
+	GTIN             string
+	Price            Price
+	Mismatches       []Mismatch
+}
+
+// item is a texts of product extracted from page by selectors of page instruction.
+// Currency, AvailabilityStatus and structured are set from structured data of page.
+type item struct {
+	Name, IRI, PreviewImageLink, Price, OldPrice, Availability string
+	SKU, GTIN, Currency, AvailabilityStatus                    string
+	structured                                                 *structured.Product
+}
+
+func pageParse(request Request) ([]Product, error) {
+	return parse(request, nil)
+}
+
+// parse products of page by request, report of selectors is written to diagnostics if it is not nil
+func parse(request Request, diagnostics *Diagnostics) ([]Product, error) {
+	adapter := adapterOf(request.IRI)
+	adapter.Request(&request)
+
+	instruction := request.PageInstruction
+
+	if instruction.ItemSelector == "" && (!request.StructuredData || instruction.Format == entities.FormatJSON) {
+		return nil, ErrPageInstructionCanNotBeWithoutItemSelector
+	}
+
+	linkAttribute := request.LinkAttribute
+	if linkAttribute == "" {
+		linkAttribute = DefaultLinkAttribute
+	}
+
+	imageAttributes := imageAttributesOf(request)
+
+	availability := request.Availability
+	if len(availability) == 0 {
+		availability = prices.DefaultAvailability
+	}
+
+	patternForCutPrice, err := regexp.Compile(request.PricePattern)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Error compile pattern: %v for cut price for URL: %v. Error: %v",
+			request.PricePattern,
+			request.IRI,
+			err)
+
+		fmt.Println(warning)
+
+		return nil, ErrPricePatternCanNotBeCompiled
+	}
+
+	var wrappers []func(http.RoundTripper) http.RoundTripper
+
+	if request.Cache.Directory != "" {
+		wrappers = append(wrappers, func(transport http.RoundTripper) http.RoundTripper {
+			return &cache.Transport{
+				Directory: request.Cache.Directory,
+				Transport: transport,
+				Cookies:   []string{instruction.CityInCookieKey}}
+		})
+	}
+
+	// Fixtures wrap cache, so recorded pages are the same as parsed pages
+	if request.Fixtures.Mode != "" {
+		wrap, err := fixtures.Wrap(request.Fixtures.Mode, request.Fixtures.Directory)
+		if err != nil {
+			return nil, err
+		}
+
+		wrappers = append(wrappers, wrap)
+	}
+
+	collector, failedRequests, err := crawl.NewCollector(request.Politeness, wrappers...)
+	if err != nil {
+		return nil, err
+	}
+
+	notModified := false
+
+	collector.OnResponse(func(response *colly.Response) {
+		if response.Headers != nil && response.Headers.Get(cache.NotModifiedHeader) != "" {
+			notModified = true
+		}
+	})
+
+	pageIRI, err := crawl.ApplyCity(collector, request.IRI, crawl.CitySettings{
+		ParamPath:   instruction.CityParamPath,
+		InCookieKey: instruction.CityInCookieKey,
+		IDForCookie: instruction.CityIDForCookie}, request.City.ID, request.City.Code)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Error set city: %v for URL: %v. Error: %v",
+			request.City,
+			request.IRI,
+			err)
+
+		fmt.Println(warning)
+
+		return nil, err
+	}
+
+	city := City{ID: request.City.ID, Name: request.City.Name}
+
+	var productsFromPage []Product
+
+	if diagnostics != nil {
+		diagnostics.Selectors = selectorsOf(instruction)
+
+		collector.OnResponse(func(response *colly.Response) {
+			diagnostics.StatusCode = response.StatusCode
+		})
+	}
+
+	addItem := func(itemOfPage item, node *html.Node, pageURL string) {
+		if diagnostics != nil {
+			diagnostics.addSample("nameOfItemSelector", itemOfPage.Name)
+			diagnostics.addSample("linkOfItemSelector", itemOfPage.IRI)
+			diagnostics.addSample("previewImageOfSelector", itemOfPage.PreviewImageLink)
+			diagnostics.addSample("priceOfItemSelector", itemOfPage.Price)
+			diagnostics.addSample("oldPriceOfItemSelector", itemOfPage.OldPrice)
+			diagnostics.addSample("availabilityOfItemSelector", itemOfPage.Availability)
+		}
+
+		adapter.Item(&itemOfPage, node)
+
+		product := Product{
+			Name:             itemOfPage.Name,
+			IRI:              canonicalIRI(itemOfPage.IRI, pageURL),
+			PreviewImageLink: absoluteLink(itemOfPage.PreviewImageLink, pageURL),
+			SKU:              itemOfPage.SKU,
+			GTIN:             itemOfPage.GTIN}
+
+		priceOfItem := patternForCutPrice.ReplaceAllString(itemOfPage.Price, "")
+
+		price := Price{
+			DateTime: time.Now().UTC(),
+			City:     city}
+
+		normalizedPrice, err := prices.Parse(priceOfItem)
+		priceIsParsed := err == nil
+
+		if priceIsParsed {
+			price.Value = normalizedPrice.Value
+			price.MaxValue = normalizedPrice.MaxValue
+			price.Currency = normalizedPrice.Currency
+		} else {
+			if diagnostics != nil {
+				diagnostics.PriceFailures = append(diagnostics.PriceFailures,
+					PriceFailure{ProductName: itemOfPage.Name, Text: itemOfPage.Price, Error: err.Error()})
+			}
+
+			warning := fmt.Sprintf(
+				"Error get price: %v of product: %v, by IRI: %v. Error: %v",
+				itemOfPage.Price,
+				product,
+				pageURL,
+				err)
+
+			fmt.Println(warning)
+		}
+
+		if priceIsParsed && instruction.OldPriceOfItemSelector != "" {
+			oldPrice, err := prices.Parse(patternForCutPrice.ReplaceAllString(itemOfPage.OldPrice, ""))
+			if err == nil {
+				price.OldValue = oldPrice.Value
+				price.Discount = prices.Discount(price.Value, oldPrice.Value)
+			}
+		}
+
+		if instruction.AvailabilityOfItemSelector != "" {
+			price.Availability = prices.AvailabilityOf(itemOfPage.Availability, availability)
+		}
+
+		if price.Currency == "" {
+			price.Currency = strings.ToUpper(itemOfPage.Currency)
+		}
+
+		if price.Availability == "" {
+			price.Availability = itemOfPage.AvailabilityStatus
+		}
+
+		// Product without price is kept only with availability of it, like product which is out of stock
+		if !priceIsParsed && price.Availability == "" {
+			return
+		}
+
+		product.Price = price
+
+		adapter.Product(&product)
+
+		if itemOfPage.structured != nil {
+			product.Mismatches = crossCheck(product, *itemOfPage.structured)
+
+			for _, mismatch := range product.Mismatches {
+				warning := fmt.Sprintf(
+					"Structured data of product: %v has %v: %v, selector has: %v",
+					product.Name,
+					mismatch.Field,
+					mismatch.StructuredData,
+					mismatch.Selector)
+
+				fmt.Println(warning)
+
+				if diagnostics != nil {
+					diagnostics.Warnings = append(diagnostics.Warnings, warning)
+				}
+			}
+		}
+
+		info := fmt.Sprintf("Get product: %v by iri: %v", product, pageURL)
+		fmt.Println(info)
+
+		productsFromPage = append(productsFromPage, product)
+	}
+
+	switch instruction.Format {
+	case entities.FormatJSON:
+		paths, err := compilePaths(instruction)
+		if err != nil {
+			return nil, err
+		}
+
+		collector.OnResponse(func(response *colly.Response) {
+			items, err := paths.items(response.Body, diagnostics)
+			if err != nil {
+				warning := fmt.Sprintf(
+					"Error decode JSON of URL: %v. Error: %v", response.Request.URL, err)
+
+				fmt.Println(warning)
+
+				if diagnostics != nil {
+					diagnostics.Warnings = append(diagnostics.Warnings, warning)
+				}
+
+				return
+			}
+
+			for _, itemOfPage := range items {
+				addItem(itemOfPage, nil, response.Request.URL.String())
+			}
+		})
+
+	case "", entities.FormatHTML:
+		if diagnostics != nil {
+			collector.OnHTML("html", diagnostics.countSelectors)
+		}
+
+		// htmlquery panics on invalid XPath expression, so XPath selectors are checked before parse
+		err = selectors.CompileXPaths(
+			instruction.ItemSelector,
+			instruction.NameOfItemSelector,
+			instruction.LinkOfItemSelector,
+			instruction.PreviewImageOfSelector,
+			instruction.PriceOfItemSelector,
+			instruction.OldPriceOfItemSelector,
+			instruction.AvailabilityOfItemSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		var structuredProducts []structured.Product
+
+		if request.StructuredData {
+			collector.OnHTML("html", func(page *colly.HTMLElement) {
+				structuredProducts = structured.Extract(page.DOM.Nodes[0])
+
+				if diagnostics != nil {
+					diagnostics.StructuredProductsCount += len(structuredProducts)
+				}
+
+				if instruction.ItemSelector != "" {
+					return
+				}
+
+				for _, product := range structuredProducts {
+					addItem(itemOfStructuredData(product), nil, page.Request.URL.String())
+				}
+			})
+		}
+
+		if instruction.ItemSelector == "" {
+			break
+		}
+
+		onItem(collector, instruction.ItemSelector,
+			func(node *html.Node, pageURL string) {
+				itemOfPage := item{
+					Name:             selectors.ChildText(node, instruction.NameOfItemSelector),
+					IRI:              selectors.ChildAttr(node, instruction.LinkOfItemSelector, linkAttribute),
+					PreviewImageLink: imageOf(node, instruction.PreviewImageOfSelector, imageAttributes),
+					Price:            selectors.ChildText(node, instruction.PriceOfItemSelector),
+					OldPrice:         selectors.ChildText(node, instruction.OldPriceOfItemSelector),
+					Availability:     selectors.ChildText(node, instruction.AvailabilityOfItemSelector)}
+
+				if request.StructuredData {
+					itemOfPage = withStructuredData(itemOfPage, structuredProducts)
+				}
+
+				addItem(itemOfPage, node, pageURL)
+			})
+
+	default:
+		return nil, ErrFormatOfPageIsUnknown
+	}
+
+	collector.OnError(func(response *colly.Response, err error) {
+		warning := fmt.Sprintf(
+			"Request URL: %v failed with response: %v. Error: %v",
+			response.Request.URL,
+			response,
+			err)
+
+		fmt.Println(warning)
+
+		if diagnostics != nil {
+			diagnostics.StatusCode = response.StatusCode
+			diagnostics.Warnings = append(diagnostics.Warnings, warning)
+		}
+	})
+
+	err = collector.Visit(pageIRI)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Error visit URL: %v. Error: %v",
+			pageIRI,
+			err)
+
+		fmt.Println(warning)
+
+		return nil, err
+	}
+
+	collector.Wait()
+
+	err = failedRequests.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if notModified {
+		fmt.Printf("Page by IRI: %v is not modified\n", pageIRI)
+
+		if diagnostics != nil {
+			diagnostics.Warnings = append(diagnostics.Warnings, ErrPageIsNotModified.Error())
+		} else if !request.Cache.ParseNotModified {
+			return nil, ErrPageIsNotModified
+		}
+	}
+
+	return productsFromPage, nil
+}
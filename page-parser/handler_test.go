@@ -0,0 +1,1283 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/fixtures"
+	"github.com/hecatoncheir/Functions/shared/jsonpath"
+	"github.com/hecatoncheir/Functions/shared/prices"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Storage"
+	"golang.org/x/net/html"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	record = flag.Bool("record", false, "record fixtures of corpus from shops")
+	update = flag.Bool("update", false, "update golden files of corpus")
+)
+
+func TestParserCanParsePage(t *testing.T) {
+	testFileContent, err := ioutil.ReadFile("testdata/mvideo/fixtures/www.mvideo.ru_smartfony-i-svyaz_smartfony-205.html")
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write(testFileContent)
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/test"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:           ".c-product-tile",
+			PreviewImageOfSelector: ".c-product-tile-picture__link .lazy-load-image-holder img",
+			NameOfItemSelector:     ".c-product-tile__description .sel-product-tile-title",
+			LinkOfItemSelector:     ".c-product-tile__description .sel-product-tile-title",
+			PriceOfItemSelector:    ".c-product-tile__checkout-section .c-pdp-price__current"}},
+		ImageAttributes: []string{"data-original"},
+		PricePattern:    "[ ¤]*",
+	}
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	encodedResponse := Handle(bytes)
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(encodedResponse), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if response.Error != "" {
+		t.Errorf(response.Error)
+	}
+
+	var listOfProducts []Product
+
+	json.Unmarshal([]byte(response.Data), &listOfProducts)
+
+	expectedLengthOfProductsList := 12
+
+	if len(listOfProducts) != expectedLengthOfProductsList {
+		t.Errorf("expected '%d' but got '%d'", expectedLengthOfProductsList, len(listOfProducts))
+	}
+
+	if listOfProducts[0].Price.Value != 18195 {
+		t.Errorf("expected '%v' but got '%v'", 18195, listOfProducts[0].Price.Value)
+	}
+
+	if listOfProducts[0].PreviewImageLink == "" {
+		t.Errorf("expected preview image link of product, but got empty")
+	}
+}
+
+func TestParserCanParsePageOfOtherShopByPageInstructionPath(t *testing.T) {
+	testPageContent := `
+		<html><body>
+			<ul class="catalog">
+				<li class="item">
+					<a class="item__title" data-link="/product/1">First product</a>
+					<img class="item__image" data-src="/images/1.png"/>
+					<span class="item__price">$1,099.50</span>
+				</li>
+				<li class="item">
+					<a class="item__title" data-link="/product/2">Second product</a>
+					<img class="item__image" data-src="/images/2.png"/>
+					<span class="item__price">$42</span>
+				</li>
+			</ul>
+		</body></html>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			Path:                   fmt.Sprint(server.URL, "/catalog"),
+			ItemSelector:           ".catalog .item",
+			PreviewImageOfSelector: ".item__image",
+			NameOfItemSelector:     ".item__title",
+			LinkOfItemSelector:     ".item__title",
+			PriceOfItemSelector:    ".item__price"}},
+		LinkAttribute:   "data-link",
+		ImageAttributes: []string{"data-src"},
+		PricePattern:    "[$,]",
+	}
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if response.Error != "" {
+		t.Fatalf(response.Error)
+	}
+
+	var listOfProducts []Product
+
+	err = json.Unmarshal([]byte(response.Data), &listOfProducts)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(listOfProducts) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(listOfProducts))
+	}
+
+	if listOfProducts[0].IRI != server.URL+"/product/1" {
+		t.Errorf("expected '%v' but got '%v'", server.URL+"/product/1", listOfProducts[0].IRI)
+	}
+
+	if listOfProducts[0].PreviewImageLink != server.URL+"/images/1.png" {
+		t.Errorf("expected '%v' but got '%v'", server.URL+"/images/1.png", listOfProducts[0].PreviewImageLink)
+	}
+
+	if listOfProducts[0].Price.Value != 1099.50 {
+		t.Errorf("expected '%v' but got '%v'", 1099.50, listOfProducts[0].Price.Value)
+	}
+}
+
+func TestParserCanParseOldPriceAndSkipProductWithoutPrice(t *testing.T) {
+	testPageContent := `
+		<div class="item">
+			<span class="name">Product with discount</span>
+			<span class="price">1 299,90 ₽</span>
+			<span class="old-price">1 499,90 ₽</span>
+		</div>
+		<div class="item">
+			<span class="name">Product without price</span>
+			<span class="price">Нет в наличии</span>
+		</div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{
+			PageInstruction: storage.PageInstruction{
+				ItemSelector:        ".item",
+				NameOfItemSelector:  ".name",
+				PriceOfItemSelector: ".price"},
+			OldPriceOfItemSelector: ".old-price"}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 {
+		t.Fatalf("expected '%d' but got '%d'", 1, len(products))
+	}
+
+	price := products[0].Price
+
+	if price.Value != 1299.90 || price.OldValue != 1499.90 || price.Currency != "RUB" {
+		t.Errorf("expected '%v' but got '%v'", "1299.9 of 1499.9 RUB", price)
+	}
+
+	if price.Discount != 13.33 {
+		t.Errorf("expected '%v' but got '%v'", 13.33, price.Discount)
+	}
+}
+
+func TestParserCanParseAvailabilityOfProducts(t *testing.T) {
+	testPageContent := `
+		<div class="item">
+			<span class="name">First product</span><span class="price">100</span>
+			<span class="availability">В наличии</span>
+		</div>
+		<div class="item">
+			<span class="name">Second product</span><span class="price">200</span>
+			<span class="availability">Нет в наличии</span>
+		</div>
+		<div class="item">
+			<span class="name">Third product</span><span class="price">300</span>
+			<span class="availability">Ожидается</span>
+		</div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{
+			PageInstruction: storage.PageInstruction{
+				ItemSelector:        ".item",
+				NameOfItemSelector:  ".name",
+				PriceOfItemSelector: ".price"},
+			AvailabilityOfItemSelector: ".availability"}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 3 {
+		t.Fatalf("expected '%d' but got '%d'", 3, len(products))
+	}
+
+	expectedStatuses := []string{prices.InStock, prices.OutOfStock, ""}
+	for index, product := range products {
+		if product.Price.Availability != expectedStatuses[index] {
+			t.Errorf("expected '%v' but got '%v'", expectedStatuses[index], product.Price.Availability)
+		}
+	}
+
+	request.Availability = map[string]string{"ожидается": prices.PreOrder}
+
+	products, err = pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if products[2].Price.Availability != prices.PreOrder || products[0].Price.Availability != "" {
+		t.Errorf("expected '%v' but got '%v'", prices.PreOrder, products[2].Price.Availability)
+	}
+}
+
+func TestParserCanParseProductWithoutPriceWithAvailability(t *testing.T) {
+	testPageContent := `
+		<div class="item"><a class="name" href="/1">First product</a><span class="price">100 ₽</span>
+			<span class="stock">В наличии</span></div>
+		<div class="item"><a class="name" href="/2">Second product</a><span class="price">Скоро</span>
+			<span class="stock">Нет в наличии</span></div>
+		<div class="item"><a class="name" href="/3">Third product</a><span class="price">Скоро</span></div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{
+			PageInstruction: storage.PageInstruction{
+				ItemSelector:        ".item",
+				NameOfItemSelector:  ".name",
+				LinkOfItemSelector:  ".name",
+				PriceOfItemSelector: ".price"},
+			AvailabilityOfItemSelector: ".stock"}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(products))
+	}
+
+	if products[0].Price.Value != 100 || products[0].Price.Availability != prices.InStock {
+		t.Errorf("expected '%v' but got '%v'", "100 in stock", products[0].Price)
+	}
+
+	if products[1].Name != "Second product" || products[1].Price.Value != 0 || products[1].Price.Availability != prices.OutOfStock {
+		t.Errorf("expected '%v' but got '%v'", "Second product out of stock without price", products[1])
+	}
+}
+
+func TestParserCanDiagnosePageInstruction(t *testing.T) {
+	testPageContent := `
+		<div class="item"><a class="name" href="/1">First product</a><span class="price">100 ₽</span></div>
+		<div class="item"><a class="name" href="/2">Second product</a><span class="price">Скоро</span></div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:           ".item",
+			NameOfItemSelector:     ".name",
+			LinkOfItemSelector:     ".name",
+			PreviewImageOfSelector: ".image",
+			PriceOfItemSelector:    ".price"}},
+		Diagnostics: true}
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if response.Error != "" {
+		t.Fatalf(response.Error)
+	}
+
+	var diagnostics Diagnostics
+
+	err = json.Unmarshal([]byte(response.Data), &diagnostics)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if diagnostics.StatusCode != 200 || diagnostics.ProductsCount != 1 {
+		t.Errorf("expected '%v' but got '%v'", "status 200 and 1 product", diagnostics)
+	}
+
+	expectedMatches := map[string]int{
+		"itemSelector":           2,
+		"nameOfItemSelector":     2,
+		"linkOfItemSelector":     2,
+		"previewImageOfSelector": 0,
+		"priceOfItemSelector":    2}
+
+	for _, selector := range diagnostics.Selectors {
+		if selector.Matched != expectedMatches[selector.Name] {
+			t.Errorf("expected '%v' but got '%v' for %v", expectedMatches[selector.Name], selector.Matched, selector.Name)
+		}
+	}
+
+	if diagnostics.Selectors[1].Samples[0] != "First product" {
+		t.Errorf("expected '%v' but got '%v'", "First product", diagnostics.Selectors[1].Samples)
+	}
+
+	if len(diagnostics.PriceFailures) != 1 || diagnostics.PriceFailures[0].Text != "Скоро" {
+		t.Errorf("expected '%v' but got '%v'", "Скоро", diagnostics.PriceFailures)
+	}
+
+	if len(diagnostics.Warnings) != 1 {
+		t.Errorf("expected '%v' but got '%v'", "warning of previewImageOfSelector", diagnostics.Warnings)
+	}
+}
+
+func TestParserCanRetryFailedRequests(t *testing.T) {
+	requestsCount := 0
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		requestsCount++
+
+		if requestsCount < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="item"><span class="name">Product</span><span class="price">100</span></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  ".name",
+			PriceOfItemSelector: ".price"}},
+		Politeness: crawl.Politeness{RetryDelay: 1}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 || requestsCount != 3 {
+		t.Fatalf("expected '%v' but got '%v' products by '%v' requests", 1, len(products), requestsCount)
+	}
+
+	request.IRI = fmt.Sprint(server.URL, "/broken")
+
+	bytes, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if !strings.HasPrefix(response.Error, crawl.ErrPageCanNotBeLoaded.Error()) || !strings.Contains(response.Error, "429") {
+		t.Errorf("expected '%v' but got '%v'", crawl.ErrPageCanNotBeLoaded, response.Error)
+	}
+}
+
+func TestParserCanRotateProxiesAndUserAgents(t *testing.T) {
+	badProxyRequests := 0
+
+	badProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		badProxyRequests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer badProxy.Close()
+
+	var userAgentsOfGoodProxy, urlsOfGoodProxy []string
+
+	goodProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgentsOfGoodProxy = append(userAgentsOfGoodProxy, r.UserAgent())
+		urlsOfGoodProxy = append(urlsOfGoodProxy, r.URL.String())
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="item"><a href="/product/1">First product</a><span>100 ₽</span></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}))
+	defer goodProxy.Close()
+
+	request := Request{
+		IRI: "http://shop.test/catalog",
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  "a",
+			PriceOfItemSelector: "span"}},
+		Politeness: crawl.Politeness{
+			RetryDelay: 1,
+			Rotation: crawl.Rotation{
+				Proxies:     []string{badProxy.URL, goodProxy.URL},
+				UserAgents:  []string{"first agent", "second agent"},
+				MaxFailures: 1}}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 || products[0].Name != "First product" {
+		t.Errorf("expected '%v' but got '%v'", "First product", products)
+	}
+
+	if badProxyRequests != 1 {
+		t.Errorf("expected '%d' but got '%d'", 1, badProxyRequests)
+	}
+
+	if fmt.Sprint(urlsOfGoodProxy) != "[http://shop.test/catalog]" || fmt.Sprint(userAgentsOfGoodProxy) != "[second agent]" {
+		t.Errorf("expected '%v' but got '%v' with '%v'", "second agent", urlsOfGoodProxy, userAgentsOfGoodProxy)
+	}
+}
+
+func TestParserCanDropClosedProxy(t *testing.T) {
+	closedProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedProxy.Close()
+
+	var urlsOfGoodProxy []string
+
+	goodProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		urlsOfGoodProxy = append(urlsOfGoodProxy, r.URL.String())
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="item"><a href="/product/1">First product</a><span>100 ₽</span></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}))
+	defer goodProxy.Close()
+
+	request := Request{
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  "a",
+			PriceOfItemSelector: "span"}},
+		Politeness: crawl.Politeness{
+			RetryDelay: 1,
+			Rotation: crawl.Rotation{
+				Proxies:     []string{closedProxy.URL, goodProxy.URL},
+				MaxFailures: 1}}}
+
+	for _, iri := range []string{"http://shop.test/catalog", "http://shop.test/catalog?page=2"} {
+		request.IRI = iri
+
+		products, err := pageParse(request)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if len(products) != 1 || products[0].Name != "First product" {
+			t.Errorf("expected '%v' but got '%v'", "First product", products)
+		}
+	}
+
+	if fmt.Sprint(urlsOfGoodProxy) != "[http://shop.test/catalog http://shop.test/catalog?page=2]" {
+		t.Errorf("expected all pages to be loaded by good proxy but got '%v'", urlsOfGoodProxy)
+	}
+}
+
+func TestParserCanSkipNotModifiedPage(t *testing.T) {
+	directory, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == "Mon, 01 Jan 2018 00:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2018 00:00:00 GMT")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="item"><a href="/product/1">First product</a><span>100 ₽</span></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  "a",
+			PriceOfItemSelector: "span"}},
+		Cache: Cache{Directory: directory}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 {
+		t.Fatalf("expected '%d' but got '%d'", 1, len(products))
+	}
+
+	_, err = pageParse(request)
+	if err != ErrPageIsNotModified {
+		t.Errorf("expected '%v' but got '%v'", ErrPageIsNotModified, err)
+	}
+
+	request.Cache.ParseNotModified = true
+
+	products, err = pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 || products[0].Name != "First product" {
+		t.Errorf("expected '%v' but got '%v'", "First product", products)
+	}
+}
+
+func TestParserCanRespectRobotsTxt(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("User-agent: *\nDisallow: /catalog"))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected that '%v' is not visited", r.URL)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI:             fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{ItemSelector: ".item"}},
+		Politeness:      crawl.Politeness{RespectRobotsTxt: true}}
+
+	_, err := pageParse(request)
+	if err != colly.ErrRobotsTxtBlocked {
+		t.Errorf("expected '%v' but got '%v'", colly.ErrRobotsTxtBlocked, err)
+	}
+}
+
+func TestParserCanParseJSONPage(t *testing.T) {
+	testPageContent := `{
+		"body": {
+			"products": [
+				{"title": "First product", "url": "/product/1", "images": ["/1.png"],
+				 "price": {"sale": 899, "base": 999}, "stock": "in stock"},
+				{"title": "Second product", "url": "/product/2", "images": [],
+				 "price": {"sale": "1 299,90 ₽"}, "stock": "sold out"},
+				{"title": "Third product", "url": "/product/3", "price": {}}
+			]
+		}
+	}`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/api/catalog"),
+		PageInstruction: entities.PageInstruction{
+			PageInstruction: storage.PageInstruction{
+				ItemSelector:           "$.body.products[*]",
+				NameOfItemSelector:     "title",
+				LinkOfItemSelector:     "url",
+				PreviewImageOfSelector: "images[0]",
+				PriceOfItemSelector:    "price.sale"},
+			Format:                     entities.FormatJSON,
+			OldPriceOfItemSelector:     "price.base",
+			AvailabilityOfItemSelector: "stock"},
+		Diagnostics: true}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(products))
+	}
+
+	if products[0].Name != "First product" || products[0].IRI != server.URL+"/product/1" ||
+		products[0].PreviewImageLink != server.URL+"/1.png" {
+		t.Errorf("expected '%v' but got '%v'", "First product", products[0])
+	}
+
+	if products[0].Price.Value != 899 || products[0].Price.OldValue != 999 || products[0].Price.Availability != prices.InStock {
+		t.Errorf("expected '%v' but got '%v'", "899 of 999 in stock", products[0].Price)
+	}
+
+	if products[1].Price.Value != 1299.90 || products[1].Price.Currency != "RUB" || products[1].Price.Availability != prices.OutOfStock {
+		t.Errorf("expected '%v' but got '%v'", "1299.9 RUB out of stock", products[1].Price)
+	}
+
+	diagnostics, err := pageDiagnose(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if diagnostics.Selectors[0].Matched != 3 || diagnostics.Selectors[3].Matched != 1 || len(diagnostics.PriceFailures) != 1 {
+		t.Errorf("expected '%v' but got '%v'", "3 items, 1 image and 1 price failure", diagnostics)
+	}
+
+	request.PageInstruction.ItemSelector = "$.body["
+
+	_, err = pageParse(request)
+	if err != jsonpath.ErrPathCanNotBeParsed {
+		t.Errorf("expected '%v' but got '%v'", jsonpath.ErrPathCanNotBeParsed, err)
+	}
+}
+
+func TestParserCanParsePageByXPath(t *testing.T) {
+	testPageContent := `
+		<ul id="catalog">
+			<li data-sku="1"><a href="/product/1"><b>First product</b></a><em>100 ₽</em></li>
+			<li data-sku="2"><a href="/product/2"><b>Second product</b></a><em>200 ₽</em></li>
+		</ul>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        "xpath://ul[@id='catalog']/li",
+			NameOfItemSelector:  "xpath:./a/b",
+			LinkOfItemSelector:  "a",
+			PriceOfItemSelector: "xpath:.//em"}}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(products))
+	}
+
+	if products[1].Name != "Second product" || products[1].IRI != server.URL+"/product/2" || products[1].Price.Value != 200 {
+		t.Errorf("expected '%v' but got '%v'", "Second product", products[1])
+	}
+
+	request.PageInstruction.ItemSelector = "#catalog li"
+	request.PageInstruction.LinkOfItemSelector = "xpath:./a"
+
+	products, err = pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 || products[0].IRI != server.URL+"/product/1" {
+		t.Errorf("expected '%v' but got '%v'", server.URL+"/product/1", products)
+	}
+
+	request.PageInstruction.NameOfItemSelector = "xpath:./a[@"
+
+	_, err = pageParse(request)
+	if err != ErrSelectorCanNotBeCompiled {
+		t.Errorf("expected '%v' but got '%v'", ErrSelectorCanNotBeCompiled, err)
+	}
+}
+
+func TestParserCanUseStructuredDataOfPage(t *testing.T) {
+	testPageContent := `
+		<html><head>
+			<script type="application/ld+json">
+			{
+				"@context": "https://schema.org",
+				"@type": "ItemList",
+				"itemListElement": [
+					{"@type": "ListItem", "position": 1, "item": {
+						"@type": "Product", "name": "First product", "url": "https://shop.ru/product/1",
+						"sku": "A-1", "gtin13": "4600000000011",
+						"offers": {"@type": "Offer", "price": "100", "priceCurrency": "RUB",
+							"availability": "https://schema.org/InStock"}}},
+					{"@type": "ListItem", "position": 2, "item": {
+						"@type": "Product", "name": "Second product", "url": "https://shop.ru/product/2",
+						"sku": "A-2",
+						"offers": {"@type": "Offer", "price": 250, "priceCurrency": "RUB",
+							"availability": "https://schema.org/OutOfStock"}}}
+				]
+			}
+			</script>
+		</head><body>
+			<div class="item"><a href="/product/1">First product</a><span class="price">100 ₽</span></div>
+			<div class="item"><a href="/product/2">Second product</a><span class="price">200 ₽</span></div>
+		</body></html>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI:            fmt.Sprint(server.URL, "/catalog"),
+		StructuredData: true,
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			LinkOfItemSelector:  "a",
+			PriceOfItemSelector: ".price"}}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(products))
+	}
+
+	first := products[0]
+	if first.Name != "First product" || first.SKU != "A-1" || first.GTIN != "4600000000011" ||
+		first.Price.Availability != prices.InStock || len(first.Mismatches) != 0 {
+		t.Errorf("expected '%v' but got '%v'", "First product", first)
+	}
+
+	second := products[1]
+	if second.Price.Value != 200 || second.Price.Availability != prices.OutOfStock {
+		t.Errorf("expected '%v' but got '%v'", 200, second.Price)
+	}
+
+	expectedMismatch := Mismatch{Field: "price", Selector: "200", StructuredData: "250"}
+	if len(second.Mismatches) != 1 || second.Mismatches[0] != expectedMismatch {
+		t.Errorf("expected '%v' but got '%v'", expectedMismatch, second.Mismatches)
+	}
+
+	request.PageInstruction = entities.PageInstruction{}
+
+	products, err = pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 || products[1].IRI != "https://shop.ru/product/2" || products[1].Price.Value != 250 ||
+		products[1].Price.Currency != "RUB" {
+		t.Errorf("expected '%v' but got '%v'", "https://shop.ru/product/2", products)
+	}
+}
+
+func TestParserCanNotParsePageWithoutItemSelector(t *testing.T) {
+	bytes, err := json.Marshal(Request{IRI: "http://"})
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if response.Error != ErrPageInstructionCanNotBeWithoutItemSelector.Error() {
+		t.Errorf("expected '%v' but got '%v'", ErrPageInstructionCanNotBeWithoutItemSelector, response.Error)
+	}
+}
+
+func TestParserCanParsePageForCity(t *testing.T) {
+	pricesOfCities := map[string]string{"moscow": "100", "spb": "200"}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		cityCode := r.URL.Query().Get("city")
+
+		cookie, err := r.Cookie("CITY_ID")
+		if err == nil {
+			cityCode = cookie.Value
+		}
+
+		page := fmt.Sprintf(
+			`<div class="item"><span class="name">Product</span><span class="price">%v</span></div>`,
+			pricesOfCities[cityCode])
+
+		w.WriteHeader(200)
+		_, err = w.Write([]byte(page))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		pageInstruction entities.PageInstruction
+		city            City
+		expectedPrice   float64
+	}{
+		{
+			pageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{CityParamPath: "?city="}},
+			city:            City{ID: "0x12", Name: "Moscow", Code: "moscow"},
+			expectedPrice:   100},
+		{
+			pageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{CityInCookieKey: "CITY_ID"}},
+			city:            City{ID: "0x13", Name: "Saint Petersburg", Code: "spb"},
+			expectedPrice:   200},
+		{
+			pageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{CityInCookieKey: "CITY_ID", CityIDForCookie: "spb"}},
+			city:            City{},
+			expectedPrice:   200}}
+
+	for _, test := range tests {
+		test.pageInstruction.Path = fmt.Sprint(server.URL, "/catalog")
+		test.pageInstruction.ItemSelector = ".item"
+		test.pageInstruction.NameOfItemSelector = ".name"
+		test.pageInstruction.PriceOfItemSelector = ".price"
+
+		bytes, err := json.Marshal(Request{PageInstruction: test.pageInstruction, City: test.city})
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+
+		response := Response{}
+
+		err = json.Unmarshal([]byte(Handle(bytes)), &response)
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+
+		var listOfProducts []Product
+
+		err = json.Unmarshal([]byte(response.Data), &listOfProducts)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if len(listOfProducts) != 1 {
+			t.Fatalf("expected '%d' but got '%d'", 1, len(listOfProducts))
+		}
+
+		if listOfProducts[0].Price.Value != test.expectedPrice {
+			t.Errorf("expected '%v' but got '%v'", test.expectedPrice, listOfProducts[0].Price.Value)
+		}
+
+		if listOfProducts[0].Price.City.ID != test.city.ID {
+			t.Errorf("expected '%v' but got '%v'", test.city.ID, listOfProducts[0].Price.City.ID)
+		}
+	}
+
+	request := Request{
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			Path:                fmt.Sprint(server.URL, "/catalog"),
+			ItemSelector:        ".item",
+			NameOfItemSelector:  ".name",
+			PriceOfItemSelector: ".price",
+			CityInCookieKey:     "CITY_ID",
+			CityIDForCookie:     "spb"}},
+		City: City{ID: "0x13", Name: "Saint Petersburg"}}
+
+	_, err := pageParse(request)
+	if err != ErrCityCanNotBeWithoutCode {
+		t.Errorf("expected '%v' but got '%v'", ErrCityCanNotBeWithoutCode, err)
+	}
+}
+
+// TestParserCanParseCorpusOfShops parse pages of every shop in testdata by request.json
+// from fixtures and compare products with golden.json.
+// Use -record for record fixtures from shops and -update for update golden files.
+func TestParserCanParseCorpusOfShops(t *testing.T) {
+	shops, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, shop := range shops {
+		if !shop.IsDir() {
+			continue
+		}
+
+		shopDirectory := filepath.Join("testdata", shop.Name())
+
+		encodedRequest, err := ioutil.ReadFile(filepath.Join(shopDirectory, "request.json"))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		request := Request{}
+
+		err = json.Unmarshal(encodedRequest, &request)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		request.Fixtures = Fixtures{Mode: fixtures.ModeReplay, Directory: filepath.Join(shopDirectory, "fixtures")}
+		if *record {
+			request.Fixtures.Mode = fixtures.ModeRecord
+		}
+
+		products, err := pageParse(request)
+		if err != nil {
+			t.Fatalf("%v: %v", shop.Name(), err)
+		}
+
+		for index := range products {
+			products[index].Price.DateTime = time.Time{}
+		}
+
+		encodedProducts, err := json.MarshalIndent(products, "", "  ")
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		goldenFile := filepath.Join(shopDirectory, "golden.json")
+
+		if *update {
+			err = ioutil.WriteFile(goldenFile, encodedProducts, 0644)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+
+		goldenProducts, err := ioutil.ReadFile(goldenFile)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if !bytes.Equal(encodedProducts, goldenProducts) {
+			t.Errorf("%v: expected '%s' but got '%s'", shop.Name(), goldenProducts, encodedProducts)
+		}
+	}
+}
+
+func TestParserCanRecordAndReplayPages(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`<div class="item"><span class="name">Product</span><span class="price">100</span></div>`))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+
+	directory, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer os.RemoveAll(directory)
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  ".name",
+			PriceOfItemSelector: ".price"}},
+		Fixtures: Fixtures{Mode: fixtures.ModeRecord, Directory: directory}}
+
+	recordedProducts, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	server.Close()
+
+	request.Fixtures.Mode = fixtures.ModeReplay
+
+	replayedProducts, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(recordedProducts) != 1 || len(replayedProducts) != 1 || replayedProducts[0].Name != "Product" {
+		t.Errorf("expected '%v' but got '%v'", recordedProducts, replayedProducts)
+	}
+
+	request.IRI = fmt.Sprint(server.URL, "/other")
+
+	_, err = pageParse(request)
+	if err == nil || !strings.Contains(err.Error(), fixtures.ErrFixtureNotFound.Error()) {
+		t.Errorf("expected '%v' but got '%v'", fixtures.ErrFixtureNotFound, err)
+	}
+}
+
+func TestParserCanNormalizeLinksOfProducts(t *testing.T) {
+	testPageContent := `
+		<div class="item">
+			<a href="/product/1?utm_source=main&color=black&gclid=123#reviews">First product</a>
+			<img src="data:image/gif;base64,R0lGOD" srcset="/1.jpg 1x, /1@2x.jpg 2x">
+			<span>100 ₽</span>
+		</div>
+		<div class="item">
+			<a href="HTTPS://Shop.RU/product/2">Second product</a>
+			<img src="/2.jpg" data-src="//cdn.shop.ru/2.jpg">
+			<span>200 ₽</span>
+		</div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog/phones/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog/phones/"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:           ".item",
+			NameOfItemSelector:     "a",
+			LinkOfItemSelector:     "a",
+			PreviewImageOfSelector: "img",
+			PriceOfItemSelector:    "span"}}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("expected '%d' but got '%d'", 2, len(products))
+	}
+
+	expectedIRI := server.URL + "/product/1?color=black"
+	if products[0].IRI != expectedIRI || products[0].PreviewImageLink != server.URL+"/1@2x.jpg" {
+		t.Errorf("expected '%v' but got '%v'", expectedIRI, products[0])
+	}
+
+	if products[1].IRI != "https://shop.ru/product/2" || products[1].PreviewImageLink != "http://cdn.shop.ru/2.jpg" {
+		t.Errorf("expected '%v' but got '%v'", "https://shop.ru/product/2", products[1])
+	}
+
+	request.ImageAttributes = []string{"src"}
+
+	products, err = pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 2 || products[1].PreviewImageLink != server.URL+"/2.jpg" {
+		t.Errorf("expected '%v' but got '%v'", server.URL+"/2.jpg", products)
+	}
+}
+
+// testAdapter cut marketing prefix and bundle suffix of names and join price split across elements
+type testAdapter struct{ noAdapter }
+
+func (testAdapter) Item(itemOfPage *item, node *html.Node) {
+	itemOfPage.Name = strings.TrimPrefix(itemOfPage.Name, "Акция! ")
+
+	if itemOfPage.Price == "" && node != nil {
+		itemOfPage.Price = selectors.ChildText(node, ".rub") + "," + selectors.ChildText(node, ".kop") + " ₽"
+	}
+}
+
+func (testAdapter) Product(product *Product) {
+	product.Name = strings.TrimSuffix(product.Name, " + подарок")
+}
+
+func TestParserCanApplyAdapterOfShop(t *testing.T) {
+	testPageContent := `
+		<div class="item">
+			<span class="name">Акция! Phone + подарок</span>
+			<span class="price"></span><span class="rub">1 299</span><span class="kop">90</span>
+		</div>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	registerAdapter(testAdapter{}, "127.0.0.1")
+	defer delete(adapters, "127.0.0.1")
+
+	if _, ok := adapterOf("https://www.mvideo.ru/catalog").(mvideoAdapter); !ok {
+		t.Errorf("expected adapter of M.Video for subdomain of it")
+	}
+
+	request := Request{
+		IRI: fmt.Sprint(server.URL, "/catalog"),
+		PageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{
+			ItemSelector:        ".item",
+			NameOfItemSelector:  ".name",
+			PriceOfItemSelector: ".price"}}}
+
+	products, err := pageParse(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 {
+		t.Fatalf("expected '%d' but got '%d'", 1, len(products))
+	}
+
+	if products[0].Name != "Phone" {
+		t.Errorf("expected '%v' but got '%v'", "Phone", products[0].Name)
+	}
+
+	if products[0].Price.Value != 1299.90 || products[0].Price.Currency != "RUB" {
+		t.Errorf("expected '%v' but got '%v'", "1299.9 RUB", products[0].Price)
+	}
+}
@@ -0,0 +1,97 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/jsonpath"
+)
+
+// paths is a compiled JSONPath expressions of page instruction with entities.FormatJSON
+type paths struct {
+	list         jsonpath.Path
+	name         *jsonpath.Path
+	link         *jsonpath.Path
+	image        *jsonpath.Path
+	price        *jsonpath.Path
+	oldPrice     *jsonpath.Path
+	availability *jsonpath.Path
+}
+
+// compilePaths compile selectors of page instruction, empty selectors are skipped
+func compilePaths(instruction entities.PageInstruction) (paths, error) {
+	compiledPaths := paths{}
+
+	itemsPath, err := jsonpath.Compile(instruction.ItemSelector)
+	if err != nil {
+		return compiledPaths, err
+	}
+
+	compiledPaths.list = itemsPath
+
+	selectors := []struct {
+		expression string
+		path       **jsonpath.Path
+	}{
+		{instruction.NameOfItemSelector, &compiledPaths.name},
+		{instruction.LinkOfItemSelector, &compiledPaths.link},
+		{instruction.PreviewImageOfSelector, &compiledPaths.image},
+		{instruction.PriceOfItemSelector, &compiledPaths.price},
+		{instruction.OldPriceOfItemSelector, &compiledPaths.oldPrice},
+		{instruction.AvailabilityOfItemSelector, &compiledPaths.availability}}
+
+	for _, selector := range selectors {
+		if selector.expression == "" {
+			continue
+		}
+
+		path, err := jsonpath.Compile(selector.expression)
+		if err != nil {
+			return compiledPaths, err
+		}
+
+		*selector.path = &path
+	}
+
+	return compiledPaths, nil
+}
+
+// items decode JSON page and extract texts of every item.
+// Matched elements of selectors are counted in diagnostics if it is not nil.
+func (compiledPaths paths) items(page []byte, diagnostics *Diagnostics) ([]item, error) {
+	document, err := jsonpath.Decode(page)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsOfPage := compiledPaths.list.Select(document)
+
+	if diagnostics != nil {
+		diagnostics.addMatches("itemSelector", len(itemsOfPage))
+	}
+
+	textOf := func(name string, path *jsonpath.Path, itemOfPage interface{}) string {
+		if path == nil {
+			return ""
+		}
+
+		text, found := path.Text(itemOfPage)
+		if found && diagnostics != nil {
+			diagnostics.addMatches(name, 1)
+		}
+
+		return text
+	}
+
+	var extractedItems []item
+
+	for _, itemOfPage := range itemsOfPage {
+		extractedItems = append(extractedItems, item{
+			Name:             textOf("nameOfItemSelector", compiledPaths.name, itemOfPage),
+			IRI:              textOf("linkOfItemSelector", compiledPaths.link, itemOfPage),
+			PreviewImageLink: textOf("previewImageOfSelector", compiledPaths.image, itemOfPage),
+			Price:            textOf("priceOfItemSelector", compiledPaths.price, itemOfPage),
+			OldPrice:         textOf("oldPriceOfItemSelector", compiledPaths.oldPrice, itemOfPage),
+			Availability:     textOf("availabilityOfItemSelector", compiledPaths.availability, itemOfPage)})
+	}
+
+	return extractedItems, nil
+}
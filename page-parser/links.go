@@ -0,0 +1,138 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"golang.org/x/net/html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultImageAttributes is a chain of attributes of PreviewImageOfSelector element with link of image,
+// first not empty link is taken. Lazy loaded images have a placeholder in src, so it is last.
+var DefaultImageAttributes = []string{"data-original", "data-src", "srcset", "src"}
+
+// TrackingParams are names of query params of links which are removed from IRI of product,
+// names ending with "_" are prefixes, like "utm_"
+var TrackingParams = []string{
+	"utm_", "gclid", "dclid", "yclid", "ymclid", "fbclid", "msclkid", "_openstat", "mc_cid", "mc_eid"}
+
+// imageAttributesOf return chain of attributes for image of request, DefaultImageAttributes if it is empty
+func imageAttributesOf(request Request) []string {
+	if len(request.ImageAttributes) > 0 {
+		return request.ImageAttributes
+	}
+
+	return DefaultImageAttributes
+}
+
+// imageOf return link of image of first element matched by selector inside of node
+// from first attribute of chain with link. Inline images of placeholders are skipped.
+func imageOf(node *html.Node, selector string, attributes []string) string {
+	children := selectors.Find(node, selector)
+	if len(children) == 0 {
+		return ""
+	}
+
+	for _, attribute := range attributes {
+		link := selectors.AttributeOf(children[0], attribute)
+
+		if attribute == "srcset" || attribute == "data-srcset" {
+			link = largestOfSrcset(link)
+		}
+
+		if link == "" || strings.HasPrefix(link, "data:") {
+			continue
+		}
+
+		return link
+	}
+
+	return ""
+}
+
+// largestOfSrcset return link of largest image of srcset, like "/1.jpg 1x, /1@2x.jpg 2x"
+func largestOfSrcset(srcset string) string {
+	var link string
+	var size float64
+
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+
+		candidateSize := 1.0
+
+		if len(fields) > 1 {
+			descriptor := fields[1]
+
+			parsedSize, err := strconv.ParseFloat(descriptor[:len(descriptor)-1], 64)
+			if err == nil {
+				candidateSize = parsedSize
+			}
+		}
+
+		if link == "" || candidateSize > size {
+			link = fields[0]
+			size = candidateSize
+		}
+	}
+
+	return link
+}
+
+// absoluteLink resolve link by URL of page, links which can not be parsed are returned as is
+func absoluteLink(link, pageURL string) string {
+	if link == "" {
+		return ""
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return link
+	}
+
+	reference, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	return base.ResolveReference(reference).String()
+}
+
+// canonicalIRI resolve link of product by URL of page and remove fragment and tracking params of it,
+// so the same product has the same IRI on every page
+func canonicalIRI(link, pageURL string) string {
+	parsedLink, err := url.Parse(absoluteLink(link, pageURL))
+	if err != nil || link == "" {
+		return link
+	}
+
+	parsedLink.Scheme = strings.ToLower(parsedLink.Scheme)
+	parsedLink.Host = strings.ToLower(parsedLink.Host)
+	parsedLink.Fragment = ""
+
+	query := parsedLink.Query()
+	for name := range query {
+		if isTrackingParam(name) {
+			query.Del(name)
+		}
+	}
+
+	parsedLink.RawQuery = query.Encode()
+
+	return parsedLink.String()
+}
+
+func isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, param := range TrackingParams {
+		if name == param || (strings.HasSuffix(param, "_") && strings.HasPrefix(name, param)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package function
+
+import (
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"golang.org/x/net/html"
+)
+
+// ErrSelectorCanNotBeCompiled means that XPath of selector is not a valid expression
+var ErrSelectorCanNotBeCompiled = selectors.ErrSelectorCanNotBeCompiled
+
+// onItem register callback for every element of page matched by CSS or XPath selector
+func onItem(collector *colly.Collector, selector string, callback func(node *html.Node, pageURL string)) {
+	if selectors.IsXPath(selector) {
+		collector.OnXML(selectors.XPathOf(selector), func(element *colly.XMLElement) {
+			node, ok := element.DOM.(*html.Node)
+			if ok {
+				callback(node, element.Request.URL.String())
+			}
+		})
+
+		return
+	}
+
+	collector.OnHTML(selector, func(element *colly.HTMLElement) {
+		callback(element.DOM.Nodes[0], element.Request.URL.String())
+	})
+}
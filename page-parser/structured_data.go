@@ -0,0 +1,127 @@
+package function
+
+import (
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/prices"
+	"github.com/hecatoncheir/Functions/shared/structured"
+	"math"
+	"net/url"
+	"strings"
+)
+
+// Mismatch is a value of product extracted by selector of page instruction
+// which is not equal to value of structured data of page
+type Mismatch struct {
+	Field          string
+	Selector       string
+	StructuredData string
+}
+
+// itemOfStructuredData return texts of product of structured data
+func itemOfStructuredData(product structured.Product) item {
+	return item{
+		Name:               product.Name,
+		IRI:                product.URL,
+		PreviewImageLink:   product.Image,
+		Price:              product.Price,
+		SKU:                product.SKU,
+		GTIN:               product.GTIN,
+		Currency:           product.Currency,
+		AvailabilityStatus: prices.StructuredAvailability[product.Availability]}
+}
+
+// withStructuredData find product of structured data for item by link or name of it
+// and fill values of item which are not extracted by selectors
+func withStructuredData(itemOfPage item, products []structured.Product) item {
+	for index := range products {
+		product := &products[index]
+
+		if !isSameLink(itemOfPage.IRI, product.URL) && !isSameName(itemOfPage.Name, product.Name) {
+			continue
+		}
+
+		fallback := itemOfStructuredData(*product)
+
+		fill := func(value *string, fallbackValue string) {
+			if *value == "" {
+				*value = fallbackValue
+			}
+		}
+
+		fill(&itemOfPage.Name, fallback.Name)
+		fill(&itemOfPage.IRI, fallback.IRI)
+		fill(&itemOfPage.PreviewImageLink, fallback.PreviewImageLink)
+		fill(&itemOfPage.Price, fallback.Price)
+		fill(&itemOfPage.SKU, fallback.SKU)
+		fill(&itemOfPage.GTIN, fallback.GTIN)
+		fill(&itemOfPage.Currency, fallback.Currency)
+		fill(&itemOfPage.AvailabilityStatus, fallback.AvailabilityStatus)
+
+		itemOfPage.structured = product
+
+		return itemOfPage
+	}
+
+	return itemOfPage
+}
+
+// isSameLink compare paths of links, so relative link of selector is equal to absolute link of structured data
+func isSameLink(link, otherLink string) bool {
+	if link == "" || otherLink == "" {
+		return false
+	}
+
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	parsedOtherLink, err := url.Parse(otherLink)
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSuffix(parsedLink.Path, "/") == strings.TrimSuffix(parsedOtherLink.Path, "/")
+}
+
+func isSameName(name, otherName string) bool {
+	if name == "" || otherName == "" {
+		return false
+	}
+
+	return strings.EqualFold(strings.Join(strings.Fields(name), " "), strings.Join(strings.Fields(otherName), " "))
+}
+
+// crossCheck compare product with product of structured data found for it
+func crossCheck(product Product, structuredProduct structured.Product) []Mismatch {
+	var mismatches []Mismatch
+
+	if structuredProduct.Name != "" && !isSameName(product.Name, structuredProduct.Name) {
+		mismatches = append(mismatches,
+			Mismatch{Field: "name", Selector: product.Name, StructuredData: structuredProduct.Name})
+	}
+
+	structuredPrice, err := prices.Parse(structuredProduct.Price)
+	if err == nil && math.Abs(structuredPrice.Value-product.Price.Value) >= 0.01 {
+		mismatches = append(mismatches,
+			Mismatch{
+				Field:          "price",
+				Selector:       fmt.Sprint(product.Price.Value),
+				StructuredData: fmt.Sprint(structuredPrice.Value)})
+	}
+
+	if structuredProduct.Currency != "" && product.Price.Currency != "" &&
+		!strings.EqualFold(structuredProduct.Currency, product.Price.Currency) {
+		mismatches = append(mismatches,
+			Mismatch{Field: "currency", Selector: product.Price.Currency, StructuredData: structuredProduct.Currency})
+	}
+
+	structuredAvailability := prices.StructuredAvailability[structuredProduct.Availability]
+	if structuredAvailability != "" && product.Price.Availability != "" &&
+		structuredAvailability != product.Price.Availability {
+		mismatches = append(mismatches,
+			Mismatch{Field: "availability", Selector: product.Price.Availability, StructuredData: structuredAvailability})
+	}
+
+	return mismatches
+}
@@ -0,0 +1,376 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/prices"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Functions/shared/structured"
+	"golang.org/x/net/html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// City of price of product.
+// Code is a value of city for shop from facet of has_city edge of instruction.
+type City struct {
+	ID, Name, Code string
+}
+
+// DefaultImageAttributes is a chain of attributes of ImageSelector element with link of image
+var DefaultImageAttributes = []string{"src"}
+
+var (
+	// ErrProductIRICanNotBeEmpty means that detail page of product can't be loaded without IRI
+	ErrProductIRICanNotBeEmpty = errors.New("product IRI can not be empty")
+
+	// ErrPricePatternCanNotBeCompiled means that pattern for cut price is not a valid regular expression
+	ErrPricePatternCanNotBeCompiled = errors.New("price pattern can not be compiled")
+
+	// ErrProductDetailsAreNotFound means that nothing is found on page by detail page instruction
+	ErrProductDetailsAreNotFound = errors.New("product details are not found")
+
+	// ErrCityCanNotBeWithoutCode means that city can't be applied to page without code of it for shop
+	ErrCityCanNotBeWithoutCode = crawl.ErrCityCanNotBeWithoutCode
+
+	// ErrSelectorCanNotBeCompiled means that XPath of selector is not a valid expression
+	ErrSelectorCanNotBeCompiled = selectors.ErrSelectorCanNotBeCompiled
+)
+
+// Request for parse detail page of product.
+// DetailPageInstruction is stored by storage-detail-page-instruction-create
+// and read by storage-detail-page-instruction-read-by-id.
+// Selectors of it are CSS selectors or XPath expressions with selectors.XPathPrefix,
+// XPath of name and value of specification is searched from row, so it must be relative, like "xpath:./td[1]".
+// ImageAttributes is an optional chain of attributes with link of image, first not empty link is taken.
+// DefaultImageAttributes are used if it is empty.
+// PricePattern is optional too, all matches of it are cut from price before normalization.
+// City is optional too, it is sent to shop by CityParamPath or
+// CityInCookieKey of detail page instruction and set to price of product.
+// Availability is a mapping of text of AvailabilitySelector element
+// to status of availability, prices.DefaultAvailability is used if it is empty.
+// Politeness is optional, defaults are used for empty values.
+// If StructuredData is true, JSON-LD, microdata and OpenGraph of page are used for values
+// which selectors of detail page instruction are not set for or found nothing.
+type Request struct {
+	ProductIRI            string
+	DetailPageInstruction entities.DetailPageInstruction
+	City                  City
+	ImageAttributes       []string
+	PricePattern          string
+	Availability          map[string]string
+	Politeness            crawl.Politeness
+	StructuredData        bool
+}
+
+type Response struct{ Message, Data, Error string }
+
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+		fmt.Println(warning)
+	}
+
+	details, err := parseProductPage(request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Parse product page error by IRI: %v. Error: %v",
+			request.ProductIRI,
+			err)
+
+		fmt.Println(warning)
+
+		encodedResponse := Response{
+			Message: warning,
+			Data:    string(req),
+			Error:   err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedDetails, err := json.Marshal(details)
+	if err != nil {
+		encodedResponse := Response{
+			Data:  string(req),
+			Error: err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	fmt.Println(string(encodedDetails))
+
+	encodedResponse := Response{Data: string(encodedDetails)}
+
+	response, err := json.Marshal(encodedResponse)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return string(response)
+}
+
+// Price of product.
+// OldValue and Discount in percents are set if OldPriceSelector of detail page instruction is found.
+// Availability is empty if it is not found or text of it is unknown.
+type Price struct {
+	Value        float64
+	MaxValue     float64
+	OldValue     float64
+	Discount     float64
+	Currency     string
+	Availability string
+	DateTime     time.Time
+	City         City
+}
+
+// Specification is an attribute of product from table of specifications, like "Color": "black"
+type Specification struct {
+	Name, Value string
+}
+
+// ProductDetails is an attributes of product from detail page of it.
+// Images are absolute links of images of gallery in order of page.
+// Price is empty if price is not found or can not be parsed.
+type ProductDetails struct {
+	IRI            string
+	Name           string
+	Description    string
+	SKU            string
+	GTIN           string
+	Images         []string
+	Specifications []Specification
+	Price          Price
+}
+
+// parseProductPage load detail page of product and extract details of it by request
+func parseProductPage(request Request) (ProductDetails, error) {
+	instruction := request.DetailPageInstruction
+	details := ProductDetails{IRI: request.ProductIRI}
+
+	if request.ProductIRI == "" {
+		return details, ErrProductIRICanNotBeEmpty
+	}
+
+	imageAttributes := request.ImageAttributes
+	if len(imageAttributes) == 0 {
+		imageAttributes = DefaultImageAttributes
+	}
+
+	availability := request.Availability
+	if len(availability) == 0 {
+		availability = prices.DefaultAvailability
+	}
+
+	patternForCutPrice, err := regexp.Compile(request.PricePattern)
+	if err != nil {
+		return details, ErrPricePatternCanNotBeCompiled
+	}
+
+	// htmlquery panics on invalid XPath expression, so XPath selectors are checked before parse
+	err = selectors.CompileXPaths(
+		instruction.NameSelector,
+		instruction.DescriptionSelector,
+		instruction.SKUSelector,
+		instruction.GTINSelector,
+		instruction.PriceSelector,
+		instruction.OldPriceSelector,
+		instruction.AvailabilitySelector,
+		instruction.ImageSelector,
+		instruction.SpecificationSelector,
+		instruction.SpecificationNameSelector,
+		instruction.SpecificationValueSelector)
+	if err != nil {
+		return details, err
+	}
+
+	collector, failedRequests, err := crawl.NewCollector(request.Politeness)
+	if err != nil {
+		return details, err
+	}
+
+	pageIRI, err := crawl.ApplyCity(collector, request.ProductIRI, crawl.CitySettings{
+		ParamPath:   instruction.CityParamPath,
+		InCookieKey: instruction.CityInCookieKey,
+		IDForCookie: instruction.CityIDForCookie}, request.City.ID, request.City.Code)
+	if err != nil {
+		return details, err
+	}
+
+	found := false
+
+	collector.OnHTML("html", func(page *colly.HTMLElement) {
+		node := page.DOM.Nodes[0]
+
+		details.Name = selectors.ChildText(node, instruction.NameSelector)
+		details.Description = selectors.ChildText(node, instruction.DescriptionSelector)
+		details.SKU = selectors.ChildText(node, instruction.SKUSelector)
+		details.GTIN = selectors.ChildText(node, instruction.GTINSelector)
+		details.Images = imagesOf(page, instruction.ImageSelector, imageAttributes)
+		details.Specifications = specificationsOf(node, instruction)
+
+		priceText := selectors.ChildText(node, instruction.PriceSelector)
+		currency := ""
+		availabilityStatus := ""
+
+		if instruction.AvailabilitySelector != "" {
+			availabilityStatus = prices.AvailabilityOf(selectors.ChildText(node, instruction.AvailabilitySelector), availability)
+		}
+
+		if request.StructuredData {
+			products := structured.Extract(node)
+			if len(products) > 0 {
+				product := products[0]
+
+				fill := func(value *string, fallbackValue string) {
+					if *value == "" {
+						*value = fallbackValue
+					}
+				}
+
+				fill(&details.Name, product.Name)
+				fill(&details.Description, product.Description)
+				fill(&details.SKU, product.SKU)
+				fill(&details.GTIN, product.GTIN)
+				fill(&priceText, product.Price)
+				fill(&currency, strings.ToUpper(product.Currency))
+				fill(&availabilityStatus, prices.StructuredAvailability[product.Availability])
+
+				if len(details.Images) == 0 && product.Image != "" {
+					details.Images = []string{page.Request.AbsoluteURL(product.Image)}
+				}
+			}
+		}
+
+		found = details.Name != "" || priceText != "" || len(details.Specifications) > 0
+
+		if priceText == "" {
+			return
+		}
+
+		normalizedPrice, err := prices.Parse(patternForCutPrice.ReplaceAllString(priceText, ""))
+		if err != nil {
+			warning := fmt.Sprintf(
+				"Error get price: %v of product: %v, by IRI: %v. Error: %v",
+				priceText,
+				details.Name,
+				page.Request.URL,
+				err)
+
+			fmt.Println(warning)
+
+			return
+		}
+
+		details.Price = Price{
+			Value:        normalizedPrice.Value,
+			MaxValue:     normalizedPrice.MaxValue,
+			Currency:     normalizedPrice.Currency,
+			Availability: availabilityStatus,
+			DateTime:     time.Now().UTC(),
+			City:         City{ID: request.City.ID, Name: request.City.Name}}
+
+		if details.Price.Currency == "" {
+			details.Price.Currency = currency
+		}
+
+		oldPrice, err := prices.Parse(
+			patternForCutPrice.ReplaceAllString(selectors.ChildText(node, instruction.OldPriceSelector), ""))
+		if err == nil {
+			details.Price.OldValue = oldPrice.Value
+			details.Price.Discount = prices.Discount(details.Price.Value, oldPrice.Value)
+		}
+	})
+
+	collector.OnError(func(response *colly.Response, err error) {
+		warning := fmt.Sprintf(
+			"Request URL: %v failed with response: %v. Error: %v",
+			response.Request.URL,
+			response,
+			err)
+
+		fmt.Println(warning)
+	})
+
+	err = collector.Visit(pageIRI)
+	if err != nil {
+		return details, err
+	}
+
+	collector.Wait()
+
+	err = failedRequests.Err()
+	if err != nil {
+		return details, err
+	}
+
+	if !found {
+		return details, ErrProductDetailsAreNotFound
+	}
+
+	return details, nil
+}
+
+// imagesOf return absolute links of all images of gallery without duplicates.
+// Link of image is taken from first attribute of chain with link, inline images of placeholders are skipped.
+func imagesOf(page *colly.HTMLElement, selector string, attributes []string) []string {
+	var images []string
+
+	seen := map[string]bool{}
+
+	for _, image := range selectors.Find(page.DOM.Nodes[0], selector) {
+		for _, attribute := range attributes {
+			link := selectors.AttributeOf(image, attribute)
+			if link == "" || strings.HasPrefix(link, "data:") {
+				continue
+			}
+
+			link = page.Request.AbsoluteURL(link)
+			if link != "" && !seen[link] {
+				seen[link] = true
+				images = append(images, link)
+			}
+
+			break
+		}
+	}
+
+	return images
+}
+
+// specificationsOf return specifications of rows of page, rows without name are skipped
+func specificationsOf(page *html.Node, instruction entities.DetailPageInstruction) []Specification {
+	var specifications []Specification
+
+	for _, row := range selectors.Find(page, instruction.SpecificationSelector) {
+		specification := Specification{
+			Name:  strings.TrimSpace(strings.TrimSuffix(selectors.ChildText(row, instruction.SpecificationNameSelector), ":")),
+			Value: selectors.ChildText(row, instruction.SpecificationValueSelector)}
+
+		if specification.Name == "" {
+			continue
+		}
+
+		specifications = append(specifications, specification)
+	}
+
+	return specifications
+}
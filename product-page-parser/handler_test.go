@@ -0,0 +1,146 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/prices"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParserCanParseProductPage(t *testing.T) {
+	testPageContent := `
+		<html><head>
+			<script type="application/ld+json">
+			{"@type": "Product", "name": "Phone from JSON-LD", "sku": "12345", "gtin13": "4600000000011",
+				"offers": {"@type": "Offer", "price": "19990", "priceCurrency": "RUB",
+					"availability": "https://schema.org/InStock"}}
+			</script>
+		</head><body>
+			<h1 class="title"> Phone </h1>
+			<div class="description"><p>Good phone.</p></div>
+			<div class="gallery">
+				<img src="/images/1.jpg"><img src="/images/2.jpg"><img src="/images/1.jpg">
+				<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-src="/images/3.jpg">
+			</div>
+			<span class="price">21 990 ₽</span>
+			<span class="old-price">24 990 ₽</span>
+			<table class="specifications">
+				<tr><td>Color:</td><td>black</td></tr>
+				<tr><td>Memory</td><td>128 GB</td></tr>
+				<tr><td></td><td>skipped</td></tr>
+			</table>
+		</body></html>`
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/product/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testPageContent))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := Request{
+		ProductIRI: fmt.Sprint(server.URL, "/product/1"),
+		DetailPageInstruction: entities.DetailPageInstruction{
+			NameSelector:               "h1.title",
+			DescriptionSelector:        ".description",
+			PriceSelector:              ".price",
+			OldPriceSelector:           ".old-price",
+			ImageSelector:              "xpath://div[@class='gallery']/img",
+			SpecificationSelector:      ".specifications tr",
+			SpecificationNameSelector:  "td:first-child",
+			SpecificationValueSelector: "xpath:./td[2]"},
+		StructuredData: true}
+
+	details, err := parseProductPage(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if details.Name != "Phone" || details.Description != "Good phone." {
+		t.Errorf("expected '%v' but got '%v'", "Phone", details)
+	}
+
+	if details.SKU != "12345" || details.GTIN != "4600000000011" {
+		t.Errorf("expected '%v' but got '%v'", "12345", details)
+	}
+
+	expectedImages := []string{server.URL + "/images/1.jpg", server.URL + "/images/2.jpg"}
+	if fmt.Sprint(details.Images) != fmt.Sprint(expectedImages) {
+		t.Errorf("expected '%v' but got '%v'", expectedImages, details.Images)
+	}
+
+	expectedSpecifications := []Specification{{Name: "Color", Value: "black"}, {Name: "Memory", Value: "128 GB"}}
+	if fmt.Sprint(details.Specifications) != fmt.Sprint(expectedSpecifications) {
+		t.Errorf("expected '%v' but got '%v'", expectedSpecifications, details.Specifications)
+	}
+
+	price := details.Price
+	if price.Value != 21990 || price.OldValue != 24990 || price.Currency != "RUB" || price.Availability != prices.InStock {
+		t.Errorf("expected '%v' but got '%v'", 21990, price)
+	}
+
+	request.StructuredData = false
+	request.DetailPageInstruction.NameSelector = ".name"
+	request.ImageAttributes = []string{"data-src", "src"}
+
+	details, err = parseProductPage(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expectedImages = append(expectedImages, server.URL+"/images/3.jpg")
+	if fmt.Sprint(details.Images) != fmt.Sprint(expectedImages) {
+		t.Errorf("expected '%v' but got '%v'", expectedImages, details.Images)
+	}
+
+	if details.Name != "" || details.SKU != "" || details.Price.Availability != "" {
+		t.Errorf("expected '%v' but got '%v'", "details without structured data", details)
+	}
+
+	request.DetailPageInstruction = entities.DetailPageInstruction{NameSelector: ".name"}
+
+	_, err = parseProductPage(request)
+	if err != ErrProductDetailsAreNotFound {
+		t.Errorf("expected '%v' but got '%v'", ErrProductDetailsAreNotFound, err)
+	}
+}
+
+func TestParserCanNotParseProductPageWithoutIRI(t *testing.T) {
+	bytes, err := json.Marshal(Request{})
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	response := Response{}
+
+	err = json.Unmarshal([]byte(Handle(bytes)), &response)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+
+	if response.Error != ErrProductIRICanNotBeEmpty.Error() {
+		t.Errorf("expected '%v' but got '%v'", ErrProductIRICanNotBeEmpty, response.Error)
+	}
+}
+
+func TestParserCanNotParseProductPageForCityWithoutCode(t *testing.T) {
+	request := Request{
+		ProductIRI:            "http://shop/products/phone-1",
+		DetailPageInstruction: entities.DetailPageInstruction{CityParamPath: "?city=", CityIDForCookie: "spb"},
+		City:                  City{ID: "0x12", Name: "Moscow"}}
+
+	_, err := parseProductPage(request)
+	if err != ErrCityCanNotBeWithoutCode {
+		t.Errorf("expected '%v' but got '%v'", ErrCityCanNotBeWithoutCode, err)
+	}
+}
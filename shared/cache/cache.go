@@ -0,0 +1,129 @@
+// Package cache keep pages of shop in directory and revalidate them by conditional requests
+// with ETag and Last-Modified of saved page, so not modified pages are not downloaded again.
+// Pages are saved the same way as fixtures, only pages with ETag or Last-Modified are saved.
+package cache
+
+import (
+	"bytes"
+	"github.com/hecatoncheir/Functions/shared/fixtures"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// NotModifiedHeader is a header of saved page returned for response with status 304 Not Modified
+const NotModifiedHeader = "X-Cache-Not-Modified"
+
+// Transport is a http.RoundTripper which make conditional requests by Transport for pages saved to Directory.
+// Cookies are names of cookies which change page of the same URL, like cookie of city of shop,
+// so page is saved for every value of them.
+type Transport struct {
+	Directory string
+	Transport http.RoundTripper
+	Cookies   []string
+}
+
+// RoundTrip return saved page with NotModifiedHeader if it is not modified,
+// otherwise response of Transport is returned and saved
+func (transport *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		return transport.Transport.RoundTrip(request)
+	}
+
+	key := transport.keyOf(request)
+
+	saved, err := fixtures.Load(transport.Directory, key, request)
+	if err != nil && err != fixtures.ErrFixtureNotFound {
+		return nil, err
+	}
+
+	// http.RoundTripper must not modify request, so conditions are set to copy of it
+	if saved != nil {
+		request = cloneRequest(request)
+
+		if eTag := saved.Header.Get("ETag"); eTag != "" {
+			request.Header.Set("If-None-Match", eTag)
+		}
+
+		if lastModified := saved.Header.Get("Last-Modified"); lastModified != "" {
+			request.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	response, err := transport.Transport.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode == http.StatusNotModified && saved != nil {
+		response.Body.Close()
+
+		// request of response keep context of request, like proxy of it
+		saved.Request = response.Request
+		saved.Header.Set(NotModifiedHeader, "true")
+
+		return saved, nil
+	}
+
+	if response.StatusCode != http.StatusOK ||
+		(response.Header.Get("ETag") == "" && response.Header.Get("Last-Modified") == "") {
+		return response, nil
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	page := fixtures.Fixture{
+		URL:        key,
+		StatusCode: response.StatusCode,
+		Header:     response.Header}
+
+	err = fixtures.Save(transport.Directory, page, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// keyOf return URL of request with values of Cookies of request, like "http://shop/catalog#city=spb",
+// so pages of different cities are saved separately
+func (transport *Transport) keyOf(request *http.Request) string {
+	var values []string
+
+	for _, name := range transport.Cookies {
+		// Cookie with empty name is a first cookie of request
+		if name == "" {
+			continue
+		}
+
+		cookie, err := request.Cookie(name)
+		if err == nil {
+			values = append(values, name+"="+cookie.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		return request.URL.String()
+	}
+
+	return request.URL.String() + "#" + strings.Join(values, "&")
+}
+
+// cloneRequest return shallow copy of request with own copy of headers
+func cloneRequest(request *http.Request) *http.Request {
+	clone := new(http.Request)
+	*clone = *request
+
+	clone.Header = make(http.Header, len(request.Header))
+	for key, values := range request.Header {
+		clone.Header[key] = append([]string(nil), values...)
+	}
+
+	return clone
+}
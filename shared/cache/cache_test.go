@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestPagesCanBeRevalidated(t *testing.T) {
+	directory, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	version := "1"
+	var conditions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditions = append(conditions, r.Header.Get("If-None-Match"))
+
+		if r.Header.Get("If-None-Match") == `"`+version+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"`+version+`"`)
+		w.WriteHeader(200)
+		_, err := w.Write([]byte("page " + version))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}))
+	defer server.Close()
+
+	client := http.Client{Transport: &Transport{Directory: directory, Transport: http.DefaultTransport}}
+
+	get := func() (string, bool) {
+		request, err := http.NewRequest(http.MethodGet, server.URL+"/catalog", nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if len(request.Header) != 0 {
+			t.Errorf("expected request is not modified but got headers '%v'", request.Header)
+		}
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		return string(body), response.Header.Get(NotModifiedHeader) != ""
+	}
+
+	if body, notModified := get(); body != "page 1" || notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page 1", body, notModified)
+	}
+
+	if body, notModified := get(); body != "page 1" || !notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page 1", body, notModified)
+	}
+
+	version = "2"
+
+	if body, notModified := get(); body != "page 2" || notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page 2", body, notModified)
+	}
+
+	expectedConditions := []string{"", `"1"`, `"1"`}
+	for index, condition := range expectedConditions {
+		if conditions[index] != condition {
+			t.Errorf("expected '%v' but got '%v'", condition, conditions[index])
+		}
+	}
+}
+
+func TestPagesOfCitiesCanBeCachedSeparately(t *testing.T) {
+	directory, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		city, err := r.Cookie("city")
+		if err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"`+city.Value+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"`+city.Value+`"`)
+		w.WriteHeader(200)
+		_, err = w.Write([]byte("page of " + city.Value))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}))
+	defer server.Close()
+
+	client := http.Client{Transport: &Transport{
+		Directory: directory,
+		Transport: http.DefaultTransport,
+		Cookies:   []string{"", "city"}}}
+
+	get := func(city string) (string, bool) {
+		request, err := http.NewRequest(http.MethodGet, server.URL+"/catalog", nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		request.AddCookie(&http.Cookie{Name: "session", Value: "1"})
+		request.AddCookie(&http.Cookie{Name: "city", Value: city})
+
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		return string(body), response.Header.Get(NotModifiedHeader) != ""
+	}
+
+	if body, notModified := get("spb"); body != "page of spb" || notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page of spb", body, notModified)
+	}
+
+	if body, notModified := get("moscow"); body != "page of moscow" || notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page of moscow", body, notModified)
+	}
+
+	if body, notModified := get("spb"); body != "page of spb" || !notModified {
+		t.Errorf("expected '%v' but got '%v' and not modified: %v", "page of spb", body, notModified)
+	}
+}
@@ -0,0 +1,61 @@
+package crawl
+
+import (
+	"errors"
+	"github.com/gocolly/colly"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrCityCanNotBeWithoutCode means that city can't be applied to page without code of it for shop
+var ErrCityCanNotBeWithoutCode = errors.New("city can not be without code")
+
+// CitySettings are settings of instruction for request of page of city:
+// param with code of city which is added to IRI, key of cookie with code of city
+// and code of city in cookie for request without city.
+type CitySettings struct {
+	ParamPath, InCookieKey, IDForCookie string
+}
+
+// ApplyCity add code of city to param of IRI or to cookie of collector
+// by settings of instruction and return IRI for visit.
+// City with ID must have code if settings have param or cookie of city,
+// IDForCookie of settings is used only for request without city.
+func ApplyCity(collector *colly.Collector, pageIRI string, settings CitySettings, cityID, cityCode string) (string, error) {
+	if settings.ParamPath == "" && settings.InCookieKey == "" {
+		return pageIRI, nil
+	}
+
+	if cityCode == "" && cityID != "" {
+		return pageIRI, ErrCityCanNotBeWithoutCode
+	}
+
+	if cityCode == "" {
+		cityCode = settings.IDForCookie
+	}
+
+	if cityCode == "" {
+		return pageIRI, nil
+	}
+
+	if settings.InCookieKey != "" {
+		cookie := &http.Cookie{Name: settings.InCookieKey, Value: cityCode, Path: "/"}
+
+		err := collector.SetCookies(pageIRI, []*http.Cookie{cookie})
+		if err != nil {
+			return pageIRI, err
+		}
+	}
+
+	if settings.ParamPath == "" {
+		return pageIRI, nil
+	}
+
+	cityParamPath := settings.ParamPath
+	if strings.Contains(pageIRI, "?") && strings.HasPrefix(cityParamPath, "?") {
+		cityParamPath = "&" + strings.TrimPrefix(cityParamPath, "?")
+	}
+
+	return pageIRI + cityParamPath + url.QueryEscape(cityCode), nil
+}
@@ -0,0 +1,45 @@
+package crawl
+
+import (
+	"github.com/gocolly/colly"
+	"testing"
+)
+
+func TestCityCanBeAppliedToParamAndCookie(t *testing.T) {
+	collector := colly.NewCollector()
+
+	settings := CitySettings{ParamPath: "?cityId=", InCookieKey: "MVID_CITY_ID"}
+
+	pageIRI, err := ApplyCity(collector, "http://shop/catalog?page=2", settings, "0x12", "CityCZ_975")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if pageIRI != "http://shop/catalog?page=2&cityId=CityCZ_975" {
+		t.Errorf("expected '%v' but got '%v'", "http://shop/catalog?page=2&cityId=CityCZ_975", pageIRI)
+	}
+
+	cookies := collector.Cookies("http://shop/catalog")
+	if len(cookies) != 1 || cookies[0].Name != "MVID_CITY_ID" || cookies[0].Value != "CityCZ_975" {
+		t.Errorf("expected cookie '%v' of city but got '%v'", "MVID_CITY_ID=CityCZ_975", cookies)
+	}
+
+	pageIRI, err = ApplyCity(collector, "http://shop/catalog", CitySettings{ParamPath: "?city=", IDForCookie: "spb"}, "", "")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if pageIRI != "http://shop/catalog?city=spb" {
+		t.Errorf("expected '%v' but got '%v'", "http://shop/catalog?city=spb", pageIRI)
+	}
+
+	_, err = ApplyCity(collector, "http://shop/catalog", settings, "0x12", "")
+	if err != ErrCityCanNotBeWithoutCode {
+		t.Errorf("expected '%v' but got '%v'", ErrCityCanNotBeWithoutCode, err)
+	}
+
+	pageIRI, err = ApplyCity(collector, "http://shop/catalog", CitySettings{}, "0x12", "")
+	if err != nil || pageIRI != "http://shop/catalog" {
+		t.Errorf("expected '%v' without city but got '%v', '%v'", "http://shop/catalog", pageIRI, err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package crawl make colly collectors of parsers with limits of politeness,
+// rotation of proxies and user agents and retries of failed requests.
+package crawl
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultParallelism is a count of parallel requests to one domain
+	DefaultParallelism = 2
+
+	// DefaultRetries is a count of retries of request failed with 429 or 5xx status
+	DefaultRetries = 3
+
+	// DefaultRetryDelay is a delay in milliseconds before first retry, it is doubled for every next retry
+	DefaultRetryDelay = 500
+)
+
+// ErrPageCanNotBeLoaded means that the page is not loaded after all retries
+var ErrPageCanNotBeLoaded = errors.New("page can not be loaded")
+
+// Politeness is a settings of requests to shop. Delays are in milliseconds.
+// Defaults are used for zero Parallelism, Retries and RetryDelay, negative Retries turn retries off.
+// RandomDelay is added to Delay for every request.
+// Rotation is optional, requests are sent without proxies and with user agent of colly if it is empty.
+type Politeness struct {
+	Parallelism      int
+	Delay            int
+	RandomDelay      int
+	Retries          int
+	RetryDelay       int
+	RespectRobotsTxt bool
+	Rotation         Rotation
+}
+
+// Failures of requests which are failed for good
+type Failures struct {
+	sync.Mutex
+	warnings []string
+}
+
+// Add failure of request
+func (failures *Failures) Add(warning string) {
+	failures.Lock()
+	defer failures.Unlock()
+
+	failures.warnings = append(failures.warnings, warning)
+}
+
+// Warnings return failures of requests
+func (failures *Failures) Warnings() []string {
+	failures.Lock()
+	defer failures.Unlock()
+
+	return append([]string(nil), failures.warnings...)
+}
+
+// Err return ErrPageCanNotBeLoaded with all failures or nil if all requests are succeed
+func (failures *Failures) Err() error {
+	failures.Lock()
+	defer failures.Unlock()
+
+	if len(failures.warnings) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%v: %v", ErrPageCanNotBeLoaded, strings.Join(failures.warnings, "; "))
+}
+
+// NewCollector make async collector with limits of politeness for all domains,
+// proxies and user agents of rotation and retries of requests failed with 429 or 5xx status.
+// Transport of requests is wrapped by wrappers in order, like by cache of pages.
+func NewCollector(politeness Politeness, wrappers ...func(http.RoundTripper) http.RoundTripper) (*colly.Collector, *Failures, error) {
+	parallelism := politeness.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	retries := politeness.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+
+	retryDelay := politeness.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = DefaultRetryDelay
+	}
+
+	collector := colly.NewCollector(colly.Async(true))
+	collector.IgnoreRobotsTxt = !politeness.RespectRobotsTxt
+
+	err := collector.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: parallelism,
+		Delay:       time.Duration(politeness.Delay) * time.Millisecond,
+		RandomDelay: time.Duration(politeness.RandomDelay) * time.Millisecond})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rotationPool, err := newPool(politeness.Rotation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second}
+
+	roundTripper := rotationPool.apply(collector, transport)
+	for _, wrap := range wrappers {
+		roundTripper = wrap(roundTripper)
+	}
+
+	collector.WithTransport(roundTripper)
+
+	failedRequests := &Failures{}
+
+	collector.OnError(func(response *colly.Response, err error) {
+		attempt, _ := response.Ctx.GetAny("attempt").(int)
+
+		if isRetryable(response.StatusCode) && attempt < retries {
+			delay := backoff(retryDelay, attempt, response)
+
+			fmt.Printf("Retry request URL: %v after: %v. Error: %v\n", response.Request.URL, delay, err)
+
+			time.Sleep(delay)
+
+			response.Ctx.Put("attempt", attempt+1)
+
+			err = response.Request.Retry()
+			if err == nil {
+				return
+			}
+		}
+
+		failedRequests.Add(fmt.Sprintf(
+			"URL: %v, status: %v, error: %v", response.Request.URL, response.StatusCode, err))
+	})
+
+	return collector, failedRequests, nil
+}
+
+// isRetryable return true for statuses of overloaded or broken shop
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// backoff return exponential delay for attempt, or delay of Retry-After header if it is longer
+func backoff(retryDelay, attempt int, response *colly.Response) time.Duration {
+	delay := time.Duration(float64(retryDelay)*math.Pow(2, float64(attempt))) * time.Millisecond
+
+	if response.Headers == nil {
+		return delay
+	}
+
+	retryAfter, err := strconv.Atoi(response.Headers.Get("Retry-After"))
+	if err == nil && time.Duration(retryAfter)*time.Second > delay {
+		return time.Duration(retryAfter) * time.Second
+	}
+
+	return delay
+}
@@ -0,0 +1,271 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"github.com/gocolly/colly"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// RotationRoundRobin take proxies and user agents in order
+	RotationRoundRobin = "round_robin"
+
+	// RotationRandom take random proxy and user agent for every request
+	RotationRandom = "random"
+
+	// DefaultProxyMaxFailures is a count of failed requests in a row after which proxy is dropped
+	DefaultProxyMaxFailures = 3
+
+	// DefaultProxyCooldown is a time in milliseconds for which failed proxy is dropped
+	DefaultProxyCooldown = 60000
+)
+
+var (
+	// ErrRotationModeIsUnknown means that mode of rotation is not RotationRoundRobin or RotationRandom
+	ErrRotationModeIsUnknown = errors.New("rotation mode is unknown")
+
+	// ErrProxyCanNotBeParsed means that proxy of rotation is not a valid URL
+	ErrProxyCanNotBeParsed = errors.New("proxy can not be parsed")
+
+	// ErrProxiesAreUnavailable means that all proxies are dropped for failures
+	ErrProxiesAreUnavailable = errors.New("proxies are unavailable")
+)
+
+// Rotation is a settings of proxies and user agents of requests, all fields are optional.
+// Mode is RotationRoundRobin by default. Proxy is dropped for Cooldown milliseconds
+// after MaxFailures failed requests in a row, defaults are used for zero values.
+type Rotation struct {
+	Mode        string
+	Proxies     []string
+	UserAgents  []string
+	MaxFailures int
+	Cooldown    int
+}
+
+// proxy of pool with failures of it
+type proxy struct {
+	url          *url.URL
+	failures     int
+	droppedUntil time.Time
+}
+
+// pool rotate proxies and user agents for requests of collector
+type pool struct {
+	sync.Mutex
+
+	mode        string
+	proxies     []*proxy
+	userAgents  []string
+	maxFailures int
+	cooldown    time.Duration
+
+	nextProxy     int
+	nextUserAgent int
+
+	// now is a clock of pool, it is replaced in tests
+	now func() time.Time
+}
+
+// newPool parse proxies of rotation
+func newPool(rotation Rotation) (*pool, error) {
+	rotationPool := &pool{
+		mode:        rotation.Mode,
+		userAgents:  rotation.UserAgents,
+		maxFailures: rotation.MaxFailures,
+		cooldown:    time.Duration(rotation.Cooldown) * time.Millisecond,
+		now:         time.Now}
+
+	if rotationPool.mode == "" {
+		rotationPool.mode = RotationRoundRobin
+	}
+
+	if rotationPool.mode != RotationRoundRobin && rotationPool.mode != RotationRandom {
+		return nil, ErrRotationModeIsUnknown
+	}
+
+	if rotationPool.maxFailures <= 0 {
+		rotationPool.maxFailures = DefaultProxyMaxFailures
+	}
+
+	if rotationPool.cooldown <= 0 {
+		rotationPool.cooldown = DefaultProxyCooldown * time.Millisecond
+	}
+
+	for _, proxyURL := range rotation.Proxies {
+		parsedURL, err := url.Parse(proxyURL)
+		if err != nil || parsedURL.Host == "" {
+			return nil, ErrProxyCanNotBeParsed
+		}
+
+		rotationPool.proxies = append(rotationPool.proxies, &proxy{url: parsedURL})
+	}
+
+	return rotationPool, nil
+}
+
+// apply set user agents of pool to requests of collector
+// and wrap transport by proxyTransport which choose proxies of pool and track failures of them
+func (rotationPool *pool) apply(collector *colly.Collector, transport *http.Transport) http.RoundTripper {
+	if len(rotationPool.userAgents) > 0 {
+		collector.OnRequest(func(request *colly.Request) {
+			request.Headers.Set("User-Agent", rotationPool.userAgent())
+		})
+	}
+
+	if len(rotationPool.proxies) == 0 {
+		return transport
+	}
+
+	transport.Proxy = proxyOfRequest
+
+	return &proxyTransport{pool: rotationPool, transport: transport}
+}
+
+func (rotationPool *pool) userAgent() string {
+	rotationPool.Lock()
+	defer rotationPool.Unlock()
+
+	index := rotationPool.nextUserAgent % len(rotationPool.userAgents)
+	if rotationPool.mode == RotationRandom {
+		index = rand.Intn(len(rotationPool.userAgents))
+	}
+
+	rotationPool.nextUserAgent = index + 1
+
+	return rotationPool.userAgents[index]
+}
+
+// proxy return next proxy which is not dropped
+func (rotationPool *pool) proxy() (*url.URL, error) {
+	rotationPool.Lock()
+	defer rotationPool.Unlock()
+
+	now := rotationPool.now()
+
+	var available []int
+	for index, proxy := range rotationPool.proxies {
+		if !now.Before(proxy.droppedUntil) {
+			available = append(available, index)
+		}
+	}
+
+	if len(available) == 0 {
+		return nil, ErrProxiesAreUnavailable
+	}
+
+	index := available[rand.Intn(len(available))]
+
+	if rotationPool.mode == RotationRoundRobin {
+		index = available[0]
+
+		for _, availableIndex := range available {
+			if availableIndex >= rotationPool.nextProxy%len(rotationPool.proxies) {
+				index = availableIndex
+				break
+			}
+		}
+	}
+
+	rotationPool.nextProxy = index + 1
+
+	return rotationPool.proxies[index].url, nil
+}
+
+// succeed reset failures of proxy
+func (rotationPool *pool) succeed(proxyURL string) {
+	rotationPool.Lock()
+	defer rotationPool.Unlock()
+
+	for _, proxy := range rotationPool.proxies {
+		if proxy.url.String() == proxyURL {
+			proxy.failures = 0
+		}
+	}
+}
+
+// fail count failure of proxy and drop it for cooldown after MaxFailures in a row
+func (rotationPool *pool) fail(proxyURL string) {
+	rotationPool.Lock()
+	defer rotationPool.Unlock()
+
+	for _, proxy := range rotationPool.proxies {
+		if proxy.url.String() != proxyURL {
+			continue
+		}
+
+		proxy.failures++
+
+		if proxy.failures >= rotationPool.maxFailures {
+			proxy.failures = 0
+			proxy.droppedUntil = rotationPool.now().Add(rotationPool.cooldown)
+		}
+	}
+}
+
+// proxyKey is a key of context of request with proxy chosen by proxyTransport
+type proxyKey struct{}
+
+// proxyOfRequest is a proxy function of transport, it return proxy chosen for request by proxyTransport
+func proxyOfRequest(request *http.Request) (*url.URL, error) {
+	proxyURL, _ := request.Context().Value(proxyKey{}).(*url.URL)
+	return proxyURL, nil
+}
+
+// proxyTransport send request by proxy of pool, so failure of proxy is counted
+// even if request is failed without response, like on refused connection to proxy.
+// Request failed without response is sent by next proxy, until all proxies are tried.
+type proxyTransport struct {
+	pool      *pool
+	transport http.RoundTripper
+}
+
+// RoundTrip is a method of http.RoundTripper
+func (proxyTransport *proxyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < len(proxyTransport.pool.proxies); attempt++ {
+		proxyURL, err := proxyTransport.pool.proxy()
+		if err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+
+			return nil, err
+		}
+
+		proxyRequest := request.WithContext(context.WithValue(request.Context(), proxyKey{}, proxyURL))
+
+		if attempt > 0 && request.Body != nil {
+			if request.GetBody == nil {
+				return nil, lastErr
+			}
+
+			proxyRequest.Body, err = request.GetBody()
+			if err != nil {
+				return nil, lastErr
+			}
+		}
+
+		response, err := proxyTransport.transport.RoundTrip(proxyRequest)
+		if err != nil {
+			proxyTransport.pool.fail(proxyURL.String())
+			lastErr = err
+			continue
+		}
+
+		if isRetryable(response.StatusCode) || response.StatusCode == http.StatusProxyAuthRequired {
+			proxyTransport.pool.fail(proxyURL.String())
+		} else {
+			proxyTransport.pool.succeed(proxyURL.String())
+		}
+
+		return response, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,118 @@
+package crawl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPoolCanDropFailingProxies(t *testing.T) {
+	rotationPool, err := newPool(Rotation{
+		Proxies:     []string{"http://first:3128", "http://second:3128"},
+		MaxFailures: 2,
+		Cooldown:    1000})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	rotationPool.now = func() time.Time { return now }
+
+	next := func() string {
+		proxyURL, err := rotationPool.proxy()
+		if err != nil {
+			return err.Error()
+		}
+
+		return proxyURL.Host
+	}
+
+	if first, second, third := next(), next(), next(); first != "first:3128" || second != "second:3128" || third != "first:3128" {
+		t.Errorf("expected round robin of proxies but got '%v', '%v', '%v'", first, second, third)
+	}
+
+	rotationPool.fail("http://first:3128")
+	rotationPool.succeed("http://first:3128")
+	rotationPool.fail("http://first:3128")
+
+	if second, first := next(), next(); second != "second:3128" || first != "first:3128" {
+		t.Errorf("expected '%v' to be not dropped but got '%v', '%v'", "first:3128", second, first)
+	}
+
+	rotationPool.fail("http://first:3128")
+
+	if first, second := next(), next(); first != "second:3128" || second != "second:3128" {
+		t.Errorf("expected only '%v' but got '%v', '%v'", "second:3128", first, second)
+	}
+
+	rotationPool.fail("http://second:3128")
+	rotationPool.fail("http://second:3128")
+
+	if proxy := next(); proxy != ErrProxiesAreUnavailable.Error() {
+		t.Errorf("expected '%v' but got '%v'", ErrProxiesAreUnavailable, proxy)
+	}
+
+	now = now.Add(time.Second)
+
+	if proxy := next(); proxy != "first:3128" {
+		t.Errorf("expected '%v' but got '%v'", "first:3128", proxy)
+	}
+
+	_, err = newPool(Rotation{Mode: "sequential"})
+	if err != ErrRotationModeIsUnknown {
+		t.Errorf("expected '%v' but got '%v'", ErrRotationModeIsUnknown, err)
+	}
+}
+
+func TestTransportCanDropClosedProxy(t *testing.T) {
+	closedProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedProxy.Close()
+
+	goodProxyRequests := 0
+
+	goodProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goodProxyRequests++
+		_, err := w.Write([]byte("page"))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}))
+	defer goodProxy.Close()
+
+	rotationPool, err := newPool(Rotation{
+		Proxies:     []string{closedProxy.URL, goodProxy.URL},
+		MaxFailures: 1})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	transport := rotationPool.apply(nil, &http.Transport{})
+
+	for i := 0; i < 2; i++ {
+		request, err := http.NewRequest("GET", "http://shop.test/catalog", nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		response, err := transport.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("expected request to be sent by next proxy but got '%v'", err)
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil || string(body) != "page" {
+			t.Errorf("expected '%v' but got '%v', '%v'", "page", string(body), err)
+		}
+	}
+
+	if goodProxyRequests != 2 {
+		t.Errorf("expected '%d' requests by good proxy but got '%d'", 2, goodProxyRequests)
+	}
+
+	if proxyURL, err := rotationPool.proxy(); err != nil || proxyURL.String() != goodProxy.URL {
+		t.Errorf("expected closed proxy to be dropped but got '%v', '%v'", proxyURL, err)
+	}
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Storage"
+)
+
+// City is a storage.City of instruction with code of it for shop of instruction,
+// like "spb" or "CityCZ_975". Code is a facet of has_city edge of instruction,
+// so one city has own code for every company.
+type City struct {
+	storage.City
+	Code string `json:"has_city|cityCode,omitempty"`
+}
+
+// UnmarshalJSON decode city with code of facet which is returned by Dgraph
+// as "has_city|cityCode" key or as "cityCode" of "@facets" object by version of it
+func (city *City) UnmarshalJSON(data []byte) error {
+	var cityWithFacets struct {
+		storage.City
+		Code   string `json:"has_city|cityCode"`
+		Facets struct {
+			Edge struct {
+				Code string `json:"cityCode"`
+			} `json:"_"`
+		} `json:"@facets"`
+	}
+
+	err := json.Unmarshal(data, &cityWithFacets)
+	if err != nil {
+		return err
+	}
+
+	city.City = cityWithFacets.City
+	city.Code = cityWithFacets.Code
+
+	if city.Code == "" {
+		city.Code = cityWithFacets.Facets.Edge.Code
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package entities
+
+// DetailPageInstruction is a structure of settings for parse of detail page of product by product-page-parser.
+// Selectors are CSS selectors or XPath expressions with prefix "xpath:".
+// ImageSelector match all images of gallery, SpecificationSelector match rows of specifications
+// and SpecificationNameSelector with SpecificationValueSelector are searched inside of every row.
+// IsActive is false for deleted detail page instruction.
+type DetailPageInstruction struct {
+	ID                         string `json:"uid,omitempty"`
+	NameSelector               string `json:"nameSelector,omitempty"`
+	DescriptionSelector        string `json:"descriptionSelector,omitempty"`
+	SKUSelector                string `json:"skuSelector,omitempty"`
+	GTINSelector               string `json:"gtinSelector,omitempty"`
+	PriceSelector              string `json:"priceSelector,omitempty"`
+	OldPriceSelector           string `json:"oldPriceSelector,omitempty"`
+	AvailabilitySelector       string `json:"availabilitySelector,omitempty"`
+	ImageSelector              string `json:"imageSelector,omitempty"`
+	SpecificationSelector      string `json:"specificationSelector,omitempty"`
+	SpecificationNameSelector  string `json:"specificationNameSelector,omitempty"`
+	SpecificationValueSelector string `json:"specificationValueSelector,omitempty"`
+	CityParamPath              string `json:"cityParamPath,omitempty"`
+	CityInCookieKey            string `json:"cityInCookieKey,omitempty"`
+	CityIDForCookie            string `json:"cityIdForCookie,omitempty"`
+	IsActive                   bool   `json:"detailPageInstructionIsActive"`
+}
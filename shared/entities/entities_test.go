@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Storage"
+	"testing"
+)
+
+func TestInstructionIsEncodedWithPredicatesOfStorage(t *testing.T) {
+	instruction := Instruction{
+		Instruction: storage.Instruction{ID: "0x12", Language: "ru", IsActive: true},
+		PagesInstruction: []PageInstruction{{
+			PageInstruction:            storage.PageInstruction{ID: "0x21", ItemSelector: ".item"},
+			AvailabilityOfItemSelector: ".stock",
+			PaginationStrategies:       []string{"next_link"}}}}
+
+	encodedInstruction, err := json.Marshal(instruction)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `{"uid":"0x12","instructionLanguage":"ru","instructionIsActive":true,"has_page":[` +
+		`{"uid":"0x21","itemSelector":".item","availabilityOfItemSelector":".stock","paginationStrategies":["next_link"]}]}`
+
+	if string(encodedInstruction) != expected {
+		t.Errorf("expected '%v' but got '%v'", expected, string(encodedInstruction))
+	}
+
+	decodedInstruction := Instruction{}
+
+	err = json.Unmarshal(encodedInstruction, &decodedInstruction)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(decodedInstruction.PagesInstruction) != 1 ||
+		decodedInstruction.PagesInstruction[0].ItemSelector != ".item" ||
+		decodedInstruction.PagesInstruction[0].AvailabilityOfItemSelector != ".stock" {
+		t.Errorf("expected page instruction with all selectors but got '%v'", decodedInstruction.PagesInstruction)
+	}
+}
+
+func TestProductIsDecodedWithAvailabilityOfPrices(t *testing.T) {
+	encodedProduct := `{"uid":"0x12","productName":"Test product","productIsActive":true,"has_price":[` +
+		`{"uid":"0x13","priceValue":0,"priceAvailability":"out_of_stock","priceIsActive":true},` +
+		`{"uid":"0x14","priceValue":90,"priceCurrency":"RUB","priceOldValue":100,"priceDiscount":10,"priceIsActive":true}]}`
+
+	product := Product{}
+
+	err := json.Unmarshal([]byte(encodedProduct), &product)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if product.ID != "0x12" || product.Name != "Test product" {
+		t.Errorf("expected product with predicates of storage but got '%v'", product)
+	}
+
+	if len(product.Prices) != 2 || product.Prices[0].ID != "0x13" || product.Prices[0].Availability != "out_of_stock" {
+		t.Fatalf("expected price with availability but got '%v'", product.Prices)
+	}
+
+	discountedPrice := product.Prices[1]
+	if discountedPrice.Currency != "RUB" || discountedPrice.OldValue != 100 || discountedPrice.Discount != 10 {
+		t.Errorf("expected price with currency and discount but got '%v'", discountedPrice)
+	}
+}
+
+func TestProductIsDecodedWithDetails(t *testing.T) {
+	encodedProduct := `{"uid":"0x12","productName":"Test product","productIsActive":true,` +
+		`"productDescription":"Good phone.","productSku":"12345","productGtin":"4600000000011",` +
+		`"productImages":["https://www.shop.ru/images/1.jpg"],` +
+		`"has_specification":[{"uid":"0x15","specificationName":"Color","specificationValue":"black"}]}`
+
+	product := Product{}
+
+	err := json.Unmarshal([]byte(encodedProduct), &product)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if product.Description != "Good phone." || product.SKU != "12345" || product.GTIN != "4600000000011" {
+		t.Errorf("expected product with description, SKU and GTIN but got '%v'", product)
+	}
+
+	if len(product.Images) != 1 || product.Images[0] != "https://www.shop.ru/images/1.jpg" {
+		t.Errorf("expected product with images but got '%v'", product.Images)
+	}
+
+	if len(product.Specifications) != 1 || product.Specifications[0].Name != "Color" || product.Specifications[0].Value != "black" {
+		t.Errorf("expected product with specifications but got '%v'", product.Specifications)
+	}
+}
+
+func TestCityIsDecodedWithCodeOfFacet(t *testing.T) {
+	encodedInstructions := []string{
+		`{"uid":"0x12","has_city":[{"uid":"0x13","cityName":"Moscow","has_city|cityCode":"moscow"}]}`,
+		`{"uid":"0x12","has_city":[{"uid":"0x13","cityName":"Moscow","@facets":{"_":{"cityCode":"moscow"}}}]}`}
+
+	for _, encodedInstruction := range encodedInstructions {
+		instruction := Instruction{}
+
+		err := json.Unmarshal([]byte(encodedInstruction), &instruction)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if len(instruction.Cities) != 1 {
+			t.Fatalf("expected 1 city but got '%v'", instruction.Cities)
+		}
+
+		city := instruction.Cities[0]
+
+		if city.ID != "0x13" || city.Name != "Moscow" || city.Code != "moscow" {
+			t.Errorf("expected city with code '%v' but got '%v'", "moscow", city)
+		}
+	}
+
+	encodedCity, err := json.Marshal(City{City: storage.City{ID: "0x13"}, Code: "moscow"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `{"uid":"0x13","cityIsActive":false,"has_city|cityCode":"moscow"}`
+
+	if string(encodedCity) != expected {
+		t.Errorf("expected '%v' but got '%v'", expected, string(encodedCity))
+	}
+}
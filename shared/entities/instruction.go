@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"github.com/hecatoncheir/Storage"
+	"time"
+)
+
+// Instruction is a storage.Instruction with PageInstruction and City of entities,
+// PagesInstruction and Cities hide the same fields of storage.Instruction.
+// DetailPagesInstruction are instructions for parse of detail pages of products of company of instruction.
+// Version is a number of current version, it is 1 for never updated instruction.
+// UpdatedAt is a time since which current version is active.
+type Instruction struct {
+	storage.Instruction
+	PagesInstruction       []PageInstruction       `json:"has_page,omitempty"`
+	DetailPagesInstruction []DetailPageInstruction `json:"has_detail_page,omitempty"`
+	Cities                 []City                  `json:"has_city,omitempty"`
+	Version                int                     `json:"instructionVersion,omitempty"`
+	UpdatedAt              *time.Time              `json:"instructionUpdatedAt,omitempty"`
+}
@@ -0,0 +1,36 @@
+// Package entities extend entities of github.com/hecatoncheir/Storage by predicates
+// which are written and read by functions, but are not in Storage yet.
+// Entity embeds entity of Storage, so it is encoded with the same predicates,
+// and it is the only type of entity in functions which are parsers or storage of it.
+package entities
+
+import (
+	"github.com/hecatoncheir/Storage"
+	"time"
+)
+
+const (
+	// FormatHTML is a default format of page, selectors of page instruction are CSS selectors or XPath expressions
+	FormatHTML = "html"
+
+	// FormatJSON is a format of page loaded from API of shop, selectors of page instruction are JSONPath expressions
+	FormatJSON = "json"
+)
+
+// PageInstruction is a storage.PageInstruction with selectors of old price and availability of item,
+// format of page and settings of pagination strategies of mvideo-pages-count-parser.
+// Version is a number of current version of selectors, it is 1 for never updated page instruction.
+// UpdatedAt is a time since which current version is active.
+type PageInstruction struct {
+	storage.PageInstruction
+	OldPriceOfItemSelector     string     `json:"oldPriceOfItemSelector,omitempty"`
+	AvailabilityOfItemSelector string     `json:"availabilityOfItemSelector,omitempty"`
+	Format                     string     `json:"format,omitempty"`
+	PaginationStrategies       []string   `json:"paginationStrategies,omitempty"`
+	LastPageSelector           string     `json:"lastPageSelector,omitempty"`
+	NextPageSelector           string     `json:"nextPageSelector,omitempty"`
+	TotalItemsSelector         string     `json:"totalItemsSelector,omitempty"`
+	ItemsPerPage               int        `json:"itemsPerPage,omitempty"`
+	Version                    int        `json:"pageInstructionVersion,omitempty"`
+	UpdatedAt                  *time.Time `json:"pageInstructionUpdatedAt,omitempty"`
+}
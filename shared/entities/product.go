@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"github.com/hecatoncheir/Storage"
+)
+
+// Price is a storage.Price with status of availability of offer of product,
+// like "in_stock", "out_of_stock" or "pre_order". Price without value is a price of product
+// which is parsed with availability only.
+// OldValue is a value of price before discount, Discount is in percents, MaxValue is a top of range of price.
+type Price struct {
+	storage.Price
+	Availability string  `json:"priceAvailability,omitempty"`
+	Currency     string  `json:"priceCurrency,omitempty"`
+	OldValue     float64 `json:"priceOldValue,omitempty"`
+	Discount     float64 `json:"priceDiscount,omitempty"`
+	MaxValue     float64 `json:"priceMaxValue,omitempty"`
+}
+
+// Specification is an attribute of product from table of specifications of detail page, like "Color": "black"
+type Specification struct {
+	ID    string `json:"uid,omitempty"`
+	Name  string `json:"specificationName,omitempty"`
+	Value string `json:"specificationValue,omitempty"`
+}
+
+// Product is a storage.Product with Price of entities and details from detail page of product,
+// Prices hides the same field of storage.Product.
+// Images are absolute links of images of gallery in order of page.
+type Product struct {
+	storage.Product
+	Prices         []Price         `json:"has_price,omitempty"`
+	Description    string          `json:"productDescription,omitempty"`
+	SKU            string          `json:"productSku,omitempty"`
+	GTIN           string          `json:"productGtin,omitempty"`
+	Images         []string        `json:"productImages,omitempty"`
+	Specifications []Specification `json:"has_specification,omitempty"`
+}
+
+// ProductsByNameForPage is a storage.ProductsByNameForPage with Product of entities,
+// Products hides the same field of storage.ProductsByNameForPage.
+type ProductsByNameForPage struct {
+	storage.ProductsByNameForPage
+	Products []Product
+}
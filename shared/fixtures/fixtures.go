@@ -0,0 +1,203 @@
+// Package fixtures record responses of shop to directory and replay them for offline parse.
+// Every response is saved as two files: metadata in <name>.json and body in <name>.html,
+// name is made of URL of request, so fixtures of shop can be read and edited by hand.
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ModeRecord means that every fetched page is saved to directory
+	ModeRecord = "record"
+
+	// ModeReplay means that pages are read from directory instead of shop
+	ModeReplay = "replay"
+
+	// maxNameLength is a max length of name of fixture made of URL without hash
+	maxNameLength = 100
+)
+
+var (
+	// ErrFixtureNotFound means that the page was not recorded to directory
+	ErrFixtureNotFound = errors.New("fixture not found")
+
+	// ErrModeIsUnknown means that mode is not ModeRecord or ModeReplay
+	ErrModeIsUnknown = errors.New("mode of fixtures is unknown")
+)
+
+// Fixture is a saved response of shop without body
+type Fixture struct {
+	URL        string
+	StatusCode int
+	Header     http.Header
+}
+
+// Name return name of fixture files for URL. Letters, digits, dots and dashes of URL are kept,
+// slashes are replaced by "_" and other bytes are escaped as "~XX", so names of different URLs are different,
+// like of "catalog?page=1" and "catalog/page/1". Long name is cut and hash of URL is added to it.
+func Name(URL string) string {
+	path := strings.TrimPrefix(strings.TrimPrefix(URL, "https://"), "http://")
+
+	name := bytes.Buffer{}
+
+	for index := 0; index < len(path); index++ {
+		symbol := path[index]
+
+		switch {
+		case symbol >= 'a' && symbol <= 'z', symbol >= 'A' && symbol <= 'Z', symbol >= '0' && symbol <= '9',
+			symbol == '.', symbol == '-':
+			name.WriteByte(symbol)
+		case symbol == '/':
+			name.WriteByte('_')
+		default:
+			fmt.Fprintf(&name, "~%02X", symbol)
+		}
+	}
+
+	if name.Len() <= maxNameLength {
+		return name.String()
+	}
+
+	hash := sha1.Sum([]byte(URL))
+
+	return name.String()[:maxNameLength] + "_" + hex.EncodeToString(hash[:])[:10]
+}
+
+// Wrap return wrapper of transport for mode or ErrModeIsUnknown.
+// In ModeRecord responses of transport are saved, in ModeReplay transport is replaced by saved responses.
+func Wrap(mode, directory string) (func(http.RoundTripper) http.RoundTripper, error) {
+	switch mode {
+	case ModeRecord:
+		return func(transport http.RoundTripper) http.RoundTripper {
+			return &Recorder{Directory: directory, Transport: transport}
+		}, nil
+	case ModeReplay:
+		return func(http.RoundTripper) http.RoundTripper {
+			return &Replayer{Directory: directory}
+		}, nil
+	}
+
+	return nil, ErrModeIsUnknown
+}
+
+// Recorder is a http.RoundTripper which save every response of Transport to Directory
+type Recorder struct {
+	Directory string
+	Transport http.RoundTripper
+}
+
+// RoundTrip make request by Transport and save response
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := recorder.Transport.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		URL:        request.URL.String(),
+		StatusCode: response.StatusCode,
+		Header:     response.Header}
+
+	err = Save(recorder.Directory, fixture, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Save write fixture and body of it to directory
+func Save(directory string, fixture Fixture, body []byte) error {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return err
+	}
+
+	encodedFixture, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(directory, Name(fixture.URL))
+
+	err = ioutil.WriteFile(name+".json", encodedFixture, 0644)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(name+".html", body, 0644)
+}
+
+// Replayer is a http.RoundTripper which return responses saved to Directory
+type Replayer struct {
+	Directory string
+}
+
+// RoundTrip return saved response for URL of request or ErrFixtureNotFound
+func (replayer *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	return Load(replayer.Directory, request.URL.String(), request)
+}
+
+// Load return response saved to directory with URL of fixture or ErrFixtureNotFound,
+// request is a request of returned response
+func Load(directory, URL string, request *http.Request) (*http.Response, error) {
+	name := filepath.Join(directory, Name(URL))
+
+	encodedFixture, err := ioutil.ReadFile(name + ".json")
+	if os.IsNotExist(err) {
+		return nil, ErrFixtureNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+
+	err = json.Unmarshal(encodedFixture, &fixture)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadFile(name + ".html")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	header := fixture.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	response := &http.Response{
+		Status:        http.StatusText(fixture.StatusCode),
+		StatusCode:    fixture.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request}
+
+	return response, nil
+}
@@ -0,0 +1,86 @@
+package fixtures
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNameOfFixtureCanBeMadeOfURL(t *testing.T) {
+	name := Name("https://www.mvideo.ru/smartfony-i-svyaz/smartfony-205?cityId=CityCZ_975")
+	if name != "www.mvideo.ru_smartfony-i-svyaz_smartfony-205~3FcityId~3DCityCZ~5F975" {
+		t.Errorf("expected '%v' but got '%v'", "www.mvideo.ru_smartfony-i-svyaz_smartfony-205~3FcityId~3DCityCZ~5F975", name)
+	}
+
+	if Name("https://shop.ru/catalog?page=1") == Name("https://shop.ru/catalog/page/1") {
+		t.Errorf("expected different names but got '%v'", Name("https://shop.ru/catalog?page=1"))
+	}
+
+	longURL := "https://shop.ru/catalog?" + strings.Repeat("page=1&", 20)
+
+	name = Name(longURL)
+	if len(name) != maxNameLength+11 || name == Name(longURL+"page=2") {
+		t.Errorf("expected unique name with hash but got '%v'", name)
+	}
+}
+
+// transportFunc is a http.RoundTripper of function
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (roundTrip transportFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTrip(request)
+}
+
+func TestRecorderWrapTransport(t *testing.T) {
+	directory, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	shop := transportFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Transport": []string{"shop"}},
+			Body:       ioutil.NopCloser(strings.NewReader("page")),
+			Request:    request}, nil
+	})
+
+	record, err := Wrap(ModeRecord, directory)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	replay, err := Wrap(ModeReplay, directory)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, transport := range []http.RoundTripper{record(shop), replay(shop)} {
+		request, err := http.NewRequest(http.MethodGet, "https://shop.ru/catalog?page=1", nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		response, err := transport.RoundTrip(request)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		if string(body) != "page" || response.Header.Get("X-Transport") != "shop" {
+			t.Errorf("expected page of shop but got '%v' with headers '%v'", string(body), response.Header)
+		}
+	}
+
+	_, err = Wrap("sequential", directory)
+	if err != ErrModeIsUnknown {
+		t.Errorf("expected '%v' but got '%v'", ErrModeIsUnknown, err)
+	}
+}
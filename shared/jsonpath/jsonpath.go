@@ -0,0 +1,259 @@
+// Package jsonpath select values of decoded JSON document by subset of JSONPath:
+// root "$" or current item "@", child ".name" or "['name']", index "[0]" or "[-1]",
+// wildcard ".*" or "[*]" and recursive descent "..name".
+// Path without root, like "name.first", is a path of child of root.
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrPathCanNotBeParsed means that path is not a valid expression of supported subset of JSONPath
+var ErrPathCanNotBeParsed = errors.New("path can not be parsed")
+
+const (
+	stepChild = iota
+	stepIndex
+	stepWildcard
+	stepDescendant
+)
+
+type step struct {
+	kind  int
+	name  string
+	index int
+}
+
+// Path is a compiled JSONPath expression
+type Path struct {
+	steps []step
+}
+
+// Compile parse expression to Path
+func Compile(expression string) (Path, error) {
+	path := Path{}
+
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "$") || strings.HasPrefix(expression, "@") {
+		expression = expression[1:]
+	} else if expression != "" && expression[0] != '.' && expression[0] != '[' {
+		expression = "." + expression
+	}
+
+	for len(expression) > 0 {
+		switch {
+		case strings.HasPrefix(expression, ".."):
+			name, rest := nameOf(expression[2:])
+			if name == "" || strings.Contains(name, "]") {
+				return path, ErrPathCanNotBeParsed
+			}
+
+			path.steps = append(path.steps, step{kind: stepDescendant, name: name})
+			expression = rest
+
+		case strings.HasPrefix(expression, ".*"):
+			path.steps = append(path.steps, step{kind: stepWildcard})
+			expression = expression[2:]
+
+		case expression[0] == '.':
+			name, rest := nameOf(expression[1:])
+			if name == "" || strings.Contains(name, "]") {
+				return path, ErrPathCanNotBeParsed
+			}
+
+			path.steps = append(path.steps, step{kind: stepChild, name: name})
+			expression = rest
+
+		case expression[0] == '[':
+			end := strings.Index(expression, "]")
+			if end < 0 {
+				return path, ErrPathCanNotBeParsed
+			}
+
+			parsedStep, err := bracketStep(strings.TrimSpace(expression[1:end]))
+			if err != nil {
+				return path, err
+			}
+
+			path.steps = append(path.steps, parsedStep)
+			expression = expression[end+1:]
+
+		default:
+			return path, ErrPathCanNotBeParsed
+		}
+	}
+
+	return path, nil
+}
+
+// nameOf return name of child at start of expression and rest of expression
+func nameOf(expression string) (string, string) {
+	end := strings.IndexAny(expression, ".[")
+	if end < 0 {
+		return expression, ""
+	}
+
+	return expression[:end], expression[end:]
+}
+
+// bracketStep parse content of brackets: *, index or quoted name
+func bracketStep(content string) (step, error) {
+	if content == "*" {
+		return step{kind: stepWildcard}, nil
+	}
+
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return step{kind: stepChild, name: content[1 : len(content)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return step{}, ErrPathCanNotBeParsed
+	}
+
+	return step{kind: stepIndex, index: index}, nil
+}
+
+// Select return all values of document found by path
+func (path Path) Select(document interface{}) []interface{} {
+	values := []interface{}{document}
+
+	for _, currentStep := range path.steps {
+		var selected []interface{}
+
+		for _, value := range values {
+			selected = append(selected, currentStep.apply(value)...)
+		}
+
+		values = selected
+	}
+
+	return values
+}
+
+func (currentStep step) apply(value interface{}) []interface{} {
+	switch currentStep.kind {
+	case stepChild:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		child, ok := object[currentStep.name]
+		if !ok {
+			return nil
+		}
+
+		return []interface{}{child}
+
+	case stepIndex:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		index := currentStep.index
+		if index < 0 {
+			index += len(array)
+		}
+
+		if index < 0 || index >= len(array) {
+			return nil
+		}
+
+		return []interface{}{array[index]}
+
+	case stepWildcard:
+		return children(value)
+
+	case stepDescendant:
+		var found []interface{}
+
+		object, ok := value.(map[string]interface{})
+		if ok {
+			child, ok := object[currentStep.name]
+			if ok {
+				found = append(found, child)
+			}
+		}
+
+		for _, child := range children(value) {
+			found = append(found, currentStep.apply(child)...)
+		}
+
+		return found
+	}
+
+	return nil
+}
+
+// children return items of array or values of object sorted by keys
+func children(value interface{}) []interface{} {
+	switch typedValue := value.(type) {
+	case []interface{}:
+		return typedValue
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		values := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, typedValue[key])
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+// Text return text of first value of document found by path or empty string.
+// Objects and arrays are returned as JSON.
+func (path Path) Text(document interface{}) (string, bool) {
+	values := path.Select(document)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	switch value := values[0].(type) {
+	case nil:
+		return "", true
+	case string:
+		return value, true
+	case json.Number:
+		return value.String(), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+
+	encodedValue, err := json.Marshal(values[0])
+	if err != nil {
+		return "", false
+	}
+
+	return string(encodedValue), true
+}
+
+// Decode JSON document with numbers as json.Number, so they are not rounded
+func Decode(data []byte) (interface{}, error) {
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&document)
+
+	return document, err
+}
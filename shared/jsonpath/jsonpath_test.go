@@ -0,0 +1,60 @@
+package jsonpath
+
+import (
+	"testing"
+)
+
+func TestValuesCanBeSelectedByPath(t *testing.T) {
+	document, err := Decode([]byte(`{
+		"data": {
+			"products": [
+				{"name": "First", "price": {"current": 1299.9}, "tags": ["new", "hit"]},
+				{"name": "Second", "price": {"current": 12345678901234567}, "link": "/2"}
+			]
+		}
+	}`))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "$.data.products[*].name", expected: []string{"First", "Second"}},
+		{path: "$['data']['products'][0].name", expected: []string{"First"}},
+		{path: "data.products[-1].price.current", expected: []string{"12345678901234567"}},
+		{path: "$..current", expected: []string{"1299.9", "12345678901234567"}},
+		{path: "$.data.products[0].tags.*", expected: []string{"new", "hit"}},
+		{path: "$.data.products[0].link", expected: nil},
+		{path: "$.data.products[5]", expected: nil},
+	}
+
+	for _, test := range tests {
+		path, err := Compile(test.path)
+		if err != nil {
+			t.Fatalf("%v: %v", test.path, err)
+		}
+
+		values := path.Select(document)
+		if len(values) != len(test.expected) {
+			t.Fatalf("%v: expected '%v' but got '%v'", test.path, test.expected, values)
+		}
+
+		for index, value := range values {
+			text, _ := Path{}.Text(value)
+			if text != test.expected[index] {
+				t.Errorf("%v: expected '%v' but got '%v'", test.path, test.expected[index], text)
+			}
+		}
+	}
+}
+
+func TestPathCanNotBeCompiled(t *testing.T) {
+	for _, expression := range []string{"$.", "$[", "$[name]", "$..", "$.data]"} {
+		_, err := Compile(expression)
+		if err != ErrPathCanNotBeParsed {
+			t.Errorf("%v: expected '%v' but got '%v'", expression, ErrPathCanNotBeParsed, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package mutation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"text/template"
+)
+
+// Storage is a storage of entities for deletion
+type Storage interface {
+	Query(string) ([]byte, error)
+	CreateJSON([]byte) (string, error)
+	DeleteJSON([]byte) error
+}
+
+// Deletion make soft and hard delete of entity, so delete function declares only predicates of its entity
+type Deletion struct {
+	Store Storage
+
+	// IsActivePredicate is a predicate which is false for deleted entity
+	IsActivePredicate string
+
+	// IncomingEdges are predicates of other entities with edges to entity
+	IncomingEdges []string
+
+	// OutgoingEdges are predicates of entity with edges to other entities
+	OutgoingEdges []string
+}
+
+var DeletionLogger = log.New(os.Stdout, "Deletion: ", log.Lshortfile)
+
+var (
+	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
+	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+
+	// ErrEntityByIDCanNotBeDeleted means that the entity can't be deleted from database
+	ErrEntityByIDCanNotBeDeleted = errors.New("entity by id can not be deleted")
+)
+
+// DeleteEntityByID is a method for soft delete of entity by ID, entity is kept in database as not active
+func (deletion *Deletion) DeleteEntityByID(entityID string) error {
+
+	if entityID == "" {
+		DeletionLogger.Println(ErrEntityCanNotBeWithoutID)
+		return ErrEntityCanNotBeWithoutID
+	}
+
+	deactivateEntityData, err := json.Marshal(map[string]interface{}{"uid": entityID, deletion.IsActivePredicate: false})
+	if err != nil {
+		return err
+	}
+
+	// Set of predicate with uid of entity change existing entity
+	_, err = deletion.Store.CreateJSON(deactivateEntityData)
+	if err != nil {
+		DeletionLogger.Println(err)
+		return ErrEntityByIDCanNotBeDeleted
+	}
+
+	return nil
+}
+
+// HardDeleteEntityByID is a method for delete entity by ID with all edges of other entities to it
+// and all edges of it to other entities
+func (deletion *Deletion) HardDeleteEntityByID(entityID string) error {
+
+	if entityID == "" {
+		DeletionLogger.Println(ErrEntityCanNotBeWithoutID)
+		return ErrEntityCanNotBeWithoutID
+	}
+
+	deletions, err := deletion.incomingEdgesOf(entityID)
+	if err != nil {
+		DeletionLogger.Println(err)
+		return ErrEntityByIDCanNotBeDeleted
+	}
+
+	entity := map[string]interface{}{"uid": entityID}
+	for _, predicate := range deletion.OutgoingEdges {
+		// Deletion of predicate with null value delete all edges of predicate
+		entity[predicate] = nil
+	}
+
+	deletions = append(deletions, entity)
+
+	deleteEntityData, err := json.Marshal(deletions)
+	if err != nil {
+		return err
+	}
+
+	err = deletion.Store.DeleteJSON(deleteEntityData)
+	if err != nil {
+		DeletionLogger.Println(err)
+		return ErrEntityByIDCanNotBeDeleted
+	}
+
+	return nil
+}
+
+// incomingEdgesOf return deletions of edges by IncomingEdges predicates from other entities to entity
+func (deletion *Deletion) incomingEdgesOf(entityID string) ([]map[string]interface{}, error) {
+	var deletions []map[string]interface{}
+
+	if len(deletion.IncomingEdges) == 0 {
+		return deletions, nil
+	}
+
+	variables := struct {
+		EntityID   string
+		Predicates []string
+	}{
+		EntityID:   entityID,
+		Predicates: deletion.IncomingEdges}
+
+	queryTemplate, err := template.New("ReadIncomingEdges").Parse(`{
+				{{range .Predicates}}
+				{{.}}(func: has({{.}})) @filter(uid_in({{.}}, {{$.EntityID}})) {
+					uid
+				}
+				{{end}}
+			}`)
+	if err != nil {
+		return nil, err
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := deletion.Store.Query(queryBuf.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var entitiesByPredicates map[string][]struct {
+		ID string `json:"uid"`
+	}
+
+	err = json.Unmarshal(response, &entitiesByPredicates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, predicate := range deletion.IncomingEdges {
+		for _, entity := range entitiesByPredicates[predicate] {
+			deletions = append(deletions, map[string]interface{}{
+				"uid": entity.ID, predicate: []map[string]string{{"uid": entityID}}})
+		}
+	}
+
+	return deletions, nil
+}
@@ -0,0 +1,124 @@
+package mutation
+
+import (
+	"errors"
+	"testing"
+)
+
+type MockStore struct {
+	Edges            string
+	QueryError       error
+	Created, Deleted string
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Edges), store.QueryError
+}
+
+func (store *MockStore) CreateJSON(setJSON []byte) (string, error) {
+	store.Created = string(setJSON)
+	return "", nil
+}
+
+func (store *MockStore) DeleteJSON(deleteJSON []byte) error {
+	store.Deleted = string(deleteJSON)
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeDeletedByID(t *testing.T) {
+	store := &MockStore{}
+	deletion := Deletion{Store: store, IsActivePredicate: "entityIsActive"}
+
+	err := deletion.DeleteEntityByID("")
+	if err != ErrEntityCanNotBeWithoutID {
+		t.Errorf("Expected error: %v, actual: %v", ErrEntityCanNotBeWithoutID, err)
+	}
+
+	err = deletion.DeleteEntityByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Created != `{"entityIsActive":false,"uid":"0x12"}` {
+		t.Errorf("Expected entity is deactivated, actual: %v", store.Created)
+	}
+
+	if store.Deleted != "" {
+		t.Errorf("Expected entity is not deleted, actual: %v", store.Deleted)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeHardDeletedByID(t *testing.T) {
+	store := &MockStore{Edges: `{"has_entity": [{"uid": "0x13"}], "belongs_to_entity": [{"uid": "0x14"}]}`}
+	deletion := Deletion{
+		Store:             store,
+		IsActivePredicate: "entityIsActive",
+		IncomingEdges:     []string{"has_entity", "belongs_to_entity"}}
+
+	err := deletion.HardDeleteEntityByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `[{"has_entity":[{"uid":"0x12"}],"uid":"0x13"},{"belongs_to_entity":[{"uid":"0x12"}],"uid":"0x14"},{"uid":"0x12"}]`
+
+	if store.Deleted != expected {
+		t.Errorf("Expected deletion of entity with edges to it: %v, actual: %v", expected, store.Deleted)
+	}
+
+	if store.Created != "" {
+		t.Errorf("Expected entity is not deactivated, actual: %v", store.Created)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityWithoutIncomingEdgesCanBeHardDeletedWithoutQuery(t *testing.T) {
+	store := &MockStore{QueryError: errors.New("query must not be made")}
+	deletion := Deletion{Store: store, IsActivePredicate: "entityIsActive"}
+
+	err := deletion.HardDeleteEntityByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Deleted != `[{"uid":"0x12"}]` {
+		t.Errorf("Expected deletion of entity, actual: %v", store.Deleted)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityIsNotHardDeletedIfEdgesCanNotBeRead(t *testing.T) {
+	store := &MockStore{QueryError: errors.New("database is not available")}
+	deletion := Deletion{Store: store, IncomingEdges: []string{"has_entity"}}
+
+	err := deletion.HardDeleteEntityByID("0x12")
+	if err != ErrEntityByIDCanNotBeDeleted {
+		t.Errorf("Expected error: %v, actual: %v", ErrEntityByIDCanNotBeDeleted, err)
+	}
+
+	if store.Deleted != "" {
+		t.Errorf("Expected entity is not deleted, actual: %v", store.Deleted)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeHardDeletedWithEdgesToOtherEntities(t *testing.T) {
+	store := &MockStore{}
+	deletion := Deletion{
+		Store:             store,
+		IsActivePredicate: "entityIsActive",
+		OutgoingEdges:     []string{"has_company", "has_category"}}
+
+	err := deletion.HardDeleteEntityByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `[{"has_category":null,"has_company":null,"uid":"0x12"}]`
+
+	if store.Deleted != expected {
+		t.Errorf("Expected deletion of entity with edges of it: %v, actual: %v", expected, store.Deleted)
+	}
+}
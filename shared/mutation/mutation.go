@@ -0,0 +1,41 @@
+// Package mutation change entities in Dgraph by mutations which storage.Store can't make,
+// like deletion and set of predicates of entity in one transaction.
+package mutation
+
+import (
+	"context"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+)
+
+// Store is a storage.Store with mutations in one transaction
+type Store struct {
+	storage.Store
+}
+
+// MutateJSON delete predicates of deleteJSON and set predicates of setJSON in one transaction,
+// so entity is changed completely or is not changed at all. Empty JSON is skipped.
+func (store *Store) MutateJSON(setJSON, deleteJSON []byte) error {
+	connection, err := grpc.Dial(store.DatabaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+
+	defer connection.Close()
+
+	databaseClient := dataBaseClient.NewDgraphClient(dataBaseAPI.NewDgraphClient(connection))
+
+	transaction := databaseClient.NewTxn()
+	defer transaction.Discard(context.Background())
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:    setJSON,
+		DeleteJson: deleteJSON,
+		CommitNow:  true}
+
+	_, err = transaction.Mutate(context.Background(), mutation)
+
+	return err
+}
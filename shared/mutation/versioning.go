@@ -0,0 +1,146 @@
+package mutation
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Version is a replaced state of entity, it was active
+// from ActiveFrom (empty for first version) until ActiveUntil.
+// Snapshot is a JSON of predicates of entity in version.
+type Version struct {
+	ID          string     `json:"uid,omitempty"`
+	Number      int        `json:"versionNumber"`
+	ActiveFrom  *time.Time `json:"versionActiveFrom,omitempty"`
+	ActiveUntil *time.Time `json:"versionActiveUntil,omitempty"`
+	Snapshot    string     `json:"versionSnapshot"`
+}
+
+// Mutator is a storage of entities which are changed in one transaction
+type Mutator interface {
+	MutateJSON([]byte, []byte) error
+}
+
+// Versioning replace state of entity and keep replaced state as version of entity,
+// so update function declares only predicates of version and time of update of its entity
+type Versioning struct {
+	Store                                Mutator
+	VersionPredicate, UpdatedAtPredicate string
+}
+
+// Replacement is a change of current state of entity to next state.
+// Version and UpdatedAt are number and time of update of current state,
+// Snapshot is a JSON of current state for version, JSON of Current fields is used if it is empty.
+type Replacement struct {
+	EntityID      string
+	Version       int
+	UpdatedAt     *time.Time
+	Snapshot      string
+	Current, Next map[string]interface{}
+}
+
+// FieldsOf return not empty predicates of entity by JSON of it, without uid, version and time of update
+func (versioning *Versioning) FieldsOf(entity interface{}) (map[string]interface{}, error) {
+	encodedEntity, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+
+	err = json.Unmarshal(encodedEntity, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, predicate := range []string{"uid", "has_version", versioning.VersionPredicate, versioning.UpdatedAtPredicate} {
+		delete(fields, predicate)
+	}
+
+	return fields, nil
+}
+
+// IsSameFields return true if predicates have same values
+func IsSameFields(fields, otherFields map[string]interface{}) bool {
+	encodedFields, err := json.Marshal(fields)
+	if err != nil {
+		return false
+	}
+
+	encodedOtherFields, err := json.Marshal(otherFields)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(encodedFields, encodedOtherFields)
+}
+
+// Replace save current state as version and set next state to entity in one transaction.
+// Predicates which are set in current and empty in next are deleted.
+func (versioning *Versioning) Replace(replacement Replacement) error {
+	now := time.Now().UTC()
+
+	snapshot := replacement.Snapshot
+	if snapshot == "" {
+		encodedSnapshot, err := json.Marshal(replacement.Current)
+		if err != nil {
+			return err
+		}
+
+		snapshot = string(encodedSnapshot)
+	}
+
+	version := Version{
+		Number:      replacement.Version,
+		ActiveFrom:  replacement.UpdatedAt,
+		ActiveUntil: &now,
+		Snapshot:    snapshot}
+
+	if version.Number == 0 {
+		version.Number = 1
+	}
+
+	deletion := map[string]interface{}{"uid": replacement.EntityID}
+	for predicate := range replacement.Current {
+		if _, ok := replacement.Next[predicate]; !ok {
+			deletion[predicate] = nil
+		}
+	}
+
+	// Values of list predicate, like paginationStrategies or has_page, are added to list by set,
+	// so changed list is deleted before set of it, even if current state has no values of it
+	for predicate, value := range replacement.Next {
+		if _, isList := value.([]interface{}); isList && !reflect.DeepEqual(replacement.Current[predicate], value) {
+			deletion[predicate] = nil
+		}
+	}
+
+	var encodedDeletion []byte
+	if len(deletion) > 1 {
+		var err error
+		encodedDeletion, err = json.Marshal(deletion)
+		if err != nil {
+			return err
+		}
+	}
+
+	entity := map[string]interface{}{}
+	for predicate, value := range replacement.Next {
+		entity[predicate] = value
+	}
+
+	entity["uid"] = replacement.EntityID
+	entity[versioning.VersionPredicate] = version.Number + 1
+	entity[versioning.UpdatedAtPredicate] = now
+	entity["has_version"] = []Version{version}
+
+	encodedEntity, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	// Version, predicates of next state and deletion of replaced predicates are saved in one transaction
+	return versioning.Store.MutateJSON(encodedEntity, encodedDeletion)
+}
@@ -0,0 +1,119 @@
+package mutation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MutatorMock struct {
+	Set, Deleted map[string]interface{}
+}
+
+func (store *MutatorMock) MutateJSON(setJSON, deleteJSON []byte) error {
+	store.Set, store.Deleted = map[string]interface{}{}, map[string]interface{}{}
+
+	if deleteJSON != nil {
+		err := json.Unmarshal(deleteJSON, &store.Deleted)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(setJSON, &store.Set)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestFieldsOfEntityAreWithoutIDAndVersion(t *testing.T) {
+	versioning := Versioning{VersionPredicate: "entityVersion", UpdatedAtPredicate: "entityUpdatedAt"}
+
+	fields, err := versioning.FieldsOf(map[string]interface{}{
+		"uid":             "0x12",
+		"entityVersion":   2,
+		"entityUpdatedAt": time.Now(),
+		"has_version":     []Version{{Number: 1}},
+		"path":            "catalog/"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(fields) != 1 || fields["path"] != "catalog/" {
+		t.Errorf("Expected only predicates of entity, actual: %v", fields)
+	}
+
+	if !IsSameFields(fields, map[string]interface{}{"path": "catalog/"}) ||
+		IsSameFields(fields, map[string]interface{}{"path": "products/"}) {
+		t.Errorf("Expected fields are compared by values")
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeReplacedWithVersion(t *testing.T) {
+	store := &MutatorMock{}
+	versioning := Versioning{Store: store, VersionPredicate: "entityVersion", UpdatedAtPredicate: "entityUpdatedAt"}
+
+	err := versioning.Replace(Replacement{
+		EntityID: "0x12",
+		Version:  2,
+		Current: map[string]interface{}{
+			"path":                 "catalog/",
+			"nextPageSelector":     "a.next",
+			"paginationStrategies": []interface{}{"next_link"}},
+		Next: map[string]interface{}{
+			"path":                 "catalog/",
+			"paginationStrategies": []interface{}{"next_link"}}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(store.Deleted) != 2 || store.Deleted["uid"] != "0x12" {
+		t.Fatalf("Expected deletion of empty predicate only, actual: %v", store.Deleted)
+	}
+
+	if _, ok := store.Deleted["nextPageSelector"]; !ok {
+		t.Errorf("Expected deletion of empty predicate, actual: %v", store.Deleted)
+	}
+
+	if store.Set["uid"] != "0x12" || store.Set["entityVersion"] != float64(3) || store.Set["entityUpdatedAt"] == nil {
+		t.Errorf("Expected next version of entity, actual: %v", store.Set)
+	}
+
+	versions, ok := store.Set["has_version"].([]interface{})
+	if !ok || len(versions) != 1 {
+		t.Fatalf("Expected version of current state, actual: %v", store.Set["has_version"])
+	}
+
+	version := versions[0].(map[string]interface{})
+	if version["versionNumber"] != float64(2) || !strings.Contains(version["versionSnapshot"].(string), `"nextPageSelector":"a.next"`) {
+		t.Errorf("Expected current state in version, actual: %v", version)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestListOfEntityIsDeletedBeforeSetEvenIfCurrentStateHasNoList(t *testing.T) {
+	store := &MutatorMock{}
+	versioning := Versioning{Store: store, VersionPredicate: "entityVersion", UpdatedAtPredicate: "entityUpdatedAt"}
+
+	err := versioning.Replace(Replacement{
+		EntityID: "0x12",
+		Snapshot: `{"path":"catalog/"}`,
+		Current:  map[string]interface{}{"path": "catalog/"},
+		Next: map[string]interface{}{
+			"path":                 "catalog/",
+			"paginationStrategies": []interface{}{"total_items"}}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if value, ok := store.Deleted["paginationStrategies"]; !ok || value != nil {
+		t.Errorf("Expected deletion of list before set, actual: %v", store.Deleted)
+	}
+
+	versions := store.Set["has_version"].([]interface{})
+	version := versions[0].(map[string]interface{})
+
+	if version["versionNumber"] != float64(1) || version["versionSnapshot"] != `{"path":"catalog/"}` {
+		t.Errorf("Expected first version with snapshot, actual: %v", version)
+	}
+}
@@ -0,0 +1,71 @@
+package prices
+
+import "strings"
+
+const (
+	// InStock means that product can be bought
+	InStock = "in_stock"
+
+	// OutOfStock means that product can not be bought
+	OutOfStock = "out_of_stock"
+
+	// PreOrder means that product can be ordered before it comes to shop
+	PreOrder = "pre_order"
+)
+
+// DefaultAvailability is a mapping of text of availability element of page to status of availability.
+// It is used by parsers if mapping of request is empty.
+var DefaultAvailability = map[string]string{
+	"в наличии":     InStock,
+	"в корзину":     InStock,
+	"купить":        InStock,
+	"in stock":      InStock,
+	"add to cart":   InStock,
+	"нет в наличии": OutOfStock,
+	"закончился":    OutOfStock,
+	"распродан":     OutOfStock,
+	"out of stock":  OutOfStock,
+	"sold out":      OutOfStock,
+	"предзаказ":     PreOrder,
+	"pre-order":     PreOrder,
+	"preorder":      PreOrder,
+}
+
+// StructuredAvailability is a mapping of availability of offer of schema.org to status of availability
+var StructuredAvailability = map[string]string{
+	"InStock":             InStock,
+	"InStoreOnly":         InStock,
+	"OnlineOnly":          InStock,
+	"LimitedAvailability": InStock,
+	"OutOfStock":          OutOfStock,
+	"SoldOut":             OutOfStock,
+	"Discontinued":        OutOfStock,
+	"PreOrder":            PreOrder,
+	"PreSale":             PreOrder,
+}
+
+// AvailabilityOf return status of availability for text of element.
+// Longest matched text of mapping wins, so "нет в наличии" is not taken for "в наличии".
+// Empty status is returned if text is not matched.
+func AvailabilityOf(text string, mapping map[string]string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	var status, matchedText string
+
+	for textOfStatus, statusOfText := range mapping {
+		textOfStatus = strings.ToLower(textOfStatus)
+
+		if !strings.Contains(text, textOfStatus) || len(textOfStatus) < len(matchedText) {
+			continue
+		}
+
+		if len(textOfStatus) == len(matchedText) && statusOfText > status {
+			continue
+		}
+
+		matchedText = textOfStatus
+		status = statusOfText
+	}
+
+	return status
+}
@@ -0,0 +1,23 @@
+package prices
+
+import (
+	"testing"
+)
+
+func TestAvailabilityCanBeMapped(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: " В наличии ", expected: InStock},
+		{text: "Нет в наличии", expected: OutOfStock},
+		{text: "Оформить предзаказ", expected: PreOrder},
+		{text: "Сообщить о поступлении", expected: ""}}
+
+	for _, test := range tests {
+		status := AvailabilityOf(test.text, DefaultAvailability)
+		if status != test.expected {
+			t.Errorf("expected '%v' for '%v' but got '%v'", test.expected, test.text, status)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package prices normalize text of price from page of shop to value and currency
+// and text of availability of product to status of it.
+package prices
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Price is a normalized price.
+// MaxValue is not empty only for ranges of price, like "999 - 1 299".
+type Price struct {
+	Value    float64
+	MaxValue float64
+	Currency string
+}
+
+var (
+	// ErrPriceCanNotBeEmpty means that text of price has no one number
+	ErrPriceCanNotBeEmpty = errors.New("price can not be empty")
+
+	// ErrPriceCanNotBeParsed means that number of price is not a valid number
+	ErrPriceCanNotBeParsed = errors.New("price can not be parsed")
+)
+
+// Currencies is a codes of ISO 4217 for signs and names of currencies
+var Currencies = map[string]string{
+	"₽":    "RUB",
+	"руб":  "RUB",
+	"р.":   "RUB",
+	"rub":  "RUB",
+	"$":    "USD",
+	"usd":  "USD",
+	"€":    "EUR",
+	"eur":  "EUR",
+	"£":    "GBP",
+	"gbp":  "GBP",
+	"₴":    "UAH",
+	"грн":  "UAH",
+	"uah":  "UAH",
+	"₸":    "KZT",
+	"тг":   "KZT",
+	"kzt":  "KZT",
+	"byn":  "BYN",
+	"бел.": "BYN",
+}
+
+// spaces replace all kinds of spaces, which can separate groups of digits, by usual space
+var spaces = strings.NewReplacer(
+	"\u00a0", " ",
+	"\u2007", " ",
+	"\u2009", " ",
+	"\u202f", " ",
+	"\t", " ",
+	"\n", " ")
+
+var numberPattern = regexp.MustCompile(`\d+(?:[ '.,]\d+)*`)
+
+// Parse find value, currency and range of price in text
+func Parse(text string) (Price, error) {
+	price := Price{}
+
+	text = spaces.Replace(text)
+
+	numbers := numberPattern.FindAllString(text, -1)
+	if len(numbers) == 0 {
+		return price, ErrPriceCanNotBeEmpty
+	}
+
+	value, err := ParseNumber(numbers[0])
+	if err != nil {
+		return price, err
+	}
+
+	price.Value = value
+
+	if len(numbers) > 1 {
+		maxValue, err := ParseNumber(numbers[len(numbers)-1])
+		if err != nil {
+			return price, err
+		}
+
+		if maxValue > value {
+			price.MaxValue = maxValue
+		}
+	}
+
+	price.Currency = Currency(text)
+
+	return price, nil
+}
+
+// ParseNumber parse number with separators of groups of digits and decimal point or comma
+func ParseNumber(number string) (float64, error) {
+	number = strings.NewReplacer(" ", "", "'", "").Replace(number)
+
+	lastComma := strings.LastIndex(number, ",")
+	lastDot := strings.LastIndex(number, ".")
+
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		decimalSeparator, groupSeparator := ".", ","
+		if lastComma > lastDot {
+			decimalSeparator, groupSeparator = ",", "."
+		}
+
+		number = strings.Replace(number, groupSeparator, "", -1)
+		number = strings.Replace(number, decimalSeparator, ".", 1)
+
+	case lastComma >= 0:
+		number = normalizeSeparator(number, ",")
+
+	case lastDot >= 0:
+		number = normalizeSeparator(number, ".")
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, ErrPriceCanNotBeParsed
+	}
+
+	return value, nil
+}
+
+// normalizeSeparator replace only one separator, which is not followed by three digits, by decimal point.
+// Other separators are separators of groups of digits.
+func normalizeSeparator(number, separator string) string {
+	parts := strings.Split(number, separator)
+
+	if len(parts) == 2 && len(parts[1]) != 3 {
+		return parts[0] + "." + parts[1]
+	}
+
+	return strings.Join(parts, "")
+}
+
+// Currency return code of ISO 4217 of currency found in text or empty string
+func Currency(text string) string {
+	text = strings.ToLower(text)
+
+	var code string
+	position := -1
+
+	for sign, currencyCode := range Currencies {
+		index := strings.Index(text, sign)
+		if index < 0 {
+			continue
+		}
+
+		if position < 0 || index < position || (index == position && currencyCode < code) {
+			position = index
+			code = currencyCode
+		}
+	}
+
+	return code
+}
+
+// Discount return percent of discount of old value
+func Discount(value, oldValue float64) float64 {
+	if oldValue <= 0 || value >= oldValue {
+		return 0
+	}
+
+	return math.Round((oldValue-value)/oldValue*10000) / 100
+}
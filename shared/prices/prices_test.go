@@ -0,0 +1,56 @@
+package prices
+
+import (
+	"testing"
+)
+
+func TestPriceCanBeParsed(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Price
+	}{
+		{text: "18 195¤", expected: Price{Value: 18195}},
+		{text: "1 299,90 ₽", expected: Price{Value: 1299.90, Currency: "RUB"}},
+		{text: "1 299,90 руб.", expected: Price{Value: 1299.90, Currency: "RUB"}},
+		{text: "1 299 990 р.", expected: Price{Value: 1299990, Currency: "RUB"}},
+		{text: "от 999", expected: Price{Value: 999}},
+		{text: "999 – 1 299 ₴", expected: Price{Value: 999, MaxValue: 1299, Currency: "UAH"}},
+		{text: "$1,099.50", expected: Price{Value: 1099.50, Currency: "USD"}},
+		{text: "1.099,50 €", expected: Price{Value: 1099.50, Currency: "EUR"}},
+		{text: "€ 42.5", expected: Price{Value: 42.5, Currency: "EUR"}},
+		{text: "1'299 CHF", expected: Price{Value: 1299}},
+		{text: "12,345,678", expected: Price{Value: 12345678}}}
+
+	for _, test := range tests {
+		price, err := Parse(test.text)
+		if err != nil {
+			t.Errorf("Price: %v can not be parsed. Error: %v", test.text, err)
+			continue
+		}
+
+		if price != test.expected {
+			t.Errorf("Expected: %v for: %v, but got: %v", test.expected, test.text, price)
+		}
+	}
+}
+
+func TestPriceCanNotBeParsedWithoutNumbers(t *testing.T) {
+	_, err := Parse("Нет в наличии")
+	if err != ErrPriceCanNotBeEmpty {
+		t.Fatalf("Expected: %v, but got: %v", ErrPriceCanNotBeEmpty, err)
+	}
+}
+
+func TestDiscountCanBeCalculated(t *testing.T) {
+	if discount := Discount(750, 1000); discount != 25 {
+		t.Errorf("Expected: %v, but got: %v", 25, discount)
+	}
+
+	if discount := Discount(1299.9, 1999.9); discount != 35 {
+		t.Errorf("Expected: %v, but got: %v", 35, discount)
+	}
+
+	if discount := Discount(1000, 0); discount != 0 {
+		t.Errorf("Expected: %v, but got: %v", 0, discount)
+	}
+}
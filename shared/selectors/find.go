@@ -0,0 +1,94 @@
+package selectors
+
+import (
+	"errors"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// ErrSelectorCanNotBeCompiled means that XPath of selector is not a valid expression
+var ErrSelectorCanNotBeCompiled = errors.New("selector can not be compiled")
+
+// IsXPath return true for selector with XPathPrefix
+func IsXPath(selector string) bool {
+	return strings.HasPrefix(selector, XPathPrefix)
+}
+
+// XPathOf return XPath expression of selector without XPathPrefix
+func XPathOf(selector string) string {
+	return strings.TrimSpace(strings.TrimPrefix(selector, XPathPrefix))
+}
+
+// CompileXPaths check all XPath selectors before parse of page, htmlquery panics on invalid expression.
+// CSS selectors and empty selectors are skipped.
+func CompileXPaths(selectors ...string) error {
+	for _, selector := range selectors {
+		if !IsXPath(selector) {
+			continue
+		}
+
+		_, err := xpath.Compile(XPathOf(selector))
+		if err != nil {
+			return ErrSelectorCanNotBeCompiled
+		}
+	}
+
+	return nil
+}
+
+// Find return elements matched by CSS or XPath selector inside of node
+func Find(node *html.Node, selector string) []*html.Node {
+	if selector == "" {
+		return nil
+	}
+
+	if IsXPath(selector) {
+		return htmlquery.Find(node, XPathOf(selector))
+	}
+
+	return goquery.NewDocumentFromNode(node).Find(selector).Nodes
+}
+
+// ChildText return text of elements matched by selector inside of node,
+// the same way as ChildText of colly.HTMLElement and colly.XMLElement
+func ChildText(node *html.Node, selector string) string {
+	if selector == "" {
+		return ""
+	}
+
+	if IsXPath(selector) {
+		child := htmlquery.FindOne(node, XPathOf(selector))
+		if child == nil {
+			return ""
+		}
+
+		return strings.TrimSpace(htmlquery.InnerText(child))
+	}
+
+	return strings.TrimSpace(goquery.NewDocumentFromNode(node).Find(selector).Text())
+}
+
+// ChildAttr return attribute of first element matched by selector inside of node,
+// the same way as ChildAttr of colly.HTMLElement
+func ChildAttr(node *html.Node, selector, attribute string) string {
+	children := Find(node, selector)
+	if len(children) == 0 {
+		return ""
+	}
+
+	return AttributeOf(children[0], attribute)
+}
+
+// AttributeOf return value of attribute of element without spaces around
+func AttributeOf(node *html.Node, attribute string) string {
+	for _, nodeAttribute := range node.Attr {
+		if nodeAttribute.Key == attribute {
+			return strings.TrimSpace(nodeAttribute.Val)
+		}
+	}
+
+	return ""
+}
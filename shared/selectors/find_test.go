@@ -0,0 +1,55 @@
+package selectors
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+	"testing"
+)
+
+func TestElementsCanBeFoundByCSSAndXPathSelectors(t *testing.T) {
+	document, err := html.Parse(strings.NewReader(`
+		<div class="product">
+			<a class="name" href=" /phone "> Phone </a>
+			<span class="price">999</span>
+			<span class="price">1299</span>
+		</div>`))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if prices := Find(document, "span.price"); len(prices) != 2 {
+		t.Errorf("expected '%v' elements but got '%v'", 2, len(prices))
+	}
+
+	if prices := Find(document, "xpath://span[@class='price']"); len(prices) != 2 {
+		t.Errorf("expected '%v' elements but got '%v'", 2, len(prices))
+	}
+
+	if name := ChildText(document, "xpath://a[@class='name']"); name != "Phone" {
+		t.Errorf("expected '%v' but got '%v'", "Phone", name)
+	}
+
+	if price := ChildText(document, "span.price"); price != "9991299" {
+		t.Errorf("expected '%v' but got '%v'", "9991299", price)
+	}
+
+	if link := ChildAttr(document, "a.name", "href"); link != "/phone" {
+		t.Errorf("expected '%v' but got '%v'", "/phone", link)
+	}
+
+	if text := ChildText(document, ""); text != "" {
+		t.Errorf("expected empty text for empty selector but got '%v'", text)
+	}
+}
+
+func TestXPathSelectorsCanBeCompiled(t *testing.T) {
+	err := CompileXPaths(".product", "", "xpath:.//span[@class='price']")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	err = CompileXPaths(".product", "xpath://span[@class='price'")
+	if err != ErrSelectorCanNotBeCompiled {
+		t.Errorf("expected '%v' but got '%v'", ErrSelectorCanNotBeCompiled, err)
+	}
+}
@@ -0,0 +1,131 @@
+// Package selectors find elements of pages by CSS selectors or XPath expressions for parsers
+// and validate selectors of page instructions like parsers compile them,
+// so broken selectors are not saved to storage.
+package selectors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xpath"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/jsonpath"
+	"strings"
+)
+
+// XPathPrefix is a prefix of selector which is an XPath expression, selectors without it are CSS selectors
+const XPathPrefix = "xpath:"
+
+// PaginationStrategies are strategies of mvideo-pages-count-parser function
+var PaginationStrategies = []string{"max_number", "last_page_link", "next_link", "total_items"}
+
+var (
+	// ErrSelectorIsNotValid means that selector can not be compiled by format of page instruction
+	ErrSelectorIsNotValid = errors.New("selector is not valid")
+
+	// ErrFormatOfPageIsUnknown means that format of page instruction is not FormatHTML or FormatJSON
+	ErrFormatOfPageIsUnknown = errors.New("format of page is unknown")
+
+	// ErrPaginationStrategyIsUnknown means that strategy of page instruction is not one of PaginationStrategies
+	ErrPaginationStrategyIsUnknown = errors.New("pagination strategy is unknown")
+
+	// ErrPageInstructionCanNotBeWithoutItemSelector means that products can't be found on page without ItemSelector
+	ErrPageInstructionCanNotBeWithoutItemSelector = errors.New("page instruction can not be without item selector")
+)
+
+// Validate compile every selector of page instruction like parsers do.
+// Error contains name of first invalid selector.
+func Validate(pageInstruction entities.PageInstruction) error {
+	format := pageInstruction.Format
+	if format != "" && format != entities.FormatHTML && format != entities.FormatJSON {
+		return ErrFormatOfPageIsUnknown
+	}
+
+	if format == entities.FormatJSON && pageInstruction.ItemSelector == "" {
+		return ErrPageInstructionCanNotBeWithoutItemSelector
+	}
+
+	for _, strategy := range pageInstruction.PaginationStrategies {
+		if !isPaginationStrategy(strategy) {
+			return fmt.Errorf("%v: %v", ErrPaginationStrategyIsUnknown, strategy)
+		}
+	}
+
+	// Pagination is always parsed from HTML page, items are parsed by format of page
+	selectors := []struct{ name, selector, format string }{
+		{"pageInPaginationSelector", pageInstruction.PageInPaginationSelector, entities.FormatHTML},
+		{"lastPageSelector", pageInstruction.LastPageSelector, entities.FormatHTML},
+		{"nextPageSelector", pageInstruction.NextPageSelector, entities.FormatHTML},
+		{"totalItemsSelector", pageInstruction.TotalItemsSelector, entities.FormatHTML},
+		{"itemSelector", pageInstruction.ItemSelector, format},
+		{"nameOfItemSelector", pageInstruction.NameOfItemSelector, format},
+		{"linkOfItemSelector", pageInstruction.LinkOfItemSelector, format},
+		{"previewImageOfSelector", pageInstruction.PreviewImageOfSelector, format},
+		{"priceOfItemSelector", pageInstruction.PriceOfItemSelector, format},
+		{"oldPriceOfItemSelector", pageInstruction.OldPriceOfItemSelector, format},
+		{"availabilityOfItemSelector", pageInstruction.AvailabilityOfItemSelector, format}}
+
+	for _, selector := range selectors {
+		if !IsValid(selector.selector, selector.format) {
+			return fmt.Errorf("%v: %v", ErrSelectorIsNotValid, selector.name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDetailPageInstruction compile every selector of detail page instruction like product-page-parser do,
+// detail page is always parsed as HTML page. Error contains name of first invalid selector.
+func ValidateDetailPageInstruction(detailPageInstruction entities.DetailPageInstruction) error {
+	selectors := []struct{ name, selector string }{
+		{"nameSelector", detailPageInstruction.NameSelector},
+		{"descriptionSelector", detailPageInstruction.DescriptionSelector},
+		{"skuSelector", detailPageInstruction.SKUSelector},
+		{"gtinSelector", detailPageInstruction.GTINSelector},
+		{"priceSelector", detailPageInstruction.PriceSelector},
+		{"oldPriceSelector", detailPageInstruction.OldPriceSelector},
+		{"availabilitySelector", detailPageInstruction.AvailabilitySelector},
+		{"imageSelector", detailPageInstruction.ImageSelector},
+		{"specificationSelector", detailPageInstruction.SpecificationSelector},
+		{"specificationNameSelector", detailPageInstruction.SpecificationNameSelector},
+		{"specificationValueSelector", detailPageInstruction.SpecificationValueSelector}}
+
+	for _, selector := range selectors {
+		if !IsValid(selector.selector, entities.FormatHTML) {
+			return fmt.Errorf("%v: %v", ErrSelectorIsNotValid, selector.name)
+		}
+	}
+
+	return nil
+}
+
+// IsValid return true for empty selector or for selector which can be compiled for format of page
+func IsValid(selector, format string) bool {
+	if selector == "" {
+		return true
+	}
+
+	if format == entities.FormatJSON {
+		_, err := jsonpath.Compile(selector)
+		return err == nil
+	}
+
+	if strings.HasPrefix(selector, XPathPrefix) {
+		_, err := xpath.Compile(strings.TrimSpace(strings.TrimPrefix(selector, XPathPrefix)))
+		return err == nil
+	}
+
+	_, err := cascadia.Compile(selector)
+
+	return err == nil
+}
+
+func isPaginationStrategy(strategy string) bool {
+	for _, paginationStrategy := range PaginationStrategies {
+		if strategy == paginationStrategy {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package selectors
+
+import (
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"testing"
+)
+
+func TestSelectorsOfPageInstructionCanBeValidated(t *testing.T) {
+	tests := []struct {
+		pageInstruction entities.PageInstruction
+		err             string
+	}{
+		{
+			pageInstruction: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{
+					ItemSelector:        ".product",
+					PriceOfItemSelector: "xpath:.//span[@class='price']"},
+				PaginationStrategies: []string{"next_link"},
+				NextPageSelector:     "a.next"},
+			err: ""},
+		{
+			pageInstruction: entities.PageInstruction{
+				PageInstruction:  storage.PageInstruction{ItemSelector: "$.products[*]", NameOfItemSelector: "name"},
+				Format:           entities.FormatJSON,
+				NextPageSelector: ".pagination a.next"},
+			err: ""},
+		{
+			pageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{NameOfItemSelector: "div[class="}},
+			err:             "selector is not valid: nameOfItemSelector"},
+		{
+			pageInstruction: entities.PageInstruction{PageInstruction: storage.PageInstruction{PriceOfItemSelector: "xpath://span[@class='price'"}},
+			err:             "selector is not valid: priceOfItemSelector"},
+		{
+			pageInstruction: entities.PageInstruction{
+				PageInstruction: storage.PageInstruction{ItemSelector: "$.products[", NameOfItemSelector: "name"},
+				Format:          entities.FormatJSON},
+			err: "selector is not valid: itemSelector"},
+		{
+			pageInstruction: entities.PageInstruction{Format: entities.FormatJSON},
+			err:             ErrPageInstructionCanNotBeWithoutItemSelector.Error()},
+		{
+			pageInstruction: entities.PageInstruction{Format: "xml"},
+			err:             ErrFormatOfPageIsUnknown.Error()},
+		{
+			pageInstruction: entities.PageInstruction{PaginationStrategies: []string{"random"}},
+			err:             "pagination strategy is unknown: random"}}
+
+	for _, test := range tests {
+		err := Validate(test.pageInstruction)
+
+		if test.err == "" && err != nil {
+			t.Errorf("expected valid selectors of '%v' but got '%v'", test.pageInstruction, err)
+		}
+
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("expected '%v' but got '%v'", test.err, err)
+		}
+	}
+}
+
+func TestSelectorsOfDetailPageInstructionCanBeValidated(t *testing.T) {
+	tests := []struct {
+		detailPageInstruction entities.DetailPageInstruction
+		err                   string
+	}{
+		{
+			detailPageInstruction: entities.DetailPageInstruction{
+				NameSelector:               "h1.product-title",
+				SpecificationSelector:      ".specifications tr",
+				SpecificationValueSelector: "xpath:./td[2]"},
+			err: ""},
+		{
+			detailPageInstruction: entities.DetailPageInstruction{ImageSelector: "img[src="},
+			err:                   "selector is not valid: imageSelector"},
+		{
+			detailPageInstruction: entities.DetailPageInstruction{SpecificationNameSelector: "xpath:./td[1"},
+			err:                   "selector is not valid: specificationNameSelector"}}
+
+	for _, test := range tests {
+		err := ValidateDetailPageInstruction(test.detailPageInstruction)
+
+		if test.err == "" && err != nil {
+			t.Errorf("expected valid selectors of '%v' but got '%v'", test.detailPageInstruction, err)
+		}
+
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("expected '%v' but got '%v'", test.err, err)
+		}
+	}
+}
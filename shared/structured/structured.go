@@ -0,0 +1,248 @@
+// Package structured extract products of schema.org from JSON-LD, microdata and OpenGraph of page.
+// Products of JSON-LD are used if they are found, then products of microdata, then OpenGraph of page.
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// Product is a product with offer of it from structured data of page.
+// Price is a text of price, Availability is a name of item of schema.org, like "InStock".
+type Product struct {
+	Name         string
+	Description  string
+	URL          string
+	Image        string
+	SKU          string
+	GTIN         string
+	Price        string
+	Currency     string
+	Availability string
+}
+
+// Extract return products from structured data of page
+func Extract(page *html.Node) []Product {
+	document := goquery.NewDocumentFromNode(page)
+
+	products := fromJSONLD(document)
+	if len(products) > 0 {
+		return products
+	}
+
+	products = fromMicrodata(document)
+	if len(products) > 0 {
+		return products
+	}
+
+	return fromOpenGraph(document)
+}
+
+func fromJSONLD(document *goquery.Document) []Product {
+	var products []Product
+
+	document.Find(`script[type="application/ld+json"]`).Each(func(_ int, script *goquery.Selection) {
+		var data interface{}
+
+		err := json.Unmarshal([]byte(script.Text()), &data)
+		if err != nil {
+			return
+		}
+
+		products = append(products, productsOf(data)...)
+	})
+
+	return products
+}
+
+// productsOf walk JSON-LD data and return products of it,
+// products of ItemList and @graph are returned too
+func productsOf(data interface{}) []Product {
+	switch value := data.(type) {
+	case []interface{}:
+		var products []Product
+		for _, child := range value {
+			products = append(products, productsOf(child)...)
+		}
+
+		return products
+
+	case map[string]interface{}:
+		if hasType(value, "Product") {
+			return []Product{productOf(value)}
+		}
+
+		var products []Product
+		for _, key := range []string{"@graph", "itemListElement", "item", "mainEntity"} {
+			child, ok := value[key]
+			if ok {
+				products = append(products, productsOf(child)...)
+			}
+		}
+
+		return products
+	}
+
+	return nil
+}
+
+func hasType(object map[string]interface{}, typeName string) bool {
+	switch value := object["@type"].(type) {
+	case string:
+		return value == typeName
+	case []interface{}:
+		for _, item := range value {
+			if item == typeName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func productOf(object map[string]interface{}) Product {
+	product := Product{
+		Name:        textOf(object["name"]),
+		Description: textOf(object["description"]),
+		URL:         textOf(object["url"]),
+		Image:       textOf(object["image"]),
+		SKU:         textOf(object["sku"])}
+
+	for _, key := range []string{"gtin13", "gtin", "gtin14", "gtin12", "gtin8"} {
+		if product.GTIN == "" {
+			product.GTIN = textOf(object[key])
+		}
+	}
+
+	offer := object["offers"]
+	if offers, ok := offer.([]interface{}); ok && len(offers) > 0 {
+		offer = offers[0]
+	}
+
+	offerObject, ok := offer.(map[string]interface{})
+	if !ok {
+		return product
+	}
+
+	product.Price = textOf(offerObject["price"])
+	if product.Price == "" {
+		product.Price = textOf(offerObject["lowPrice"])
+	}
+
+	product.Currency = textOf(offerObject["priceCurrency"])
+	product.Availability = schemaName(textOf(offerObject["availability"]))
+
+	if product.URL == "" {
+		product.URL = textOf(offerObject["url"])
+	}
+
+	return product
+}
+
+// textOf return text of value of JSON-LD, first item of array and url or @id of object
+func textOf(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return strings.TrimSpace(typedValue)
+	case float64:
+		return fmt.Sprint(typedValue)
+	case []interface{}:
+		if len(typedValue) > 0 {
+			return textOf(typedValue[0])
+		}
+	case map[string]interface{}:
+		for _, key := range []string{"url", "@id", "name"} {
+			if text := textOf(typedValue[key]); text != "" {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+// schemaName return name of item of schema.org from IRI, like "InStock" from "http://schema.org/InStock"
+func schemaName(value string) string {
+	return value[strings.LastIndex(value, "/")+1:]
+}
+
+func fromMicrodata(document *goquery.Document) []Product {
+	var products []Product
+
+	document.Find(`[itemscope][itemtype*="schema.org/Product"]`).Each(func(_ int, scope *goquery.Selection) {
+		product := Product{
+			Name:        propertyOf(scope, "name"),
+			Description: propertyOf(scope, "description"),
+			URL:         propertyOf(scope, "url"),
+			Image:       propertyOf(scope, "image"),
+			SKU:         propertyOf(scope, "sku")}
+
+		for _, property := range []string{"gtin13", "gtin", "gtin14", "gtin12", "gtin8"} {
+			if product.GTIN == "" {
+				product.GTIN = propertyOf(scope, property)
+			}
+		}
+
+		offer := scope.Find(`[itemprop="offers"]`).First()
+
+		product.Price = propertyOf(offer, "price")
+		if product.Price == "" {
+			product.Price = propertyOf(offer, "lowPrice")
+		}
+
+		product.Currency = propertyOf(offer, "priceCurrency")
+		product.Availability = schemaName(propertyOf(offer, "availability"))
+
+		products = append(products, product)
+	})
+
+	return products
+}
+
+// propertyOf return value of first itemprop element in scope: content, href or src attribute or text
+func propertyOf(scope *goquery.Selection, property string) string {
+	element := scope.Find(fmt.Sprintf(`[itemprop="%v"]`, property)).First()
+	if element.Length() == 0 {
+		return ""
+	}
+
+	for _, attribute := range []string{"content", "href", "src"} {
+		if value, ok := element.Attr(attribute); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return strings.TrimSpace(element.Text())
+}
+
+func fromOpenGraph(document *goquery.Document) []Product {
+	meta := func(properties ...string) string {
+		for _, property := range properties {
+			value, ok := document.Find(fmt.Sprintf(`meta[property="%v"]`, property)).First().Attr("content")
+			if ok && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+
+		return ""
+	}
+
+	product := Product{
+		Name:         meta("og:title"),
+		Description:  meta("og:description"),
+		URL:          meta("og:url"),
+		Image:        meta("og:image"),
+		Price:        meta("product:price:amount", "og:price:amount"),
+		Currency:     meta("product:price:currency", "og:price:currency"),
+		Availability: meta("product:availability", "og:availability")}
+
+	if product.Price == "" && meta("og:type") != "product" {
+		return nil
+	}
+
+	return []Product{product}
+}
@@ -0,0 +1,73 @@
+package structured
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+	"testing"
+)
+
+func TestProductsCanBeExtracted(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		expected []Product
+	}{
+		{
+			name: "JSON-LD with graph",
+			page: `<script type="application/ld+json">
+				{"@graph": [
+					{"@type": "WebPage", "name": "Page"},
+					{"@type": ["Product"], "name": "Phone", "image": ["/1.jpg", "/2.jpg"], "sku": 7,
+						"offers": [{"@type": "AggregateOffer", "lowPrice": 999.5, "priceCurrency": "RUB",
+							"availability": "http://schema.org/PreOrder"}]}
+				]}
+				</script>`,
+			expected: []Product{{
+				Name: "Phone", Image: "/1.jpg", SKU: "7", Price: "999.5", Currency: "RUB", Availability: "PreOrder"}}},
+		{
+			name: "microdata",
+			page: `<div itemscope itemtype="http://schema.org/Product">
+					<a itemprop="url" href="/phone"><span itemprop="name"> Phone </span></a>
+					<meta itemprop="gtin13" content="4600000000011">
+					<div itemprop="offers" itemscope itemtype="http://schema.org/Offer">
+						<span itemprop="price" content="1299.90">1 299,90 ₽</span>
+						<link itemprop="availability" href="http://schema.org/InStock">
+					</div>
+				</div>`,
+			expected: []Product{{
+				Name: "Phone", URL: "/phone", GTIN: "4600000000011", Price: "1299.90", Availability: "InStock"}}},
+		{
+			name: "OpenGraph",
+			page: `<head>
+					<meta property="og:type" content="product">
+					<meta property="og:title" content="Phone">
+					<meta property="product:price:amount" content="100">
+					<meta property="product:price:currency" content="USD">
+				</head>`,
+			expected: []Product{{Name: "Phone", Price: "100", Currency: "USD"}}},
+		{
+			name:     "page without structured data",
+			page:     `<meta property="og:title" content="Page"><script type="application/ld+json">{</script>`,
+			expected: nil},
+	}
+
+	for _, test := range tests {
+		page, err := html.Parse(strings.NewReader(test.page))
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		products := Extract(page)
+
+		if len(products) != len(test.expected) {
+			t.Errorf("%v: expected '%v' but got '%v'", test.name, test.expected, products)
+			continue
+		}
+
+		for index, product := range products {
+			if product != test.expected[index] {
+				t.Errorf("%v: expected '%v' but got '%v'", test.name, test.expected[index], product)
+			}
+		}
+	}
+}
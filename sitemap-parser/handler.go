@@ -0,0 +1,258 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gocolly/colly"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"net/url"
+	"regexp"
+	"sync"
+)
+
+const (
+	// DefaultSitemapPath is a path of sitemap which is used if robots.txt of shop has no sitemaps
+	DefaultSitemapPath = "/sitemap.xml"
+
+	// MaxSitemaps is a limit of loaded sitemaps with nested sitemaps of indexes
+	MaxSitemaps = 1000
+)
+
+var (
+	// ErrCompanyIRICanNotBeEmpty means that sitemaps can't be found without IRI of company
+	ErrCompanyIRICanNotBeEmpty = errors.New("company IRI can not be empty")
+
+	// ErrCompanyIRICanNotBeParsed means that IRI of company is not a valid URL
+	ErrCompanyIRICanNotBeParsed = errors.New("company IRI can not be parsed")
+
+	// ErrURLPatternCanNotBeCompiled means that pattern for filter of URLs is not a valid regular expression
+	ErrURLPatternCanNotBeCompiled = errors.New("URL pattern can not be compiled")
+
+	// ErrSitemapsAreNotFound means that no one sitemap of company is loaded
+	ErrSitemapsAreNotFound = errors.New("sitemaps are not found")
+)
+
+// Request for discovery of URLs of products of company.
+// URLPattern is optional, all URLs of sitemaps are returned if it is empty.
+// MaxURLs is optional too, all matched URLs are returned if it is zero.
+// Politeness is optional, defaults are used for empty values.
+type Request struct {
+	CompanyIRI string
+	URLPattern string
+	MaxURLs    int
+	Politeness crawl.Politeness
+}
+
+// Discovery is a result of discovery of URLs of products.
+// Sitemaps are loaded sitemaps with nested sitemaps of indexes,
+// Warnings are failures of sitemaps which are skipped.
+type Discovery struct {
+	CompanyIRI  string
+	Sitemaps    []string
+	ProductIRIs []string
+	Warnings    []string
+}
+
+type Response struct{ Message, Data, Error string }
+
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+		fmt.Println(warning)
+	}
+
+	discovery, err := discoverProducts(request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Discover products error by company IRI: %v. Error: %v",
+			request.CompanyIRI,
+			err)
+
+		fmt.Println(warning)
+
+		encodedResponse := Response{
+			Message: warning,
+			Data:    string(req),
+			Error:   err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedDiscovery, err := json.Marshal(discovery)
+	if err != nil {
+		encodedResponse := Response{
+			Data:  string(req),
+			Error: err.Error()}
+
+		response, err := json.Marshal(encodedResponse)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		return string(response)
+	}
+
+	encodedResponse := Response{Data: string(encodedDiscovery)}
+
+	response, err := json.Marshal(encodedResponse)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	return string(response)
+}
+
+// discoverProducts load sitemaps of robots.txt of company, or sitemap by DefaultSitemapPath,
+// with all nested sitemaps of indexes and return URLs of them matched by URLPattern
+func discoverProducts(request Request) (Discovery, error) {
+	discovery := Discovery{CompanyIRI: request.CompanyIRI}
+
+	if request.CompanyIRI == "" {
+		return discovery, ErrCompanyIRICanNotBeEmpty
+	}
+
+	companyIRI, err := url.Parse(request.CompanyIRI)
+	if err != nil || companyIRI.Host == "" {
+		return discovery, ErrCompanyIRICanNotBeParsed
+	}
+
+	pattern, err := regexp.Compile(request.URLPattern)
+	if err != nil {
+		return discovery, ErrURLPatternCanNotBeCompiled
+	}
+
+	sitemaps, err := sitemapsOfCompany(companyIRI, request.Politeness)
+	if err != nil {
+		return discovery, err
+	}
+
+	collector, failedRequests, err := crawl.NewCollector(request.Politeness)
+	if err != nil {
+		return discovery, err
+	}
+
+	collector.MaxBodySize = MaxSitemapSize
+
+	var mutex sync.Mutex
+	seen := map[string]bool{}
+	visited := 0
+
+	visit := func(sitemap string) {
+		mutex.Lock()
+		full := visited >= MaxSitemaps || (request.MaxURLs > 0 && len(discovery.ProductIRIs) >= request.MaxURLs)
+		if !full {
+			visited++
+		}
+		mutex.Unlock()
+
+		if full {
+			return
+		}
+
+		err := collector.Visit(sitemap)
+		if err != nil && err != colly.ErrAlreadyVisited {
+			fmt.Printf("Error visit sitemap: %v. Error: %v\n", sitemap, err)
+		}
+	}
+
+	collector.OnResponse(func(response *colly.Response) {
+		pages, nestedSitemaps, err := parseSitemap(response.Body)
+
+		mutex.Lock()
+
+		if err != nil {
+			warning := fmt.Sprintf("Sitemap: %v can not be parsed. Error: %v", response.Request.URL, err)
+
+			fmt.Println(warning)
+
+			discovery.Warnings = append(discovery.Warnings, warning)
+			mutex.Unlock()
+
+			return
+		}
+
+		discovery.Sitemaps = append(discovery.Sitemaps, response.Request.URL.String())
+
+		for _, page := range pages {
+			if request.MaxURLs > 0 && len(discovery.ProductIRIs) >= request.MaxURLs {
+				break
+			}
+
+			if seen[page] || !pattern.MatchString(page) {
+				continue
+			}
+
+			seen[page] = true
+			discovery.ProductIRIs = append(discovery.ProductIRIs, page)
+		}
+
+		mutex.Unlock()
+
+		for _, nestedSitemap := range nestedSitemaps {
+			visit(response.Request.AbsoluteURL(nestedSitemap))
+		}
+	})
+
+	for _, sitemap := range sitemaps {
+		visit(sitemap)
+	}
+
+	collector.Wait()
+
+	if err = failedRequests.Err(); err != nil {
+		discovery.Warnings = append(discovery.Warnings, failedRequests.Warnings()...)
+	}
+
+	if len(discovery.Sitemaps) == 0 {
+		if err != nil {
+			return discovery, err
+		}
+
+		return discovery, ErrSitemapsAreNotFound
+	}
+
+	return discovery, nil
+}
+
+// sitemapsOfCompany return links of sitemaps of robots.txt of company,
+// or link by DefaultSitemapPath if robots.txt has no sitemaps or can not be loaded
+func sitemapsOfCompany(companyIRI *url.URL, politeness crawl.Politeness) ([]string, error) {
+	robotsTxt := url.URL{Scheme: companyIRI.Scheme, Host: companyIRI.Host, Path: "/robots.txt"}
+	defaultSitemap := url.URL{Scheme: companyIRI.Scheme, Host: companyIRI.Host, Path: DefaultSitemapPath}
+
+	collector, _, err := crawl.NewCollector(politeness)
+	if err != nil {
+		return nil, err
+	}
+
+	var sitemaps []string
+
+	collector.OnResponse(func(response *colly.Response) {
+		for _, sitemap := range sitemapsOfRobotsTxt(response.Body) {
+			sitemaps = append(sitemaps, response.Request.AbsoluteURL(sitemap))
+		}
+	})
+
+	err = collector.Visit(robotsTxt.String())
+	if err != nil {
+		fmt.Printf("Error visit robots.txt: %v. Error: %v\n", robotsTxt.String(), err)
+	}
+
+	collector.Wait()
+
+	if len(sitemaps) == 0 {
+		return []string{defaultSitemap.String()}, nil
+	}
+
+	return sitemaps, nil
+}
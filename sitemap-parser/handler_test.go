@@ -0,0 +1,157 @@
+package function
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/crawl"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProductsCanBeDiscoveredBySitemaps(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	write := func(w http.ResponseWriter, body []byte) {
+		_, err := w.Write(body)
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	}
+
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		write(w, []byte("User-agent: *\nDisallow: /cart\nSitemap: /sitemap-index.xml\n"))
+	})
+
+	mux.HandleFunc("/sitemap-index.xml", func(w http.ResponseWriter, r *http.Request) {
+		write(w, []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+			<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+				<sitemap><loc>%v/sitemap-products.xml.gz</loc></sitemap>
+				<sitemap><loc>%v/sitemap-pages.xml</loc></sitemap>
+				<sitemap><loc>%v/sitemap-index.xml</loc></sitemap>
+			</sitemapindex>`, server.URL, server.URL, server.URL)))
+	})
+
+	mux.HandleFunc("/sitemap-products.xml.gz", func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+
+		writer := gzip.NewWriter(&body)
+		_, err := writer.Write([]byte(fmt.Sprintf(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+				<url><loc>%v/products/1</loc></url>
+				<url><loc> %v/products/2 </loc></url>
+				<url><loc>%v/products/1</loc></url>
+			</urlset>`, server.URL, server.URL, server.URL)))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+
+		writer.Close()
+
+		w.Header().Set("Content-Type", "application/x-gzip")
+		write(w, body.Bytes())
+	})
+
+	mux.HandleFunc("/sitemap-pages.xml", func(w http.ResponseWriter, r *http.Request) {
+		write(w, []byte(fmt.Sprintf(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+				<url><loc>%v/about</loc></url>
+				<url><loc>%v/products/3</loc></url>
+			</urlset>`, server.URL, server.URL)))
+	})
+
+	request := Request{CompanyIRI: server.URL + "/", URLPattern: "/products/\\d+$"}
+
+	response := Response{}
+
+	err := json.Unmarshal([]byte(Handle(mustMarshal(t, request))), &response)
+	if err != nil || response.Error != "" {
+		t.Fatalf("expected no errors but got: %v, %v", err, response.Error)
+	}
+
+	discovery := Discovery{}
+
+	err = json.Unmarshal([]byte(response.Data), &discovery)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(discovery.Sitemaps) != 3 {
+		t.Errorf("expected %v sitemaps but got: %v", 3, discovery.Sitemaps)
+	}
+
+	expected := map[string]bool{
+		server.URL + "/products/1": true,
+		server.URL + "/products/2": true,
+		server.URL + "/products/3": true}
+
+	if len(discovery.ProductIRIs) != len(expected) {
+		t.Fatalf("expected '%v' but got '%v'", expected, discovery.ProductIRIs)
+	}
+
+	for _, productIRI := range discovery.ProductIRIs {
+		if !expected[productIRI] {
+			t.Errorf("unexpected product IRI: %v", productIRI)
+		}
+	}
+
+	request.MaxURLs = 1
+
+	discovery, err = discoverProducts(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(discovery.ProductIRIs) != 1 {
+		t.Errorf("expected %v product IRI but got: %v", 1, discovery.ProductIRIs)
+	}
+}
+
+func TestProductsCanBeDiscoveredByDefaultSitemap(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(fmt.Sprintf(
+			`<urlset><url><loc>%v/products/1</loc></url></urlset>`, server.URL)))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+	})
+
+	discovery, err := discoverProducts(Request{CompanyIRI: server.URL, Politeness: crawl.Politeness{Retries: -1}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(discovery.ProductIRIs) != 1 || discovery.ProductIRIs[0] != server.URL+"/products/1" {
+		t.Errorf("expected '%v' but got '%v'", server.URL+"/products/1", discovery.ProductIRIs)
+	}
+}
+
+func TestProductsCanNotBeDiscoveredWithoutSitemaps(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := discoverProducts(Request{CompanyIRI: server.URL, Politeness: crawl.Politeness{Retries: -1}})
+	if err == nil {
+		t.Errorf("expected error of not found sitemap")
+	}
+
+	_, err = discoverProducts(Request{})
+	if err != ErrCompanyIRICanNotBeEmpty {
+		t.Errorf("expected '%v' but got '%v'", ErrCompanyIRICanNotBeEmpty, err)
+	}
+}
+
+func mustMarshal(t *testing.T, request Request) []byte {
+	encodedRequest, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return encodedRequest
+}
@@ -0,0 +1,86 @@
+package function
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// MaxSitemapSize is a limit of size of unpacked sitemap in bytes, like in sitemaps protocol
+const MaxSitemapSize = 50 * 1024 * 1024
+
+// location is a link of url or sitemap of sitemap document
+type location struct {
+	Loc string `xml:"loc"`
+}
+
+// sitemapDocument is a <urlset> with links of pages or a <sitemapindex> with links of sitemaps
+type sitemapDocument struct {
+	URLs     []location `xml:"url"`
+	Sitemaps []location `xml:"sitemap"`
+}
+
+// sitemapsOfRobotsTxt return links of "Sitemap:" lines of robots.txt
+func sitemapsOfRobotsTxt(body []byte) []string {
+	var sitemaps []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		separator := strings.Index(line, ":")
+		if separator < 0 || !strings.EqualFold(strings.TrimSpace(line[:separator]), "sitemap") {
+			continue
+		}
+
+		link := strings.TrimSpace(line[separator+1:])
+		if link != "" {
+			sitemaps = append(sitemaps, link)
+		}
+	}
+
+	return sitemaps
+}
+
+// parseSitemap return links of pages and links of nested sitemaps of sitemap.
+// Body is unpacked if it is gzip file, like sitemap.xml.gz.
+func parseSitemap(body []byte) (pages, sitemaps []string, err error) {
+	if len(body) > 1 && body[0] == 0x1f && body[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defer reader.Close()
+
+		body, err = ioutil.ReadAll(io.LimitReader(reader, MaxSitemapSize))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	document := sitemapDocument{}
+
+	err = xml.Unmarshal(body, &document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, page := range document.URLs {
+		if link := strings.TrimSpace(page.Loc); link != "" {
+			pages = append(pages, link)
+		}
+	}
+
+	for _, sitemap := range document.Sitemaps {
+		if link := strings.TrimSpace(sitemap.Loc); link != "" {
+			sitemaps = append(sitemaps, link)
+		}
+	}
+
+	return pages, sitemaps, nil
+}
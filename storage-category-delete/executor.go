@@ -1,48 +1,40 @@
 package function
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"os"
+	"github.com/hecatoncheir/Functions/shared/mutation"
 )
 
-type Storage interface {
-	DeleteJSON([]byte) error
-}
-
 type Executor struct {
-	Store Storage
+	Store mutation.Storage
 }
 
-var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+// IsActivePredicate is a predicate which is false for deleted category
+const IsActivePredicate = "categoryIsActive"
+
+// IncomingEdges are predicates of other entities with edges to category
+var IncomingEdges = []string{"has_category", "belongs_to_category"}
 
 var (
 	// ErrCategoryCanNotBeWithoutID means that category can't be found in storage for make some operation
-	ErrCategoryCanNotBeWithoutID = errors.New("category can not be without id")
+	ErrCategoryCanNotBeWithoutID = mutation.ErrEntityCanNotBeWithoutID
 
 	// ErrCategoryByIDCanNotBeDeleted means that the category can't be deleted from database
-	ErrCategoryByIDCanNotBeDeleted = errors.New("category by id can not be deleted")
+	ErrCategoryByIDCanNotBeDeleted = mutation.ErrEntityByIDCanNotBeDeleted
 )
 
-// DeleteCategoryByID is a method for delete category by ID
+// DeleteCategoryByID is a method for soft delete of category by ID, category is kept in database as not active
 func (executor *Executor) DeleteCategoryByID(categoryID string) error {
+	return executor.deletion().DeleteEntityByID(categoryID)
+}
 
-	if categoryID == "" {
-		ExecutorLogger.Println(ErrCategoryCanNotBeWithoutID)
-		return ErrCategoryCanNotBeWithoutID
-	}
-
-	deleteCategoryData, err := json.Marshal(map[string]string{"uid": categoryID})
-	if err != nil {
-		return err
-	}
-
-	err = executor.Store.DeleteJSON(deleteCategoryData)
-	if err != nil {
-		ExecutorLogger.Println(err)
-		return ErrCategoryByIDCanNotBeDeleted
-	}
+// HardDeleteCategoryByID is a method for delete category by ID with all edges of other entities to it
+func (executor *Executor) HardDeleteCategoryByID(categoryID string) error {
+	return executor.deletion().HardDeleteEntityByID(categoryID)
+}
 
-	return nil
+func (executor *Executor) deletion() *mutation.Deletion {
+	return &mutation.Deletion{
+		Store:             executor.Store,
+		IsActivePredicate: IsActivePredicate,
+		IncomingEdges:     IncomingEdges}
 }
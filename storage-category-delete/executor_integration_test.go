@@ -87,7 +87,7 @@ func TestExecutor_DeleteCategoryByID(t *testing.T) {
 		t.Fatalf("Founded category id: %v not created category id: %v", categoryFromStore.ID, createdCategoryID)
 	}
 
-	err = executor.DeleteCategoryByID(createdCategoryID)
+	err = executor.HardDeleteCategoryByID(createdCategoryID)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
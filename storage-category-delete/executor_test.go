@@ -1,7 +1,6 @@
 package function
 
 import (
-	"github.com/hecatoncheir/Storage"
 	"testing"
 )
 
@@ -9,18 +8,59 @@ import (
 func TestCategoryCanBeReadByID(t *testing.T) {
 	IDOfTestedCategory := "0x12"
 
-	executor := Executor{Store: MockStore{}}
+	store := &MockStore{}
+	executor := Executor{Store: store}
 
 	err := executor.DeleteCategoryByID(IDOfTestedCategory)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
+
+	if store.Created != `{"categoryIsActive":false,"uid":"0x12"}` {
+		t.Errorf("Expected category is deactivated, actual: %v", store.Created)
+	}
+
+	if store.Deleted != "" {
+		t.Errorf("Expected category is not deleted, actual: %v", store.Deleted)
+	}
 }
 
 type MockStore struct {
-	storage.Store
+	Edges            string
+	Created, Deleted string
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Edges), nil
+}
+
+func (store *MockStore) CreateJSON(setJSON []byte) (string, error) {
+	store.Created = string(setJSON)
+	return "", nil
 }
 
-func (store MockStore) DeleteJSON(encodedJSON []byte) error {
+func (store *MockStore) DeleteJSON(deleteJSON []byte) error {
+	store.Deleted = string(deleteJSON)
 	return nil
 }
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestCategoryCanBeHardDeletedByID(t *testing.T) {
+	store := &MockStore{Edges: `{"has_category": [{"uid": "0x13"}], "belongs_to_category": [{"uid": "0x14"}]}`}
+	executor := Executor{Store: store}
+
+	err := executor.HardDeleteCategoryByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `[{"has_category":[{"uid":"0x12"}],"uid":"0x13"},{"belongs_to_category":[{"uid":"0x12"}],"uid":"0x14"},{"uid":"0x12"}]`
+
+	if store.Deleted != expected {
+		t.Errorf("Expected deletion of category with edges to it: %v, actual: %v", expected, store.Deleted)
+	}
+
+	if store.Created != "" {
+		t.Errorf("Expected category is not deactivated, actual: %v", store.Created)
+	}
+}
@@ -6,7 +6,12 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ CategoryID, DatabaseGateway string }
+// Request for delete of category by ID, category is only deactivated if HardDelete is not set
+type Request struct {
+	CategoryID, DatabaseGateway string
+	HardDelete                  bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -28,7 +33,13 @@ func Handle(req []byte) string {
 	}
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
-	err = executor.DeleteCategoryByID(request.CategoryID)
+
+	if request.HardDelete {
+		err = executor.HardDeleteCategoryByID(request.CategoryID)
+	} else {
+		err = executor.DeleteCategoryByID(request.CategoryID)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"DeleteCategoryByID error: %v", err)
@@ -1,48 +1,40 @@
 package function
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"os"
+	"github.com/hecatoncheir/Functions/shared/mutation"
 )
 
-type Storage interface {
-	DeleteJSON([]byte) error
-}
-
 type Executor struct {
-	Store Storage
+	Store mutation.Storage
 }
 
-var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+// IsActivePredicate is a predicate which is false for deleted city
+const IsActivePredicate = "cityIsActive"
+
+// IncomingEdges are predicates of other entities with edges to city
+var IncomingEdges = []string{"has_city", "belongs_to_city"}
 
 var (
 	// ErrCityCanNotBeWithoutID means that city can't be found in storage for make some operation
-	ErrCityCanNotBeWithoutID = errors.New("city can not be without id")
+	ErrCityCanNotBeWithoutID = mutation.ErrEntityCanNotBeWithoutID
 
 	// ErrCityByIDCanNotBeDeleted means that the city can't be deleted from database
-	ErrCityByIDCanNotBeDeleted = errors.New("city by id can not be deleted")
+	ErrCityByIDCanNotBeDeleted = mutation.ErrEntityByIDCanNotBeDeleted
 )
 
-// DeleteCityByID is a method for delete city by ID
+// DeleteCityByID is a method for soft delete of city by ID, city is kept in database as not active
 func (executor *Executor) DeleteCityByID(cityID string) error {
+	return executor.deletion().DeleteEntityByID(cityID)
+}
 
-	if cityID == "" {
-		ExecutorLogger.Println(ErrCityCanNotBeWithoutID)
-		return ErrCityCanNotBeWithoutID
-	}
-
-	deleteCityData, err := json.Marshal(map[string]string{"uid": cityID})
-	if err != nil {
-		return err
-	}
-
-	err = executor.Store.DeleteJSON(deleteCityData)
-	if err != nil {
-		ExecutorLogger.Println(err)
-		return ErrCityByIDCanNotBeDeleted
-	}
+// HardDeleteCityByID is a method for delete city by ID with all edges of other entities to it
+func (executor *Executor) HardDeleteCityByID(cityID string) error {
+	return executor.deletion().HardDeleteEntityByID(cityID)
+}
 
-	return nil
+func (executor *Executor) deletion() *mutation.Deletion {
+	return &mutation.Deletion{
+		Store:             executor.Store,
+		IsActivePredicate: IsActivePredicate,
+		IncomingEdges:     IncomingEdges}
 }
@@ -80,7 +80,7 @@ func TestExecutor_DeleteCityByID(t *testing.T) {
 		t.Fatalf("Founded city id: %v not created city id: %v", cityFromStore.ID, createdCityID)
 	}
 
-	err = executor.DeleteCityByID(createdCityID)
+	err = executor.HardDeleteCityByID(createdCityID)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -6,7 +6,12 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ CityID, DatabaseGateway string }
+// Request for delete of city by ID, city is only deactivated if HardDelete is not set
+type Request struct {
+	CityID, DatabaseGateway string
+	HardDelete              bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -30,7 +35,13 @@ func Handle(req []byte) string {
 	}
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
-	err = executor.DeleteCityByID(request.CityID)
+
+	if request.HardDelete {
+		err = executor.HardDeleteCityByID(request.CityID)
+	} else {
+		err = executor.DeleteCityByID(request.CityID)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"DeleteCityByID error: %v", err)
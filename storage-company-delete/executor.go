@@ -1,48 +1,40 @@
 package function
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"os"
+	"github.com/hecatoncheir/Functions/shared/mutation"
 )
 
-type Storage interface {
-	DeleteJSON([]byte) error
-}
-
 type Executor struct {
-	Store Storage
+	Store mutation.Storage
 }
 
-var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+// IsActivePredicate is a predicate which is false for deleted company
+const IsActivePredicate = "companyIsActive"
+
+// IncomingEdges are predicates of other entities with edges to company
+var IncomingEdges = []string{"has_company", "belongs_to_company"}
 
 var (
 	// ErrCompanyCanNotBeWithoutID means that company can't be found in storage for make some operation
-	ErrCompanyCanNotBeWithoutID = errors.New("company can not be without id")
+	ErrCompanyCanNotBeWithoutID = mutation.ErrEntityCanNotBeWithoutID
 
 	// ErrCompanyByIDCanNotBeDeleted means that the company can't be deleted from database
-	ErrCompanyByIDCanNotBeDeleted = errors.New("company by id can not be deleted")
+	ErrCompanyByIDCanNotBeDeleted = mutation.ErrEntityByIDCanNotBeDeleted
 )
 
-// DeleteCompanyByID is a method for delete city by ID
+// DeleteCompanyByID is a method for soft delete of company by ID, company is kept in database as not active
 func (executor *Executor) DeleteCompanyByID(companyID string) error {
+	return executor.deletion().DeleteEntityByID(companyID)
+}
 
-	if companyID == "" {
-		ExecutorLogger.Println(ErrCompanyCanNotBeWithoutID)
-		return ErrCompanyCanNotBeWithoutID
-	}
-
-	deleteCompanyData, err := json.Marshal(map[string]string{"uid": companyID})
-	if err != nil {
-		return err
-	}
-
-	err = executor.Store.DeleteJSON(deleteCompanyData)
-	if err != nil {
-		ExecutorLogger.Println(err)
-		return ErrCompanyByIDCanNotBeDeleted
-	}
+// HardDeleteCompanyByID is a method for delete company by ID with all edges of other entities to it
+func (executor *Executor) HardDeleteCompanyByID(companyID string) error {
+	return executor.deletion().HardDeleteEntityByID(companyID)
+}
 
-	return nil
+func (executor *Executor) deletion() *mutation.Deletion {
+	return &mutation.Deletion{
+		Store:             executor.Store,
+		IsActivePredicate: IsActivePredicate,
+		IncomingEdges:     IncomingEdges}
 }
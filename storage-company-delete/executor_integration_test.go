@@ -81,7 +81,7 @@ func TestExecutor_DeleteCompanyByID(t *testing.T) {
 		t.Fatalf("Founded company id: %v not created company id: %v", companyFromStore.ID, createdCompanyID)
 	}
 
-	err = executor.DeleteCompanyByID(createdCompanyID)
+	err = executor.HardDeleteCompanyByID(createdCompanyID)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -6,7 +6,12 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ CompanyID, DatabaseGateway string }
+// Request for delete of company by ID, company is only deactivated if HardDelete is not set
+type Request struct {
+	CompanyID, DatabaseGateway string
+	HardDelete                 bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -30,7 +35,13 @@ func Handle(req []byte) string {
 	}
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
-	err = executor.DeleteCompanyByID(request.CompanyID)
+
+	if request.HardDelete {
+		err = executor.HardDeleteCompanyByID(request.CompanyID)
+	} else {
+		err = executor.DeleteCompanyByID(request.CompanyID)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"DeleteCompanyByID error: %v", err)
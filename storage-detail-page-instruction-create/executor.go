@@ -0,0 +1,58 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"log"
+	"os"
+)
+
+type Storage interface {
+	CreateJSON([]byte) (string, error)
+}
+
+type Functions interface {
+	ReadDetailPageInstructionByID(string, string) entities.DetailPageInstruction
+}
+
+type Executor struct {
+	Store     Storage
+	Functions Functions
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrDetailPageInstructionCanNotBeCreated means that the detail page instruction can't be added to database
+	ErrDetailPageInstructionCanNotBeCreated = errors.New("detail page instruction can't be created")
+)
+
+// CreateDetailPageInstruction make active DetailPageInstruction and save it to storage,
+// detail page instruction with selector which can't be compiled is not saved
+func (executor *Executor) CreateDetailPageInstruction(detailPageInstruction entities.DetailPageInstruction, language string) (entities.DetailPageInstruction, error) {
+
+	err := selectors.ValidateDetailPageInstruction(detailPageInstruction)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, err
+	}
+
+	detailPageInstruction.IsActive = true
+
+	encodedDetailPageInstruction, err := json.Marshal(detailPageInstruction)
+	if err != nil {
+		return detailPageInstruction, ErrDetailPageInstructionCanNotBeCreated
+	}
+
+	uidOfCreatedDetailPageInstruction, err := executor.Store.CreateJSON(encodedDetailPageInstruction)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, ErrDetailPageInstructionCanNotBeCreated
+	}
+
+	createdDetailPageInstruction := executor.Functions.ReadDetailPageInstructionByID(uidOfCreatedDetailPageInstruction, language)
+
+	return createdDetailPageInstruction, nil
+}
@@ -0,0 +1,135 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestIntegration_CreateDetailPageInstruction(t *testing.T) {
+	t.Skip("Database and FAAS must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "192.168.99.101:31332"
+	}
+
+	FunctionsGateway := os.Getenv("FunctionsGateway")
+	if FunctionsGateway == "" {
+		FunctionsGateway = "http://192.168.99.101:31112/function"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		nameSelector: string @index(term) .
+		imageSelector: string @index(term) .
+		specificationSelector: string @index(term) .
+		specificationValueSelector: string @index(term) .
+		detailPageInstructionIsActive: bool @index(bool) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	detailPageInstructionForCreate := entities.DetailPageInstruction{
+		NameSelector:               "h1.product-title",
+		ImageSelector:              ".product-gallery img",
+		SpecificationSelector:      ".specifications tr",
+		SpecificationValueSelector: "xpath:./td[2]"}
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  DatabaseGateway,
+			FunctionsGateway: FunctionsGateway}}
+
+	Language := "ru"
+
+	time.Sleep(3 * time.Second)
+
+	createdDetailPageInstruction, err := executor.CreateDetailPageInstruction(detailPageInstructionForCreate, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdDetailPageInstruction.ID == "" {
+		t.Fatalf("Created detail page instruction id is empty")
+	}
+
+	defer func() {
+		err = deleteEntityByID(createdDetailPageInstruction.ID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	if !createdDetailPageInstruction.IsActive {
+		t.Fatalf("Created detail page instruction: %v is not active", createdDetailPageInstruction.ID)
+	}
+
+	if createdDetailPageInstruction.NameSelector != detailPageInstructionForCreate.NameSelector ||
+		createdDetailPageInstruction.SpecificationValueSelector != detailPageInstructionForCreate.SpecificationValueSelector {
+		t.Fatalf("Created detail page instruction: %v is not saved with selectors", createdDetailPageInstruction)
+	}
+
+	_, err = executor.CreateDetailPageInstruction(entities.DetailPageInstruction{ImageSelector: "img[src="}, Language)
+	if err == nil {
+		t.Fatalf("Detail page instruction with invalid selector is created")
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"testing"
+)
+
+// ------------------------------------------------------------------------------------------------------
+func TestDetailPageInstructionCanBeCreated(t *testing.T) {
+	detailPageInstructionForCreate := entities.DetailPageInstruction{
+		NameSelector:               "h1.product-title",
+		ImageSelector:              ".product-gallery img",
+		SpecificationSelector:      ".specifications tr",
+		SpecificationValueSelector: "xpath:./td[2]"}
+
+	store := &MockStorage{}
+
+	executor := Executor{
+		Functions: MockFAASFunctions{FunctionsGateway: ""},
+		Store:     store}
+
+	createdDetailPageInstruction, err := executor.CreateDetailPageInstruction(detailPageInstructionForCreate, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdDetailPageInstruction.ID != "0x12" {
+		t.Errorf("Expect: %v, but got: %v", "0x12", createdDetailPageInstruction.ID)
+	}
+
+	var savedDetailPageInstruction map[string]interface{}
+	err = json.Unmarshal(store.SetJSON, &savedDetailPageInstruction)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for predicate, value := range map[string]string{
+		"nameSelector":               "h1.product-title",
+		"imageSelector":              ".product-gallery img",
+		"specificationSelector":      ".specifications tr",
+		"specificationValueSelector": "xpath:./td[2]"} {
+		if savedDetailPageInstruction[predicate] != value {
+			t.Errorf("Expect %v: %v, but got: %v", predicate, value, savedDetailPageInstruction[predicate])
+		}
+	}
+
+	if savedDetailPageInstruction["detailPageInstructionIsActive"] != true {
+		t.Errorf("Expect active detail page instruction saved to storage")
+	}
+}
+
+/// Mock FAAS functions
+type MockFAASFunctions struct {
+	FunctionsGateway string
+}
+
+func (functions MockFAASFunctions) ReadDetailPageInstructionByID(detailPageInstructionID, language string) entities.DetailPageInstruction {
+	return entities.DetailPageInstruction{ID: detailPageInstructionID, NameSelector: "h1.product-title", IsActive: true}
+}
+
+/// Mock Storage
+type MockStorage struct {
+	SetJSON []byte
+}
+
+func (store *MockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	store.SetJSON = setJson
+	return "0x12", nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+
+func TestDetailPageInstructionWithInvalidSelectorCanNotBeCreated(t *testing.T) {
+	detailPageInstructionForCreate := entities.DetailPageInstruction{ImageSelector: "img[src="}
+
+	store := &MockStorage{}
+
+	executor := Executor{
+		Functions: MockFAASFunctions{FunctionsGateway: ""},
+		Store:     store}
+
+	_, err := executor.CreateDetailPageInstruction(detailPageInstructionForCreate, "ru")
+	if err == nil || err.Error() != "selector is not valid: imageSelector" {
+		t.Fatalf("Expect error of invalid selector, but got: %v", err)
+	}
+
+	if store.SetJSON != nil {
+		t.Errorf("Expect detail page instruction with invalid selector is not saved to storage")
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+
+func TestDetailPageInstructionCanNotBeCreated(t *testing.T) {
+	detailPageInstructionForCreate := entities.DetailPageInstruction{}
+
+	executor := Executor{
+		Functions: MockFAASFunctions{FunctionsGateway: ""},
+		Store:     ErrorMockStorage{DatabaseGateway: ""}}
+
+	_, err := executor.CreateDetailPageInstruction(detailPageInstructionForCreate, "ru")
+	if err != ErrDetailPageInstructionCanNotBeCreated {
+		t.Fatalf(err.Error())
+	}
+}
+
+type ErrorMockStorage struct {
+	DatabaseGateway string
+}
+
+func (store ErrorMockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	return "", errors.New("")
+}
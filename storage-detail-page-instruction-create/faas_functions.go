@@ -0,0 +1,70 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+)
+
+var FAASLogger = log.New(os.Stdout, "FAASFunctions: ", log.Lshortfile)
+
+type FAASFunctions struct {
+	FunctionsGateway string
+	DatabaseGateway  string
+}
+
+func (functions FAASFunctions) ReadDetailPageInstructionByID(detailPageInstructionID, language string) entities.DetailPageInstruction {
+	functionPath := fmt.Sprintf(
+		"%v/%v", functions.FunctionsGateway, "storage-detail-page-instruction-read-by-id")
+
+	body := struct {
+		Language                string
+		DetailPageInstructionID string
+		DatabaseGateway         string
+	}{
+		Language:                language,
+		DetailPageInstructionID: detailPageInstructionID,
+		DatabaseGateway:         functions.DatabaseGateway}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.DetailPageInstruction{ID: detailPageInstructionID}
+	}
+
+	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.DetailPageInstruction{ID: detailPageInstructionID}
+	}
+
+	defer response.Body.Close()
+
+	decodedResponse, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.DetailPageInstruction{ID: detailPageInstructionID}
+	}
+
+	encodedResponse := Response{}
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.DetailPageInstruction{ID: detailPageInstructionID}
+	}
+
+	var existDetailPageInstruction entities.DetailPageInstruction
+
+	err = json.Unmarshal([]byte(encodedResponse.Data), &existDetailPageInstruction)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.DetailPageInstruction{ID: detailPageInstructionID}
+	}
+
+	return existDetailPageInstruction
+}
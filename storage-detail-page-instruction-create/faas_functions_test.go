@@ -0,0 +1,77 @@
+package function
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFAASFunctions_ReadDetailPageInstructionByID(t *testing.T) {
+	LanguageForTest := "ru"
+	DetailPageInstructionIDForTest := "0x12"
+	DatabaseGatewayForTest := "http://TestDatabaseGateway"
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		var responseBodyEncoded map[string]string
+		err = json.Unmarshal(encodedBody, &responseBodyEncoded)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if responseBodyEncoded["Language"] != LanguageForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", LanguageForTest, responseBodyEncoded["Language"])
+		}
+
+		if responseBodyEncoded["DetailPageInstructionID"] != DetailPageInstructionIDForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", DetailPageInstructionIDForTest, responseBodyEncoded["DetailPageInstructionID"])
+		}
+
+		if responseBodyEncoded["DatabaseGateway"] != DatabaseGatewayForTest {
+			t.Fatalf(
+				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
+		}
+
+		existedDetailPageInstructionInStorage := entities.DetailPageInstruction{
+			ID:                   "0x12",
+			AvailabilitySelector: ".product-buy .button",
+			IsActive:             true}
+
+		encodedExistedPageInstructionInStorage, err := json.Marshal(existedDetailPageInstructionInStorage)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedExistedPageInstructionInStorage)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(testHandler)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL, DatabaseGateway: DatabaseGatewayForTest}
+	instruction := faas.ReadDetailPageInstructionByID(DetailPageInstructionIDForTest, LanguageForTest)
+
+	if instruction.ID != "0x12" {
+		t.Fatalf("Expect detail page instruction id: %v, but got: %v", DetailPageInstructionIDForTest, instruction.ID)
+	}
+
+	if instruction.AvailabilitySelector != ".product-buy .button" {
+		t.Fatalf("Expect availability selector of detail page instruction, but got: %v", instruction.AvailabilitySelector)
+	}
+}
@@ -0,0 +1,105 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrDetailPageInstructionCanNotBeWithoutID means that detail page instruction can't be without id
+	ErrDetailPageInstructionCanNotBeWithoutID = errors.New("detail page instruction can not be without id")
+
+	// ErrDetailPageInstructionByIDCanNotBeFound means that the detail page instruction can't be found in database
+	ErrDetailPageInstructionByIDCanNotBeFound = errors.New("detail page instruction by id can not be found")
+
+	// ErrDetailPageInstructionDoesNotExist means than the detail page instruction does not exist in database
+	ErrDetailPageInstructionDoesNotExist = errors.New("detail page instruction does not exist")
+)
+
+// ReadDetailPageInstructionByID is a method for get active detail page instruction by ID with all selectors of it
+func (executor *Executor) ReadDetailPageInstructionByID(detailPageInstructionID string) (entities.DetailPageInstruction, error) {
+
+	if detailPageInstructionID == "" {
+		ExecutorLogger.Printf("Detail page instruction can't be without ID")
+		return entities.DetailPageInstruction{}, ErrDetailPageInstructionCanNotBeWithoutID
+	}
+
+	variables := struct {
+		DetailPageInstructionID string
+	}{
+		DetailPageInstructionID: detailPageInstructionID}
+
+	queryTemplate, err := template.New("ReadDetailPageInstructionByID").Parse(`{
+				detailPageInstructions(func: uid("{{.DetailPageInstructionID}}")) @filter(eq(detailPageInstructionIsActive, true)) {
+					uid
+					nameSelector
+					descriptionSelector
+					skuSelector
+					gtinSelector
+					priceSelector
+					oldPriceSelector
+					availabilitySelector
+					imageSelector
+					specificationSelector
+					specificationNameSelector
+					specificationValueSelector
+					cityParamPath
+					cityInCookieKey
+					cityIdForCookie
+					detailPageInstructionIsActive
+				}
+			}`)
+
+	detailPageInstruction := entities.DetailPageInstruction{ID: detailPageInstructionID}
+
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, err
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, err
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, ErrDetailPageInstructionByIDCanNotBeFound
+	}
+
+	type DetailPageInstructionsInStorage struct {
+		DetailPageInstructions []entities.DetailPageInstruction `json:"detailPageInstructions"`
+	}
+
+	var foundedDetailPageInstructions DetailPageInstructionsInStorage
+
+	err = json.Unmarshal(response, &foundedDetailPageInstructions)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return detailPageInstruction, ErrDetailPageInstructionByIDCanNotBeFound
+	}
+
+	if len(foundedDetailPageInstructions.DetailPageInstructions) == 0 {
+		return detailPageInstruction, ErrDetailPageInstructionDoesNotExist
+	}
+
+	return foundedDetailPageInstructions.DetailPageInstructions[0], nil
+}
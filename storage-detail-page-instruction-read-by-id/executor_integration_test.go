@@ -0,0 +1,190 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_ReadDetailPageInstructionByID(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		detailPageInstructionIsActive: bool @index(bool) .
+		nameSelector: string @index(term) .
+		descriptionSelector: string @index(term) .
+		skuSelector: string @index(term) .
+		gtinSelector: string @index(term) .
+		priceSelector: string @index(term) .
+		oldPriceSelector: string @index(term) .
+		availabilitySelector: string @index(term) .
+		imageSelector: string @index(term) .
+		specificationSelector: string @index(term) .
+		specificationNameSelector: string @index(term) .
+		specificationValueSelector: string @index(term) .
+		cityParamPath: string @index(term) .
+		cityInCookieKey: string @index(term) .
+		cityIdForCookie: string @index(term) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	EmptyID := ""
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+	_, err = executor.ReadDetailPageInstructionByID(EmptyID)
+	if err != ErrDetailPageInstructionCanNotBeWithoutID {
+		t.Error(err)
+	}
+
+	FakeID := "0x12"
+	_, err = executor.ReadDetailPageInstructionByID(FakeID)
+	if err != ErrDetailPageInstructionDoesNotExist {
+		t.Error(err)
+	}
+
+	entityForCreate := entities.DetailPageInstruction{
+		NameSelector:               "h1.product-title",
+		ImageSelector:              ".product-gallery img",
+		SpecificationSelector:      ".specifications tr",
+		SpecificationValueSelector: "xpath:./td[2]",
+		IsActive:                   true}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	entityFoundedInStorage, err := executor.ReadDetailPageInstructionByID(createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if entityFoundedInStorage.ID != createdEntityID {
+		t.Fatalf("ID: %v of founded entity in storage is not ID: %v of created entity", entityFoundedInStorage.ID, createdEntityID)
+	}
+
+	if entityFoundedInStorage.NameSelector != entityForCreate.NameSelector ||
+		entityFoundedInStorage.SpecificationValueSelector != entityForCreate.SpecificationValueSelector {
+		t.Fatalf("Selectors of founded entity in storage are not selectors of created entity")
+	}
+
+	err = deactivateEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = executor.ReadDetailPageInstructionByID(createdEntityID)
+	if err != ErrDetailPageInstructionDoesNotExist {
+		t.Fatalf("Not active entity must not be founded in storage, error: %v", err)
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate entities.DetailPageInstruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", nil
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func deactivateEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deactivateEntityData, err := json.Marshal(map[string]interface{}{"uid": entityID, "detailPageInstructionIsActive": false})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		SetJson:   deactivateEntityData,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
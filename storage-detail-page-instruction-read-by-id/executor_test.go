@@ -0,0 +1,117 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Storage"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestDetailPageInstructionCanBeReadByID(t *testing.T) {
+	IDOfTestedDetailPageInstruction := "0x12"
+
+	store := &MockStore{}
+	executor := Executor{Store: store}
+
+	detailPageInstructionFromStore, err := executor.ReadDetailPageInstructionByID(IDOfTestedDetailPageInstruction)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if detailPageInstructionFromStore.ID != "0x12" {
+		t.Fatalf("Expected id of detail page instruction: '0x12', actual: %v", detailPageInstructionFromStore.ID)
+	}
+
+	if detailPageInstructionFromStore.NameSelector != "h1.product-title" ||
+		detailPageInstructionFromStore.ImageSelector != ".product-gallery img" {
+		t.Fatalf("Expected selectors of detail page instruction, actual: %v", detailPageInstructionFromStore)
+	}
+
+	if detailPageInstructionFromStore.SpecificationValueSelector != "xpath:./td[2]" {
+		t.Fatalf("Expected selector of value of specification, actual: %v", detailPageInstructionFromStore.SpecificationValueSelector)
+	}
+
+	if !detailPageInstructionFromStore.IsActive {
+		t.Fatalf("Expected active detail page instruction")
+	}
+
+	if !strings.Contains(store.Request, "eq(detailPageInstructionIsActive, true)") {
+		t.Fatalf("Expected filter of active detail page instruction in query, actual: %v", store.Request)
+	}
+}
+
+type MockStore struct {
+	storage.Store
+	Request string
+}
+
+func (store *MockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+
+	resp := `
+		{
+		   "detailPageInstructions":[
+			  {
+				"uid" : "0x12",
+				"nameSelector": "h1.product-title",
+				"descriptionSelector": ".product-description",
+				"skuSelector": ".product-code span",
+				"imageSelector": ".product-gallery img",
+				"specificationSelector": ".specifications tr",
+				"specificationNameSelector": "td:first-child",
+				"specificationValueSelector": "xpath:./td[2]",
+				"detailPageInstructionIsActive": true
+			  }
+		   ]
+		}
+	`
+
+	return []byte(resp), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestDetailPageInstructionCanBeReadByIDWithError(t *testing.T) {
+	IDOfTestedDetailPageInstruction := "0x12"
+
+	executor := Executor{Store: ErrorMockStore{}}
+	_, err := executor.ReadDetailPageInstructionByID(IDOfTestedDetailPageInstruction)
+	if err != ErrDetailPageInstructionByIDCanNotBeFound {
+		t.Fatalf(err.Error())
+	}
+}
+
+type ErrorMockStore struct {
+	storage.Store
+}
+
+func (store ErrorMockStore) Query(request string) (response []byte, err error) {
+	return []byte(""), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestDetailPageInstructionCanBeReadByIDAndItCanBeEmpty(t *testing.T) {
+	IDOfTestedDetailPageInstruction := "0x12"
+
+	executor := Executor{Store: EmptyMockStore{}}
+	_, err := executor.ReadDetailPageInstructionByID(IDOfTestedDetailPageInstruction)
+	if err != ErrDetailPageInstructionDoesNotExist {
+		t.Fatalf(err.Error())
+	}
+}
+
+type EmptyMockStore struct {
+	storage.Store
+}
+
+func (store EmptyMockStore) Query(request string) (response []byte, err error) {
+
+	resp := `
+		{
+		   "detailPageInstructions":[]
+		}
+	`
+
+	return []byte(resp), nil
+}
@@ -0,0 +1,162 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	IsIndexed(string) (bool, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// predicates of entity for list of inactive entities, entity without name predicate is listed by IDs.
+// NameInLanguages is true for name predicate with languages.
+type predicates struct {
+	IsActive, Name  string
+	NameInLanguages bool
+}
+
+// EntityPredicates are predicates of entities which are deactivated by delete functions
+var EntityPredicates = map[string]predicates{
+	"category":              {IsActive: "categoryIsActive", Name: "categoryName", NameInLanguages: true},
+	"company":               {IsActive: "companyIsActive", Name: "companyName", NameInLanguages: true},
+	"city":                  {IsActive: "cityIsActive", Name: "cityName", NameInLanguages: true},
+	"product":               {IsActive: "productIsActive", Name: "productName", NameInLanguages: true},
+	"price":                 {IsActive: "priceIsActive"},
+	"instruction":           {IsActive: "instructionIsActive"},
+	"pageInstruction":       {IsActive: "pageInstructionIsActive", Name: "path"},
+	"detailPageInstruction": {IsActive: "detailPageInstructionIsActive"}}
+
+// DefaultItemsPerPage is a count of inactive entities on page if ItemsPerPage is not set
+const DefaultItemsPerPage = 50
+
+var (
+	// ErrEntityIsUnknown means that entity is not one of EntityPredicates
+	ErrEntityIsUnknown = errors.New("entity is unknown")
+
+	// ErrIsActivePredicateIsNotIndexed means that inactive entities can't be found by is active predicate without index
+	ErrIsActivePredicateIsNotIndexed = errors.New("is active predicate is not indexed")
+
+	// ErrInactiveEntitiesCanNotBeFound means that the inactive entities can't be found in database
+	ErrInactiveEntitiesCanNotBeFound = errors.New("inactive entities can not be found")
+)
+
+// InactiveEntity is an entity which was deleted without HardDelete and can be restored
+type InactiveEntity struct {
+	ID   string `json:"uid"`
+	Name string `json:"name,omitempty"`
+}
+
+// InactiveEntities is a page of inactive entities of one type
+type InactiveEntities struct {
+	Entity                                string
+	CurrentPage, ItemsPerPage, TotalCount int
+	Entities                              []InactiveEntity
+}
+
+// ReadInactiveEntities return page of inactive entities with names in language,
+// name in other language is returned if entity has no name in language.
+// Is active predicate of entity must be indexed, like "categoryIsActive: bool @index(bool) ."
+func (executor *Executor) ReadInactiveEntities(entity, language string, currentPage, itemsPerPage int) (InactiveEntities, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+
+	if itemsPerPage < 1 {
+		itemsPerPage = DefaultItemsPerPage
+	}
+
+	inactiveEntities := InactiveEntities{
+		Entity:       entity,
+		CurrentPage:  currentPage,
+		ItemsPerPage: itemsPerPage,
+		Entities:     []InactiveEntity{}}
+
+	entityPredicates, ok := EntityPredicates[entity]
+	if !ok {
+		ExecutorLogger.Println(ErrEntityIsUnknown)
+		return inactiveEntities, ErrEntityIsUnknown
+	}
+
+	// Root function eq of query needs index of is active predicate
+	indexed, err := executor.Store.IsIndexed(entityPredicates.IsActive)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return inactiveEntities, ErrInactiveEntitiesCanNotBeFound
+	}
+
+	if !indexed {
+		ExecutorLogger.Printf("Predicate: %v is not indexed", entityPredicates.IsActive)
+		return inactiveEntities, ErrIsActivePredicateIsNotIndexed
+	}
+
+	variables := struct {
+		predicates
+		Language             string
+		ItemsPerPage, Offset int
+	}{
+		predicates:   entityPredicates,
+		Language:     language,
+		ItemsPerPage: itemsPerPage,
+		Offset:       currentPage*itemsPerPage - itemsPerPage}
+
+	queryTemplate, err := template.New("ReadInactiveEntities").Parse(`{
+				inactive as counters(func: eq({{.IsActive}}, false)) {
+					total: count(uid)
+				}
+
+				entities(func: uid(inactive), first: {{.ItemsPerPage}}, offset: {{.Offset}}) {
+					uid
+					{{if .NameInLanguages}}name: {{.Name}}@{{if .Language}}{{.Language}}:{{end}}.{{else if .Name}}name: {{.Name}}{{end}}
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return inactiveEntities, ErrInactiveEntitiesCanNotBeFound
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return inactiveEntities, ErrInactiveEntitiesCanNotBeFound
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return inactiveEntities, ErrInactiveEntitiesCanNotBeFound
+	}
+
+	var foundedEntities struct {
+		Counters []struct {
+			Total int `json:"total"`
+		} `json:"counters"`
+		Entities []InactiveEntity `json:"entities"`
+	}
+
+	err = json.Unmarshal(response, &foundedEntities)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return inactiveEntities, ErrInactiveEntitiesCanNotBeFound
+	}
+
+	if len(foundedEntities.Counters) > 0 {
+		inactiveEntities.TotalCount = foundedEntities.Counters[0].Total
+	}
+
+	inactiveEntities.Entities = append(inactiveEntities.Entities, foundedEntities.Entities...)
+
+	return inactiveEntities, nil
+}
@@ -0,0 +1,143 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_ReadInactiveEntities(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		companyName: string @lang @index(term) .
+		companyIsActive: bool @index(bool) .
+		priceIsActive: bool .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	executor := Executor{Store: &Store{Store: storage.Store{DatabaseGateway: DatabaseGateway}}}
+
+	_, err = executor.ReadInactiveEntities("price", "", 1, 10)
+	if err != ErrIsActivePredicateIsNotIndexed {
+		t.Fatalf("Inactive entities must not be read by predicate without index, error: %v", err)
+	}
+
+	CompanyName := "Test inactive company"
+
+	createdEntityID, err := createEntity(map[string]interface{}{
+		"companyName@en":  CompanyName,
+		"companyIsActive": false}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	inactiveEntities, err := executor.ReadInactiveEntities("company", "en", 1, 1000)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if inactiveEntities.TotalCount < 1 {
+		t.Fatalf("Expected total count of inactive entities, actual: %v", inactiveEntities.TotalCount)
+	}
+
+	var inactiveEntity InactiveEntity
+	for _, entity := range inactiveEntities.Entities {
+		if entity.ID == createdEntityID {
+			inactiveEntity = entity
+		}
+	}
+
+	if inactiveEntity.Name != CompanyName {
+		t.Fatalf("Expected created entity in inactive entities, actual: %v", inactiveEntities.Entities)
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate map[string]interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", nil
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
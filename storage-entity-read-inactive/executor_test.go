@@ -0,0 +1,111 @@
+package function
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestInactiveEntitiesCanBeRead(t *testing.T) {
+	store := &MockStore{}
+
+	executor := Executor{Store: store}
+
+	inactiveEntities, err := executor.ReadInactiveEntities("company", "en", 2, 10)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !strings.Contains(store.Request, "eq(companyIsActive, false)") ||
+		!strings.Contains(store.Request, "name: companyName@en:.") ||
+		!strings.Contains(store.Request, "first: 10, offset: 10") {
+		t.Errorf("Expected query of second page of inactive companies, actual: %v", store.Request)
+	}
+
+	if inactiveEntities.TotalCount != 12 || len(inactiveEntities.Entities) != 2 {
+		t.Fatalf("Expected 2 of 12 inactive companies, actual: %v", inactiveEntities)
+	}
+
+	if inactiveEntities.Entities[0].ID != "0x12" || inactiveEntities.Entities[0].Name != "Shop" {
+		t.Errorf("Expected inactive company, actual: %v", inactiveEntities.Entities[0])
+	}
+}
+
+type MockStore struct {
+	Request string
+}
+
+func (store *MockStore) IsIndexed(predicate string) (bool, error) {
+	return true, nil
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	store.Request = request
+
+	resp := `
+		{
+		   "counters":[{"total": 12}],
+		   "entities":[
+			  {"uid":"0x12", "name":"Shop"},
+			  {"uid":"0x13", "name":"Магазин"}
+		   ]
+		}
+	`
+
+	return []byte(resp), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestInactiveEntitiesWithoutNameCanBeRead(t *testing.T) {
+	store := &MockStore{}
+
+	executor := Executor{Store: store}
+
+	inactiveEntities, err := executor.ReadInactiveEntities("price", "", 0, 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if strings.Contains(store.Request, "name:") || !strings.Contains(store.Request, "first: 50, offset: 0") {
+		t.Errorf("Expected query of first page of prices without names, actual: %v", store.Request)
+	}
+
+	if inactiveEntities.CurrentPage != 1 || inactiveEntities.ItemsPerPage != DefaultItemsPerPage {
+		t.Errorf("Expected first page by default, actual: %v", inactiveEntities)
+	}
+
+	_, err = executor.ReadInactiveEntities("version", "", 1, 10)
+	if err != ErrEntityIsUnknown {
+		t.Errorf("Expected: %v, actual: %v", ErrEntityIsUnknown, err)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestInactiveEntitiesCanNotBeReadWithoutIndexOfIsActivePredicate(t *testing.T) {
+	store := &NotIndexedMockStore{}
+
+	executor := Executor{Store: store}
+
+	_, err := executor.ReadInactiveEntities("detailPageInstruction", "", 1, 10)
+	if err != ErrIsActivePredicateIsNotIndexed {
+		t.Fatalf("Expected: %v, actual: %v", ErrIsActivePredicateIsNotIndexed, err)
+	}
+
+	if store.Predicate != "detailPageInstructionIsActive" {
+		t.Errorf("Expected check of index of detailPageInstructionIsActive, actual: %v", store.Predicate)
+	}
+
+	if store.Request != "" {
+		t.Errorf("Expected inactive entities are not queried, actual: %v", store.Request)
+	}
+}
+
+type NotIndexedMockStore struct {
+	MockStore
+	Predicate string
+}
+
+func (store *NotIndexedMockStore) IsIndexed(predicate string) (bool, error) {
+	store.Predicate = predicate
+	return false, nil
+}
@@ -0,0 +1,74 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Storage"
+)
+
+// Request for page of inactive entities, DefaultItemsPerPage are returned if ItemsPerPage is not set
+type Request struct {
+	DatabaseGateway           string
+	Entity                    string
+	Language                  string
+	CurrentPage, ItemsPerPage int
+}
+
+type Response struct{ Message, Error, Data string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{Store: &Store{Store: storage.Store{DatabaseGateway: request.DatabaseGateway}}}
+	inactiveEntities, err := executor.ReadInactiveEntities(
+		request.Entity, request.Language, request.CurrentPage, request.ItemsPerPage)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"ReadInactiveEntities error: %v", err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedInactiveEntities, err := json.Marshal(inactiveEntities)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal inactive entities error: %v. Error: %v", inactiveEntities, err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedInactiveEntities)}
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
@@ -0,0 +1,44 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+)
+
+// Store is a storage.Store which can read indexes of predicates from schema of database
+type Store struct {
+	storage.Store
+}
+
+// IsIndexed return true if predicate has index in schema of database,
+// schema is not in JSON of response, so it can't be read by Query of storage.Store
+func (store *Store) IsIndexed(predicate string) (bool, error) {
+	connection, err := grpc.Dial(store.DatabaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return false, err
+	}
+
+	defer connection.Close()
+
+	databaseClient := dataBaseClient.NewDgraphClient(dataBaseAPI.NewDgraphClient(connection))
+
+	transaction := databaseClient.NewTxn()
+	defer transaction.Discard(context.Background())
+
+	response, err := transaction.Query(context.Background(), fmt.Sprintf(`schema(pred: [%v]) { index }`, predicate))
+	if err != nil {
+		return false, err
+	}
+
+	for _, node := range response.GetSchema() {
+		if node.GetIndex() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,121 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	CreateJSON([]byte) (string, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// IsActivePredicates are predicates which are false for entities deleted by delete functions
+var IsActivePredicates = map[string]string{
+	"category":              "categoryIsActive",
+	"company":               "companyIsActive",
+	"city":                  "cityIsActive",
+	"product":               "productIsActive",
+	"price":                 "priceIsActive",
+	"instruction":           "instructionIsActive",
+	"pageInstruction":       "pageInstructionIsActive",
+	"detailPageInstruction": "detailPageInstructionIsActive"}
+
+var (
+	// ErrEntityIsUnknown means that entity is not one of IsActivePredicates
+	ErrEntityIsUnknown = errors.New("entity is unknown")
+
+	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
+	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+
+	// ErrEntityDoesNotExist means than the entity with is active predicate does not exist in database
+	ErrEntityDoesNotExist = errors.New("entity does not exist")
+
+	// ErrEntityCanNotBeRestored means that the entity can't be activated in database
+	ErrEntityCanNotBeRestored = errors.New("entity can not be restored")
+)
+
+// RestoreEntity is a method for activate entity which was deleted without HardDelete
+func (executor *Executor) RestoreEntity(entity, entityID string) error {
+	predicate, ok := IsActivePredicates[entity]
+	if !ok {
+		ExecutorLogger.Println(ErrEntityIsUnknown)
+		return ErrEntityIsUnknown
+	}
+
+	if entityID == "" {
+		ExecutorLogger.Println(ErrEntityCanNotBeWithoutID)
+		return ErrEntityCanNotBeWithoutID
+	}
+
+	variables := struct {
+		EntityID  string
+		Predicate string
+	}{
+		EntityID:  entityID,
+		Predicate: predicate}
+
+	queryTemplate, err := template.New("ReadEntityWithIsActivePredicate").Parse(`{
+				entities(func: uid("{{.EntityID}}")) @filter(has({{.Predicate}})) {
+					uid
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrEntityCanNotBeRestored
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrEntityCanNotBeRestored
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrEntityCanNotBeRestored
+	}
+
+	var foundedEntities struct {
+		Entities []struct {
+			ID string `json:"uid"`
+		} `json:"entities"`
+	}
+
+	err = json.Unmarshal(response, &foundedEntities)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrEntityCanNotBeRestored
+	}
+
+	// Entity without is active predicate is hard deleted or is not an entity of this type
+	if len(foundedEntities.Entities) == 0 {
+		return ErrEntityDoesNotExist
+	}
+
+	activateEntityData, err := json.Marshal(map[string]interface{}{"uid": entityID, predicate: true})
+	if err != nil {
+		return err
+	}
+
+	// Set of predicate with uid of entity change existing entity
+	_, err = executor.Store.CreateJSON(activateEntityData)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrEntityCanNotBeRestored
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_RestoreEntity(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		detailPageInstructionIsActive: bool @index(bool) .
+		nameSelector: string @index(term) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	err = executor.RestoreEntity("detailPageInstruction", "")
+	if err != ErrEntityCanNotBeWithoutID {
+		t.Fatalf(err.Error())
+	}
+
+	FakeEntityID := "0x12"
+
+	err = executor.RestoreEntity("detailPageInstruction", FakeEntityID)
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Entity without is active predicate must not be restored, error: %v", err)
+	}
+
+	entityForCreate := entities.DetailPageInstruction{
+		NameSelector: ".product-title",
+		IsActive:     false}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	err = executor.RestoreEntity("detailPageInstruction", createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	entityFromStore, err := readEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !entityFromStore.IsActive || entityFromStore.NameSelector != ".product-title" {
+		t.Fatalf("Expected restored entity is active, actual: %v", entityFromStore)
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate entities.DetailPageInstruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", nil
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func readEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) (entities.DetailPageInstruction, error) {
+	query := fmt.Sprintf(`{
+				entities(func: uid("%v")) @filter(has(detailPageInstructionIsActive)) {
+					uid
+					nameSelector
+					detailPageInstructionIsActive
+				}
+			}`, entityID)
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return entities.DetailPageInstruction{}, err
+	}
+
+	var foundedEntities struct {
+		Entities []entities.DetailPageInstruction `json:"entities"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedEntities)
+	if err != nil {
+		return entities.DetailPageInstruction{}, err
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return entities.DetailPageInstruction{}, errors.New("entity by id not found")
+	}
+
+	return foundedEntities.Entities[0], nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package function
+
+import (
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeRestored(t *testing.T) {
+	store := &MockStore{Response: `{"entities": [{"uid": "0x12"}]}`}
+
+	executor := Executor{Store: store}
+
+	err := executor.RestoreEntity("product", "0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Created != `{"productIsActive":true,"uid":"0x12"}` {
+		t.Errorf("Expected product is activated, actual: %v", store.Created)
+	}
+}
+
+func TestDetailPageInstructionCanBeRestored(t *testing.T) {
+	store := &MockStore{Response: `{"entities": [{"uid": "0x12"}]}`}
+
+	executor := Executor{Store: store}
+
+	err := executor.RestoreEntity("detailPageInstruction", "0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Created != `{"detailPageInstructionIsActive":true,"uid":"0x12"}` {
+		t.Errorf("Expected detail page instruction is activated, actual: %v", store.Created)
+	}
+}
+
+type MockStore struct {
+	Response, Created string
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Response), nil
+}
+
+func (store *MockStore) CreateJSON(setJSON []byte) (string, error) {
+	store.Created = string(setJSON)
+	return "", nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanNotBeRestoredAfterHardDelete(t *testing.T) {
+	store := &MockStore{Response: `{"entities": []}`}
+
+	executor := Executor{Store: store}
+
+	err := executor.RestoreEntity("category", "0x12")
+	if err != ErrEntityDoesNotExist {
+		t.Errorf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+
+	err = executor.RestoreEntity("version", "0x12")
+	if err != ErrEntityIsUnknown {
+		t.Errorf("Expected: %v, actual: %v", ErrEntityIsUnknown, err)
+	}
+
+	if store.Created != "" {
+		t.Errorf("Expected entity is not activated, actual: %v", store.Created)
+	}
+}
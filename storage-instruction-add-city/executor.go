@@ -1,13 +1,14 @@
 package function
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
 	"os"
 )
 
 type Storage interface {
-	AddEntityToOtherEntity(string, string, string) error
+	CreateJSON([]byte) (string, error)
 }
 
 type Executor struct {
@@ -19,11 +20,41 @@ var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
 var (
 	// ErrCityCanNotBeAddedToInstruction means that the City can't be added to instruction
 	ErrCityCanNotBeAddedToInstruction = errors.New("city can not be added to instruction")
+
+	// ErrCityCanNotBeAddedWithoutCode means that the City can't be added to instruction without code of it for company
+	ErrCityCanNotBeAddedWithoutCode = errors.New("city can not be added without code")
 )
 
-// AddCityToInstruction method for set quad of predicate about City and Instruction
-func (executor *Executor) AddCityToInstruction(instructionID, cityID string) error {
-	err := executor.Store.AddEntityToOtherEntity(instructionID, "has_city", cityID)
+// cityOfInstruction is a has_city edge of instruction with code of city as facet of it
+type cityOfInstruction struct {
+	ID   string `json:"uid"`
+	Code string `json:"has_city|cityCode"`
+}
+
+// instructionWithCity is an instruction with has_city edge only for mutation
+type instructionWithCity struct {
+	ID     string              `json:"uid"`
+	Cities []cityOfInstruction `json:"has_city"`
+}
+
+// AddCityToInstruction method for set edge of City to Instruction with code of City for company of Instruction
+func (executor *Executor) AddCityToInstruction(instructionID, cityID, cityCode string) error {
+	if cityCode == "" {
+		ExecutorLogger.Printf("City with ID: %v can not be added to instruction with ID: %v without code", cityID, instructionID)
+		return ErrCityCanNotBeAddedWithoutCode
+	}
+
+	instruction := instructionWithCity{
+		ID:     instructionID,
+		Cities: []cityOfInstruction{{ID: cityID, Code: cityCode}}}
+
+	encodedInstruction, err := json.Marshal(instruction)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrCityCanNotBeAddedToInstruction
+	}
+
+	_, err = executor.Store.CreateJSON(encodedInstruction)
 	if err != nil {
 		ExecutorLogger.Printf("City with ID: %v can not be added to instruction with ID: %v", cityID, instructionID)
 		return ErrCityCanNotBeAddedToInstruction
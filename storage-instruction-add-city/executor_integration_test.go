@@ -7,6 +7,7 @@ import (
 	"fmt"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -108,7 +109,7 @@ func TestExecutor_AddCityToInstruction(t *testing.T) {
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
 
-	err = executor.AddCityToInstruction(createdEntityID, cityID)
+	err = executor.AddCityToInstruction(createdEntityID, cityID, "CityCZ_975")
 	if err != nil {
 		t.Fatalf("Name of City does not added")
 	}
@@ -121,6 +122,10 @@ func TestExecutor_AddCityToInstruction(t *testing.T) {
 	if len(entityFoundedInStorage.Cities) != 1 {
 		t.Fatalf("Expect 1 city with id: %v but got: %v count", cityID, entityFoundedInStorage.Cities)
 	}
+
+	if entityFoundedInStorage.Cities[0].Code != "CityCZ_975" {
+		t.Fatalf("Expect city with code: %v but got: %v", "CityCZ_975", entityFoundedInStorage.Cities[0])
+	}
 }
 
 func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
@@ -265,7 +270,7 @@ func readCityByID(entityID, language string, databaseClient *dataBaseClient.Dgra
 	return foundedCategories.Entities[0], nil
 }
 
-func readInstructionByID(entityID, language string, databaseClient *dataBaseClient.Dgraph) (entity storage.Instruction, err error) {
+func readInstructionByID(entityID, language string, databaseClient *dataBaseClient.Dgraph) (entity entities.Instruction, err error) {
 	variables := struct {
 		InstructionID string
 		Language      string
@@ -288,7 +293,7 @@ func readInstructionByID(entityID, language string, databaseClient *dataBaseClie
 						nameOfItemSelector
 						priceOfItemSelector
 					}
-					has_city @filter(eq(cityIsActive, true)) {
+					has_city @facets(cityCode) @filter(eq(cityIsActive, true)) {
 						uid
 						cityName: cityName@{{.Language}}
 						cityIsActive
@@ -306,7 +311,7 @@ func readInstructionByID(entityID, language string, databaseClient *dataBaseClie
 				}
 			}`)
 
-	entity = storage.Instruction{ID: entityID}
+	entity = entities.Instruction{Instruction: storage.Instruction{ID: entityID}}
 
 	if err != nil {
 		ExecutorLogger.Println(err)
@@ -326,7 +331,7 @@ func readInstructionByID(entityID, language string, databaseClient *dataBaseClie
 	}
 
 	type EntitiesInStorage struct {
-		Entities []storage.Instruction `json:"instructions"`
+		Entities []entities.Instruction `json:"instructions"`
 	}
 
 	var foundedEntities EntitiesInStorage
@@ -10,22 +10,29 @@ func TestCityCanBeAddedToInstruction(t *testing.T) {
 	CityTestID := "0x12"
 	InstructionTestID := "0x13"
 
-	executor := Executor{
-		Store: MockStorage{DatabaseGateway: ""}}
+	store := &MockStorage{DatabaseGateway: ""}
+	executor := Executor{Store: store}
 
-	err := executor.AddCityToInstruction(InstructionTestID, CityTestID)
+	err := executor.AddCityToInstruction(InstructionTestID, CityTestID, "CityCZ_975")
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
+
+	expected := `{"uid":"0x13","has_city":[{"uid":"0x12","has_city|cityCode":"CityCZ_975"}]}`
+	if store.CreatedJSON != expected {
+		t.Fatalf("Expected mutation: %v, actual: %v", expected, store.CreatedJSON)
+	}
 }
 
 /// Mock Storage
 type MockStorage struct {
 	DatabaseGateway string
+	CreatedJSON     string
 }
 
-func (store MockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
-	return nil
+func (store *MockStorage) CreateJSON(setJSON []byte) (string, error) {
+	store.CreatedJSON = string(setJSON)
+	return "", nil
 }
 
 // --------------------------------------------------------------------------------------------------------
@@ -37,7 +44,7 @@ func TestCityCanNotBeAddedToInstruction(t *testing.T) {
 	executor := Executor{
 		Store: AddCityToInstructionErrorMockStorage{DatabaseGateway: ""}}
 
-	err := executor.AddCityToInstruction(InstructionTestID, CityTestID)
+	err := executor.AddCityToInstruction(InstructionTestID, CityTestID, "CityCZ_975")
 	if err != ErrCityCanNotBeAddedToInstruction {
 		t.Fatalf(err.Error())
 	}
@@ -47,12 +54,25 @@ type AddCityToInstructionErrorMockStorage struct {
 	DatabaseGateway string
 }
 
-func (store AddCityToInstructionErrorMockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
-	var status error
+func (store AddCityToInstructionErrorMockStorage) CreateJSON(setJSON []byte) (string, error) {
+	return "", errors.New("")
+}
+
+// --------------------------------------------------------------------------------------------------------
 
-	if field == "has_city" {
-		status = errors.New("")
+func TestCityCanNotBeAddedToInstructionWithoutCode(t *testing.T) {
+	CityTestID := "0x12"
+	InstructionTestID := "0x13"
+
+	store := &MockStorage{DatabaseGateway: ""}
+	executor := Executor{Store: store}
+
+	err := executor.AddCityToInstruction(InstructionTestID, CityTestID, "")
+	if err != ErrCityCanNotBeAddedWithoutCode {
+		t.Fatalf("Expected error: %v, actual: %v", ErrCityCanNotBeAddedWithoutCode, err)
 	}
 
-	return status
+	if store.CreatedJSON != "" {
+		t.Fatalf("Expected no mutation, actual: %v", store.CreatedJSON)
+	}
 }
@@ -6,7 +6,7 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ DatabaseGateway, InstructionID, CityID string }
+type Request struct{ DatabaseGateway, InstructionID, CityID, CityCode string }
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -31,7 +31,7 @@ func Handle(req []byte) string {
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
 
-	err = executor.AddCityToInstruction(request.InstructionID, request.CityID)
+	err = executor.AddCityToInstruction(request.InstructionID, request.CityID, request.CityCode)
 	if err != nil {
 		warning := fmt.Sprintf(
 			"Add City to Instruction error: %v", err)
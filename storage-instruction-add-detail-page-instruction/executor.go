@@ -0,0 +1,33 @@
+package function
+
+import (
+	"errors"
+	"log"
+	"os"
+)
+
+type Storage interface {
+	AddEntityToOtherEntity(string, string, string) error
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrDetailPageInstructionCanNotBeAddedToInstruction means that the DetailPageInstruction can't be added to instruction
+	ErrDetailPageInstructionCanNotBeAddedToInstruction = errors.New("detail page instruction can not be added to instruction")
+)
+
+// AddDetailPageInstructionToInstruction method for set quad of predicate about DetailPageInstruction and Instruction
+func (executor *Executor) AddDetailPageInstructionToInstruction(instructionID, detailPageInstructionID string) error {
+	err := executor.Store.AddEntityToOtherEntity(instructionID, "has_detail_page", detailPageInstructionID)
+	if err != nil {
+		ExecutorLogger.Printf("DetailPageInstruction with ID: %v can not be added to instruction with ID: %v", detailPageInstructionID, instructionID)
+		return ErrDetailPageInstructionCanNotBeAddedToInstruction
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_AddDetailPageInstructionToInstruction(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		instructionLanguage: string @index(term) .
+		instructionIsActive: bool @index(bool) .
+		has_detail_page: uid @count .
+		detailPageInstructionIsActive: bool @index(bool) .
+		nameSelector: string @index(term) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	instructionID, err := createEntity(storage.Instruction{Language: "ru", IsActive: true}, databaseClient)
+	if err != nil || instructionID == "" {
+		t.Fatalf("Instruction does not create: %v", err)
+	}
+
+	defer func() {
+		err = deleteEntityByID(instructionID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	detailPageInstructionForCreate := entities.DetailPageInstruction{NameSelector: "h1.product-title", IsActive: true}
+
+	detailPageInstructionID, err := createEntity(detailPageInstructionForCreate, databaseClient)
+	if err != nil || detailPageInstructionID == "" {
+		t.Fatalf("DetailPageInstruction does not create: %v", err)
+	}
+
+	defer func() {
+		err = deleteEntityByID(detailPageInstructionID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	err = executor.AddDetailPageInstructionToInstruction(instructionID, detailPageInstructionID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	instructionFoundedInStorage, err := readInstructionByID(instructionID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(instructionFoundedInStorage.DetailPagesInstruction) != 1 {
+		t.Fatalf("Expect 1 detail page instruction with id: %v but got: %v", detailPageInstructionID, instructionFoundedInStorage.DetailPagesInstruction)
+	}
+
+	if instructionFoundedInStorage.DetailPagesInstruction[0].NameSelector != detailPageInstructionForCreate.NameSelector {
+		t.Fatalf("Expect detail page instruction with name selector: %v but got: %v",
+			detailPageInstructionForCreate.NameSelector, instructionFoundedInStorage.DetailPagesInstruction[0].NameSelector)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func readInstructionByID(entityID string, databaseClient *dataBaseClient.Dgraph) (entities.Instruction, error) {
+	query := `{
+				instructions(func: uid("` + entityID + `")) {
+					uid
+					has_detail_page @filter(eq(detailPageInstructionIsActive, true)) {
+						uid
+						nameSelector
+						detailPageInstructionIsActive
+					}
+				}
+			}`
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return entities.Instruction{}, err
+	}
+
+	var foundedEntities struct {
+		Entities []entities.Instruction `json:"instructions"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedEntities)
+	if err != nil {
+		return entities.Instruction{}, err
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return entities.Instruction{}, errors.New("entity does not exist")
+	}
+
+	return foundedEntities.Entities[0], nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
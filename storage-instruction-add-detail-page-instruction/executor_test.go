@@ -0,0 +1,59 @@
+package function
+
+import (
+	"errors"
+	"testing"
+)
+
+// ------------------------------------------------------------------------------------------------------
+func TestDetailPageInstructionCanBeAddedToInstruction(t *testing.T) {
+	DetailPageInstructionTestID := "0x12"
+	InstructionTestID := "0x13"
+
+	store := &MockStorage{DatabaseGateway: ""}
+
+	executor := Executor{Store: store}
+
+	err := executor.AddDetailPageInstructionToInstruction(InstructionTestID, DetailPageInstructionTestID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Field != "has_detail_page" {
+		t.Fatalf("Expect detail page instruction added by has_detail_page, but got: %v", store.Field)
+	}
+}
+
+/// Mock Storage
+type MockStorage struct {
+	DatabaseGateway string
+	Field           string
+}
+
+func (store *MockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
+	store.Field = field
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+
+func TestDetailPageInstructionCanNotBeAddedToInstruction(t *testing.T) {
+	DetailPageInstructionTestID := "0x12"
+	InstructionTestID := "0x13"
+
+	executor := Executor{
+		Store: ErrorMockStorage{DatabaseGateway: ""}}
+
+	err := executor.AddDetailPageInstructionToInstruction(InstructionTestID, DetailPageInstructionTestID)
+	if err != ErrDetailPageInstructionCanNotBeAddedToInstruction {
+		t.Fatalf(err.Error())
+	}
+}
+
+type ErrorMockStorage struct {
+	DatabaseGateway string
+}
+
+func (store ErrorMockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
+	return errors.New("")
+}
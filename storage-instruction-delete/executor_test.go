@@ -1,7 +1,6 @@
 package function
 
 import (
-	"github.com/hecatoncheir/Storage"
 	"testing"
 )
 
@@ -9,18 +8,59 @@ import (
 func TestEntityCanBeDeletedByID(t *testing.T) {
 	IDOfTestedEntity := "0x12"
 
-	executor := Executor{Store: MockStore{}}
+	store := &MockStore{}
+	executor := Executor{Store: store}
 
 	err := executor.DeleteEntityByID(IDOfTestedEntity)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
+
+	if store.Created != `{"instructionIsActive":false,"uid":"0x12"}` {
+		t.Errorf("Expected instruction is deactivated, actual: %v", store.Created)
+	}
+
+	if store.Deleted != "" {
+		t.Errorf("Expected instruction is not deleted, actual: %v", store.Deleted)
+	}
 }
 
 type MockStore struct {
-	storage.Store
+	Edges            string
+	Created, Deleted string
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Edges), nil
+}
+
+func (store *MockStore) CreateJSON(setJSON []byte) (string, error) {
+	store.Created = string(setJSON)
+	return "", nil
 }
 
-func (store MockStore) DeleteJSON(encodedJSON []byte) error {
+func (store *MockStore) DeleteJSON(deleteJSON []byte) error {
+	store.Deleted = string(deleteJSON)
 	return nil
 }
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestEntityCanBeHardDeletedByID(t *testing.T) {
+	store := &MockStore{}
+	executor := Executor{Store: store}
+
+	err := executor.HardDeleteEntityByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := `[{"has_category":null,"has_city":null,"has_company":null,"has_detail_page":null,"has_page":null,"uid":"0x12"}]`
+
+	if store.Deleted != expected {
+		t.Errorf("Expected deletion of instruction with edges of it: %v, actual: %v", expected, store.Deleted)
+	}
+
+	if store.Created != "" {
+		t.Errorf("Expected instruction is not deactivated, actual: %v", store.Created)
+	}
+}
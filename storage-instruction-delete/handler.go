@@ -6,7 +6,12 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ InstructionID, DatabaseGateway string }
+// Request for delete of instruction by ID, instruction is only deactivated if HardDelete is not set
+type Request struct {
+	InstructionID, DatabaseGateway string
+	HardDelete                     bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -31,7 +36,13 @@ func Handle(req []byte) string {
 	}
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
-	err = executor.DeleteEntityByID(request.InstructionID)
+
+	if request.HardDelete {
+		err = executor.HardDeleteEntityByID(request.InstructionID)
+	} else {
+		err = executor.DeleteEntityByID(request.InstructionID)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"DeleteEntityByID error: %v", err)
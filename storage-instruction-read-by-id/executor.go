@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"log"
 	"os"
@@ -31,12 +32,14 @@ var (
 	ErrInstructionDoesNotExist = errors.New("instruction does not exist")
 )
 
-// ReadInstructionByID is a method for get all nodes of instructions by ID
-func (executor *Executor) ReadInstructionByID(instructionID, language string) (storage.Instruction, error) {
+// ReadInstructionByID is a method for get all nodes of active instruction by ID, deleted instruction is not found,
+// active page instructions of it are returned with all selectors and pagination,
+// active detail page instructions of it are returned with all selectors too
+func (executor *Executor) ReadInstructionByID(instructionID, language string) (entities.Instruction, error) {
 
 	if instructionID == "" {
 		ExecutorLogger.Printf("Instruction can't be without ID")
-		return storage.Instruction{}, ErrInstructionCanNotBeWithoutID
+		return entities.Instruction{}, ErrInstructionCanNotBeWithoutID
 	}
 
 	variables := struct {
@@ -47,21 +50,51 @@ func (executor *Executor) ReadInstructionByID(instructionID, language string) (s
 		Language:      language}
 
 	queryTemplate, err := template.New("ReadInstructionByID").Parse(`{
-				instructions(func: uid("{{.InstructionID}}")) @filter(eq(instructionLanguage, {{.Language}})) {
+				instructions(func: uid("{{.InstructionID}}")) @filter(eq(instructionLanguage, {{.Language}}) and eq(instructionIsActive, true)) {
 					uid
 					instructionLanguage
 					instructionIsActive
-					has_page {
+					has_page @filter(not eq(pageInstructionIsActive, false)) {
 						uid
 						path
 						pageInPaginationSelector
 						pageParamPath
 						cityParamPath
+						cityInCookieKey
+						cityIdForCookie
 						itemSelector
 						nameOfItemSelector
+						linkOfItemSelector
+						previewImageOfSelector
 						priceOfItemSelector
+						oldPriceOfItemSelector
+						availabilityOfItemSelector
+						format
+						paginationStrategies
+						lastPageSelector
+						nextPageSelector
+						totalItemsSelector
+						itemsPerPage
 					}
-					has_city @filter(eq(cityIsActive, true)) {
+					has_detail_page @filter(not eq(detailPageInstructionIsActive, false)) {
+						uid
+						nameSelector
+						descriptionSelector
+						skuSelector
+						gtinSelector
+						priceSelector
+						oldPriceSelector
+						availabilitySelector
+						imageSelector
+						specificationSelector
+						specificationNameSelector
+						specificationValueSelector
+						cityParamPath
+						cityInCookieKey
+						cityIdForCookie
+						detailPageInstructionIsActive
+					}
+					has_city @facets(cityCode) @filter(eq(cityIsActive, true)) {
 						uid
 						cityName: cityName@{{.Language}}
 						cityIsActive
@@ -69,6 +102,7 @@ func (executor *Executor) ReadInstructionByID(instructionID, language string) (s
 					has_company @filter(eq(companyIsActive, true)) {
 						uid
 						companyName: companyName@{{.Language}}
+						companyIri
 						companyIsActive
 					}
 					has_category @filter(eq(categoryIsActive, true)) {
@@ -79,7 +113,7 @@ func (executor *Executor) ReadInstructionByID(instructionID, language string) (s
 				}
 			}`)
 
-	instruction := storage.Instruction{ID: instructionID}
+	instruction := entities.Instruction{Instruction: storage.Instruction{ID: instructionID}}
 
 	if err != nil {
 		ExecutorLogger.Println(err)
@@ -100,7 +134,7 @@ func (executor *Executor) ReadInstructionByID(instructionID, language string) (s
 	}
 
 	type InstructionsInStorage struct {
-		Instructions []storage.Instruction `json:"instructions"`
+		Instructions []entities.Instruction `json:"instructions"`
 	}
 
 	var foundedInstructions InstructionsInStorage
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"google.golang.org/grpc"
 	"testing"
@@ -22,10 +23,19 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 	schema := `
 		instructionLanguage: string @index(term) .
 		instructionIsActive: bool @index(bool) .
+		pageInstructionIsActive: bool @index(bool) .
 		has_company: uid @count .
 		has_city: uid @count .
+		cityIsActive: bool @index(bool) .
 		has_page: uid @count .
+		has_detail_page: uid @count .
+		detailPageInstructionIsActive: bool @index(bool) .
+		imageSelector: string @index(term) .
 		has_category: uid @count .
+		path: string @index(term) .
+		availabilityOfItemSelector: string @index(term) .
+		paginationStrategies: [string] .
+		nextPageSelector: string @index(term) .
 	`
 
 	err = setUpSchema(schema, databaseClient)
@@ -45,9 +55,21 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 		t.Error(err)
 	}
 
-	entityForCreate := storage.Instruction{
-		Language: Language,
-		IsActive: true}
+	entityForCreate := entities.Instruction{
+		Instruction: storage.Instruction{
+			Language: Language,
+			IsActive: true},
+		PagesInstruction: []entities.PageInstruction{{
+			PageInstruction:            storage.PageInstruction{Path: "smartfony-i-svyaz/smartfony-205"},
+			AvailabilityOfItemSelector: ".c-product-tile__availability",
+			PaginationStrategies:       []string{"nextPage"},
+			NextPageSelector:           ".c-pagination__next"}},
+		DetailPagesInstruction: []entities.DetailPageInstruction{
+			{ImageSelector: ".product-gallery img", IsActive: true},
+			{ImageSelector: ".old-gallery img", IsActive: false}},
+		Cities: []entities.City{{
+			City: storage.City{IsActive: true},
+			Code: "CityCZ_975"}}}
 
 	createdEntityID, err := createEntity(entityForCreate, databaseClient)
 	if err != nil {
@@ -67,6 +89,34 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 		t.Fatalf("ID: %v of founded entity in storage is not ID: %v of created entity", entityFoundedInStorage.ID, createdEntityID)
 	}
 
+	if len(entityFoundedInStorage.PagesInstruction) != 1 {
+		t.Fatalf("Expected 1 page instruction of founded entity in storage, actual: %v", len(entityFoundedInStorage.PagesInstruction))
+	}
+
+	if entityFoundedInStorage.PagesInstruction[0].AvailabilityOfItemSelector != ".c-product-tile__availability" ||
+		entityFoundedInStorage.PagesInstruction[0].NextPageSelector != ".c-pagination__next" {
+		t.Fatalf("Page instruction of founded entity in storage is not page instruction of created entity")
+	}
+
+	if len(entityFoundedInStorage.DetailPagesInstruction) != 1 ||
+		entityFoundedInStorage.DetailPagesInstruction[0].ImageSelector != ".product-gallery img" {
+		t.Fatalf("Expected only active detail page instruction of created entity, actual: %v", entityFoundedInStorage.DetailPagesInstruction)
+	}
+
+	if len(entityFoundedInStorage.Cities) != 1 || entityFoundedInStorage.Cities[0].Code != "CityCZ_975" {
+		t.Fatalf("Expected city with code of created entity, actual: %v", entityFoundedInStorage.Cities)
+	}
+
+	err = deactivateEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = executor.ReadInstructionByID(createdEntityID, Language)
+	if err != ErrInstructionDoesNotExist {
+		t.Fatalf("Not active entity must not be founded in storage, error: %v", err)
+	}
+
 	err = deleteEntityByID(createdEntityID, databaseClient)
 	if err != nil {
 		t.Fatalf(err.Error())
@@ -96,7 +146,7 @@ func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
 	return nil
 }
 
-func createEntity(entityForCreate storage.Instruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
+func createEntity(entityForCreate entities.Instruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
 	encodedEntity, err := json.Marshal(entityForCreate)
 	if err != nil {
 		return "", err
@@ -117,6 +167,26 @@ func createEntity(entityForCreate storage.Instruction, databaseClient *dataBaseC
 	return uid, nil
 }
 
+func deactivateEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deactivateEntityData, err := json.Marshal(map[string]interface{}{"uid": entityID, "instructionIsActive": false})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		SetJson:   deactivateEntityData,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
 	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
 	if err != nil {
@@ -2,6 +2,7 @@ package function
 
 import (
 	"github.com/hecatoncheir/Storage"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +20,59 @@ func TestInstructionCanBeReadByID(t *testing.T) {
 	if instructionFromStore.ID != "0x12" {
 		t.Fatalf("Expected id of instruction: '0x12', actual: %v", instructionFromStore.ID)
 	}
+
+	if len(instructionFromStore.PagesInstruction) != 1 {
+		t.Fatalf("Expected 1 page instruction of instruction, actual: %v", len(instructionFromStore.PagesInstruction))
+	}
+
+	pageInstruction := instructionFromStore.PagesInstruction[0]
+
+	if pageInstruction.OldPriceOfItemSelector != ".c-pdp-price__old" ||
+		pageInstruction.AvailabilityOfItemSelector != ".c-product-tile__availability" {
+		t.Fatalf("Expected selectors of old price and availability of page instruction, actual: %v", pageInstruction)
+	}
+
+	if len(pageInstruction.PaginationStrategies) != 1 || pageInstruction.NextPageSelector != ".c-pagination__next" {
+		t.Fatalf("Expected pagination of page instruction, actual: %v", pageInstruction)
+	}
+
+	if len(instructionFromStore.DetailPagesInstruction) != 1 ||
+		instructionFromStore.DetailPagesInstruction[0].ImageSelector != ".product-gallery img" {
+		t.Fatalf("Expected detail page instruction of instruction, actual: %v", instructionFromStore.DetailPagesInstruction)
+	}
+
+	if len(instructionFromStore.Cities) != 1 || instructionFromStore.Cities[0].Code != "CityCZ_975" {
+		t.Fatalf("Expected city with code of instruction, actual: %v", instructionFromStore.Cities)
+	}
+}
+
+func TestInstructionIsReadByIDOnlyIfItIsActive(t *testing.T) {
+	store := &QueryMockStore{}
+	executor := Executor{Store: store}
+
+	_, err := executor.ReadInstructionByID("0x12", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !strings.Contains(store.Request, "eq(instructionIsActive, true)") {
+		t.Fatalf("Expected filter of not active instruction in query, actual: %v", store.Request)
+	}
+
+	if !strings.Contains(store.Request, "has_page @filter(not eq(pageInstructionIsActive, false))") ||
+		!strings.Contains(store.Request, "has_detail_page @filter(not eq(detailPageInstructionIsActive, false))") {
+		t.Fatalf("Expected same filter of not active page instructions in query, actual: %v", store.Request)
+	}
+}
+
+type QueryMockStore struct {
+	MockStore
+	Request string
+}
+
+func (store *QueryMockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+	return store.MockStore.Query(request)
 }
 
 type MockStore struct {
@@ -34,8 +88,33 @@ func (store MockStore) Query(request string) (response []byte, err error) {
 				"uid" : "0x12",
 				"instructionLanguage": "ru",
 				"instructionIsActive": true,
-				"has_page": [],
-				"has_city": [],
+				"has_page": [
+				  {
+					"uid": "0x13",
+					"path": "smartfony-i-svyaz/smartfony-205",
+					"priceOfItemSelector": ".c-pdp-price__current",
+					"oldPriceOfItemSelector": ".c-pdp-price__old",
+					"availabilityOfItemSelector": ".c-product-tile__availability",
+					"paginationStrategies": ["nextPage"],
+					"nextPageSelector": ".c-pagination__next"
+				  }
+				],
+				"has_detail_page": [
+				  {
+					"uid": "0x15",
+					"nameSelector": "h1.product-title",
+					"imageSelector": ".product-gallery img",
+					"detailPageInstructionIsActive": true
+				  }
+				],
+				"has_city": [
+				  {
+					"uid": "0x14",
+					"cityName": "Москва",
+					"cityIsActive": true,
+					"has_city|cityCode": "CityCZ_975"
+				  }
+				],
 				"has_company": [],
 				"has_category": []
 			  }
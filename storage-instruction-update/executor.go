@@ -0,0 +1,373 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Storage"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	MutateJSON([]byte, []byte) error
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrInstructionCanNotBeWithoutID means that instruction can't be without id
+	ErrInstructionCanNotBeWithoutID = errors.New("instruction can not be without id")
+
+	// ErrInstructionDoesNotExist means than the instruction does not exist in database
+	ErrInstructionDoesNotExist = errors.New("instruction does not exist")
+
+	// ErrInstructionCanNotBeUpdated means that the instruction can't be updated in database
+	ErrInstructionCanNotBeUpdated = errors.New("instruction can not be updated")
+
+	// ErrPageInstructionDoesNotExist means than the page instruction of patch does not exist in database
+	ErrPageInstructionDoesNotExist = errors.New("page instruction does not exist")
+
+	// ErrVersionDoesNotExist means that the instruction has no version with number for rollback
+	ErrVersionDoesNotExist = errors.New("version does not exist")
+)
+
+// InstructionPatch is a partial update of instruction, only not nil fields are changed.
+// PageInstructionIDs replace all page instructions of instruction.
+type InstructionPatch struct {
+	ID                 string
+	IsActive           *bool
+	PageInstructionIDs []string
+}
+
+// UpdateInstruction apply patch to instruction after validation of selectors of new page instructions.
+// Replaced state is kept as version of instruction.
+func (executor *Executor) UpdateInstruction(patch InstructionPatch) (entities.Instruction, error) {
+	if patch.ID == "" {
+		ExecutorLogger.Println(ErrInstructionCanNotBeWithoutID)
+		return entities.Instruction{}, ErrInstructionCanNotBeWithoutID
+	}
+
+	current, _, err := executor.readInstruction(patch.ID)
+	if err != nil {
+		return current, err
+	}
+
+	next := entities.Instruction{
+		Instruction:      storage.Instruction{IsActive: current.IsActive},
+		PagesInstruction: current.PagesInstruction}
+
+	if patch.IsActive != nil {
+		next.IsActive = *patch.IsActive
+	}
+
+	if patch.PageInstructionIDs != nil {
+		next.PagesInstruction, err = executor.readPageInstructions(patch.PageInstructionIDs)
+		if err != nil {
+			return current, err
+		}
+
+		for _, pageInstruction := range next.PagesInstruction {
+			err = selectors.Validate(pageInstruction)
+			if err != nil {
+				ExecutorLogger.Printf("Page instruction: %v has error: %v", pageInstruction.ID, err)
+				return current, fmt.Errorf("%v of page instruction: %v", err, pageInstruction.ID)
+			}
+		}
+	}
+
+	return executor.replace(current, next)
+}
+
+// RollbackInstruction restore activity and page instructions of version with number,
+// current state is kept as new version, so rollback can be rolled back too
+func (executor *Executor) RollbackInstruction(instructionID string, versionNumber int) (entities.Instruction, error) {
+	if instructionID == "" {
+		ExecutorLogger.Println(ErrInstructionCanNotBeWithoutID)
+		return entities.Instruction{}, ErrInstructionCanNotBeWithoutID
+	}
+
+	current, versions, err := executor.readInstruction(instructionID)
+	if err != nil {
+		return current, err
+	}
+
+	for _, version := range versions {
+		if version.Number != versionNumber {
+			continue
+		}
+
+		previous := entities.Instruction{}
+
+		err = json.Unmarshal([]byte(version.Snapshot), &previous)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return current, ErrInstructionCanNotBeUpdated
+		}
+
+		return executor.replace(current, previous)
+	}
+
+	return current, ErrVersionDoesNotExist
+}
+
+// replace save current state as version and set next state to instruction in one transaction
+func (executor *Executor) replace(current, next entities.Instruction) (entities.Instruction, error) {
+	currentSnapshot, err := snapshotOf(current)
+	if err != nil {
+		return current, ErrInstructionCanNotBeUpdated
+	}
+
+	nextSnapshot, err := snapshotOf(next)
+	if err != nil {
+		return current, ErrInstructionCanNotBeUpdated
+	}
+
+	if currentSnapshot == nextSnapshot {
+		return current, nil
+	}
+
+	versioning := executor.versioning()
+
+	currentFields, err := versioning.FieldsOf(fieldsOf(current))
+	if err != nil {
+		return current, ErrInstructionCanNotBeUpdated
+	}
+
+	nextFields, err := versioning.FieldsOf(fieldsOf(next))
+	if err != nil {
+		return current, ErrInstructionCanNotBeUpdated
+	}
+
+	// Version, predicates and page instructions of next state of instruction
+	// and deletion of replaced page instructions are saved in one transaction
+	err = versioning.Replace(mutation.Replacement{
+		EntityID:  current.ID,
+		Version:   current.Version,
+		UpdatedAt: current.UpdatedAt,
+		Snapshot:  currentSnapshot,
+		Current:   currentFields,
+		Next:      nextFields})
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return current, ErrInstructionCanNotBeUpdated
+	}
+
+	updated, _, err := executor.readInstruction(current.ID)
+	if err != nil {
+		return current, err
+	}
+
+	return updated, nil
+}
+
+func (executor *Executor) versioning() *mutation.Versioning {
+	return &mutation.Versioning{
+		Store:              executor.Store,
+		VersionPredicate:   "instructionVersion",
+		UpdatedAtPredicate: "instructionUpdatedAt"}
+}
+
+// fieldsOf return predicates of instruction which are changed by update, page instructions are edges by IDs
+func fieldsOf(instruction entities.Instruction) map[string]interface{} {
+	fields := map[string]interface{}{"instructionIsActive": instruction.IsActive}
+
+	var pages []map[string]string
+	for _, pageInstruction := range instruction.PagesInstruction {
+		pages = append(pages, map[string]string{"uid": pageInstruction.ID})
+	}
+
+	if len(pages) > 0 {
+		fields["has_page"] = pages
+	}
+
+	return fields
+}
+
+// snapshotOf return JSON of activity and IDs with versions of page instructions of instruction
+func snapshotOf(instruction entities.Instruction) (string, error) {
+	snapshot := entities.Instruction{Instruction: storage.Instruction{IsActive: instruction.IsActive}}
+
+	for _, pageInstruction := range instruction.PagesInstruction {
+		version := pageInstruction.Version
+		if version == 0 {
+			version = 1
+		}
+
+		snapshot.PagesInstruction = append(snapshot.PagesInstruction,
+			entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstruction.ID}, Version: version})
+	}
+
+	encodedSnapshot, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encodedSnapshot), nil
+}
+
+// readInstruction return instruction by ID with versions of it
+func (executor *Executor) readInstruction(instructionID string) (entities.Instruction, []mutation.Version, error) {
+	instruction := entities.Instruction{Instruction: storage.Instruction{ID: instructionID}}
+
+	variables := struct {
+		InstructionID string
+	}{
+		InstructionID: instructionID}
+
+	queryTemplate, err := template.New("ReadInstructionWithVersions").Parse(`{
+				instructions(func: uid("{{.InstructionID}}")) @filter(has(instructionLanguage)) {
+					uid
+					instructionLanguage
+					instructionIsActive
+					instructionVersion
+					instructionUpdatedAt
+					has_page {
+						uid
+						pageInstructionVersion
+					}
+					has_version {
+						uid
+						versionNumber
+						versionActiveFrom
+						versionActiveUntil
+						versionSnapshot
+					}
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return instruction, nil, ErrInstructionCanNotBeUpdated
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return instruction, nil, ErrInstructionCanNotBeUpdated
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return instruction, nil, ErrInstructionCanNotBeUpdated
+	}
+
+	type instructionWithVersions struct {
+		entities.Instruction
+		Versions []mutation.Version `json:"has_version"`
+	}
+
+	var foundedInstructions struct {
+		Instructions []instructionWithVersions `json:"instructions"`
+	}
+
+	err = json.Unmarshal(response, &foundedInstructions)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return instruction, nil, ErrInstructionCanNotBeUpdated
+	}
+
+	if len(foundedInstructions.Instructions) == 0 {
+		return instruction, nil, ErrInstructionDoesNotExist
+	}
+
+	found := foundedInstructions.Instructions[0]
+
+	return found.Instruction, found.Versions, nil
+}
+
+// readPageInstructions return page instructions by IDs in order of IDs
+func (executor *Executor) readPageInstructions(pageInstructionIDs []string) ([]entities.PageInstruction, error) {
+	if len(pageInstructionIDs) == 0 {
+		return []entities.PageInstruction{}, nil
+	}
+
+	variables := struct {
+		PageInstructionIDs string
+	}{
+		PageInstructionIDs: strings.Join(pageInstructionIDs, ", ")}
+
+	queryTemplate, err := template.New("ReadPageInstructionsByIDs").Parse(`{
+				pageInstructions(func: uid({{.PageInstructionIDs}})) @filter(has(path)) {
+					uid
+					path
+					pageInPaginationSelector
+					pageParamPath
+					cityParamPath
+					cityInCookieKey
+					cityIdForCookie
+					itemSelector
+					nameOfItemSelector
+					linkOfItemSelector
+					previewImageOfSelector
+					priceOfItemSelector
+					oldPriceOfItemSelector
+					availabilityOfItemSelector
+					format
+					paginationStrategies
+					lastPageSelector
+					nextPageSelector
+					totalItemsSelector
+					itemsPerPage
+					pageInstructionVersion
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrInstructionCanNotBeUpdated
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrInstructionCanNotBeUpdated
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrInstructionCanNotBeUpdated
+	}
+
+	var foundedPageInstructions struct {
+		PageInstructions []entities.PageInstruction `json:"pageInstructions"`
+	}
+
+	err = json.Unmarshal(response, &foundedPageInstructions)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrInstructionCanNotBeUpdated
+	}
+
+	pageInstructionsByID := map[string]entities.PageInstruction{}
+	for _, pageInstruction := range foundedPageInstructions.PageInstructions {
+		pageInstructionsByID[pageInstruction.ID] = pageInstruction
+	}
+
+	var pageInstructions []entities.PageInstruction
+
+	for _, pageInstructionID := range pageInstructionIDs {
+		pageInstruction, ok := pageInstructionsByID[pageInstructionID]
+		if !ok {
+			ExecutorLogger.Printf("Page instruction with ID: %v does not exist", pageInstructionID)
+			return nil, fmt.Errorf("%v: %v", ErrPageInstructionDoesNotExist, pageInstructionID)
+		}
+
+		pageInstructions = append(pageInstructions, pageInstruction)
+	}
+
+	return pageInstructions, nil
+}
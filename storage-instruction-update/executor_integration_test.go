@@ -0,0 +1,178 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_UpdateInstruction(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		instructionLanguage: string @index(term) .
+		instructionIsActive: bool @index(bool) .
+		instructionVersion: int .
+		instructionUpdatedAt: dateTime .
+		has_page: uid @count .
+		path: string @index(term) .
+		itemSelector: string @index(term) .
+		pageInstructionVersion: int .
+		has_version: uid @count .
+		versionNumber: int .
+		versionActiveFrom: dateTime .
+		versionActiveUntil: dateTime .
+		versionSnapshot: string .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	firstPageID, err := createEntity(map[string]interface{}{"path": "catalog/", "itemSelector": ".item"}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	secondPageID, err := createEntity(map[string]interface{}{"path": "catalog/", "itemSelector": ".product"}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	createdEntityID, err := createEntity(map[string]interface{}{
+		"instructionLanguage": "ru",
+		"instructionIsActive": true,
+		"has_page":            []map[string]string{{"uid": firstPageID}}}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	defer func() {
+		for _, entityID := range []string{createdEntityID, firstPageID, secondPageID} {
+			err = deleteEntityByID(entityID, databaseClient)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+	}()
+
+	executor := Executor{Store: &mutation.Store{Store: storage.Store{DatabaseGateway: DatabaseGateway}}}
+
+	isActive := false
+
+	updated, err := executor.UpdateInstruction(InstructionPatch{
+		ID:                 createdEntityID,
+		IsActive:           &isActive,
+		PageInstructionIDs: []string{secondPageID}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updated.IsActive || len(updated.PagesInstruction) != 1 || updated.PagesInstruction[0].ID != secondPageID {
+		t.Fatalf("Instruction in storage is not patched instruction: %v", updated)
+	}
+
+	if updated.Version != 2 {
+		t.Fatalf("Expected second version of instruction, actual: %v", updated.Version)
+	}
+
+	rolledBack, err := executor.RollbackInstruction(createdEntityID, 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !rolledBack.IsActive || len(rolledBack.PagesInstruction) != 1 || rolledBack.PagesInstruction[0].ID != firstPageID {
+		t.Fatalf("Instruction in storage is not instruction of first version: %v", rolledBack)
+	}
+
+	_, versions, err := executor.readInstruction(createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions of instruction in storage, actual: %v", len(versions))
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate map[string]interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
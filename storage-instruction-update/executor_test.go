@@ -0,0 +1,192 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestInstructionCanBeUpdatedAndRolledBack(t *testing.T) {
+	store := NewMockStore()
+
+	executor := Executor{Store: store}
+
+	isActive := false
+
+	updated, err := executor.UpdateInstruction(InstructionPatch{
+		ID:                 "0x12",
+		IsActive:           &isActive,
+		PageInstructionIDs: []string{"0x22"}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updated.IsActive || len(updated.PagesInstruction) != 1 || updated.PagesInstruction[0].ID != "0x22" {
+		t.Errorf("Expected patched instruction, actual: %v", updated)
+	}
+
+	if updated.Version != 2 || updated.UpdatedAt == nil {
+		t.Errorf("Expected second version, actual: %v", updated.Version)
+	}
+
+	if len(store.Versions) != 1 || store.Versions[0].Number != 1 {
+		t.Fatalf("Expected first version is kept, actual: %v", store.Versions)
+	}
+
+	if !strings.Contains(store.Versions[0].Snapshot, `"uid":"0x21","pageInstructionVersion":1`) {
+		t.Errorf("Expected page instructions of first version, actual: %v", store.Versions[0].Snapshot)
+	}
+
+	rolledBack, err := executor.RollbackInstruction("0x12", 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !rolledBack.IsActive || len(rolledBack.PagesInstruction) != 1 || rolledBack.PagesInstruction[0].ID != "0x21" {
+		t.Errorf("Expected instruction of first version, actual: %v", rolledBack)
+	}
+
+	if rolledBack.Version != 3 || len(store.Versions) != 2 {
+		t.Errorf("Expected second version is kept, actual: %v", store.Versions)
+	}
+
+	if store.Mutations != 2 {
+		t.Errorf("Expected one mutation for every change, actual: %v", store.Mutations)
+	}
+
+	_, err = executor.RollbackInstruction("0x12", 5)
+	if err != ErrVersionDoesNotExist {
+		t.Errorf("Expected: %v, actual: %v", ErrVersionDoesNotExist, err)
+	}
+}
+
+// MockStore keep one instruction with versions and page instructions in memory
+type MockStore struct {
+	Instruction      map[string]interface{}
+	Pages            []string
+	PageInstructions map[string]entities.PageInstruction
+	Versions         []mutation.Version
+	Mutations        int
+}
+
+func NewMockStore() *MockStore {
+	return &MockStore{
+		Instruction: map[string]interface{}{
+			"uid": "0x12", "instructionLanguage": "ru", "instructionIsActive": true},
+		Pages: []string{"0x21"},
+		PageInstructions: map[string]entities.PageInstruction{
+			"0x21": {PageInstruction: storage.PageInstruction{ID: "0x21", Path: "catalog/", ItemSelector: ".item"}},
+			"0x22": {PageInstruction: storage.PageInstruction{ID: "0x22", Path: "catalog/", ItemSelector: ".product"}, Version: 3},
+			"0x23": {PageInstruction: storage.PageInstruction{ID: "0x23", Path: "catalog/", ItemSelector: "div[class="}}}}
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	if strings.Contains(request, "pageInstructions(") {
+		var pageInstructions []entities.PageInstruction
+		for id, pageInstruction := range store.PageInstructions {
+			if strings.Contains(request, id) {
+				pageInstructions = append(pageInstructions, pageInstruction)
+			}
+		}
+
+		return json.Marshal(map[string]interface{}{"pageInstructions": pageInstructions})
+	}
+
+	instruction := map[string]interface{}{}
+	for predicate, value := range store.Instruction {
+		instruction[predicate] = value
+	}
+
+	var pages []entities.PageInstruction
+	for _, id := range store.Pages {
+		pages = append(pages, store.PageInstructions[id])
+	}
+
+	instruction["has_page"] = pages
+	instruction["has_version"] = store.Versions
+
+	return json.Marshal(map[string]interface{}{"instructions": []interface{}{instruction}})
+}
+
+func (store *MockStore) MutateJSON(setJSON, deleteJSON []byte) error {
+	store.Mutations++
+
+	var instruction struct {
+		Pages    []entities.PageInstruction `json:"has_page"`
+		Versions []mutation.Version         `json:"has_version"`
+	}
+
+	deletion := map[string]interface{}{}
+
+	if deleteJSON != nil {
+		err := json.Unmarshal(deleteJSON, &deletion)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Deletion of predicate with null value delete all edges of predicate
+	if pages, ok := deletion["has_page"]; ok && pages == nil {
+		store.Pages = nil
+	}
+
+	err := json.Unmarshal(setJSON, &instruction)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range instruction.Pages {
+		store.Pages = append(store.Pages, page.ID)
+	}
+
+	for _, version := range instruction.Versions {
+		version.ID = fmt.Sprintf("0x%d", 100+len(store.Versions))
+		store.Versions = append(store.Versions, version)
+	}
+
+	fields := map[string]interface{}{}
+
+	err = json.Unmarshal(setJSON, &fields)
+	if err != nil {
+		return err
+	}
+
+	for predicate, value := range fields {
+		if predicate != "has_page" && predicate != "has_version" {
+			store.Instruction[predicate] = value
+		}
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestInstructionCanNotBeUpdatedWithInvalidPageInstructions(t *testing.T) {
+	store := NewMockStore()
+
+	executor := Executor{Store: store}
+
+	tests := []struct {
+		patch InstructionPatch
+		err   string
+	}{
+		{InstructionPatch{ID: "0x12", PageInstructionIDs: []string{"0x23"}}, "selector is not valid: itemSelector of page instruction: 0x23"},
+		{InstructionPatch{ID: "0x12", PageInstructionIDs: []string{"0x21", "0x99"}}, "page instruction does not exist: 0x99"},
+		{InstructionPatch{PageInstructionIDs: []string{"0x22"}}, ErrInstructionCanNotBeWithoutID.Error()}}
+
+	for _, test := range tests {
+		_, err := executor.UpdateInstruction(test.patch)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Expected: %v, actual: %v", test.err, err)
+		}
+	}
+
+	if len(store.Versions) != 0 || len(store.Pages) != 1 || store.Pages[0] != "0x21" {
+		t.Errorf("Expected instruction is not changed, actual: %v", store.Pages)
+	}
+}
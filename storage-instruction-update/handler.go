@@ -0,0 +1,95 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+)
+
+// Request for update of instruction by not nil fields of Instruction patch.
+// If RollbackToVersion is set, activity and page instructions of version with this number are restored
+// and only ID of Instruction is used.
+type Request struct {
+	DatabaseGateway   string
+	Instruction       InstructionPatch
+	RollbackToVersion int
+}
+
+type Response struct{ Message, Data, Error string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{
+		Store: &mutation.Store{Store: storage.Store{DatabaseGateway: request.DatabaseGateway}}}
+
+	var updatedInstruction entities.Instruction
+
+	if request.RollbackToVersion > 0 {
+		updatedInstruction, err = executor.RollbackInstruction(request.Instruction.ID, request.RollbackToVersion)
+	} else {
+		updatedInstruction, err = executor.UpdateInstruction(request.Instruction)
+	}
+
+	if err != nil {
+		warning := fmt.Sprintf(
+			"UpdateInstruction error: %v", err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedInstruction, err := json.Marshal(updatedInstruction)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal instruction error: %v. Error: %v", updatedInstruction, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedInstruction)}
+
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
@@ -3,7 +3,7 @@ package function
 import (
 	"encoding/json"
 	"errors"
-	"github.com/hecatoncheir/Storage"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"log"
 	"os"
 )
@@ -13,7 +13,7 @@ type Storage interface {
 }
 
 type Functions interface {
-	ReadPageInstructionByID(string, string) storage.PageInstruction
+	ReadPageInstructionByID(string, string) entities.PageInstruction
 }
 
 type Executor struct {
@@ -29,7 +29,8 @@ var (
 )
 
 // CreatePageInstruction make PageInstruction and save it to storage
-func (executor *Executor) CreatePageInstruction(pageInstruction storage.PageInstruction, language string) (storage.PageInstruction, error) {
+// with selectors of old price, availability and pagination
+func (executor *Executor) CreatePageInstruction(pageInstruction entities.PageInstruction, language string) (entities.PageInstruction, error) {
 
 	encodedPageInstruction, err := json.Marshal(pageInstruction)
 	if err != nil {
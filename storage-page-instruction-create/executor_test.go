@@ -1,14 +1,16 @@
 package function
 
 import (
+	"encoding/json"
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"testing"
 )
 
 // ------------------------------------------------------------------------------------------------------
 func TestPageInstructionCanBeCreated(t *testing.T) {
-	pageInstructionForCreate := storage.PageInstruction{Path: "http://"}
+	pageInstructionForCreate := entities.PageInstruction{PageInstruction: storage.PageInstruction{Path: "http://"}}
 
 	executor := Executor{
 		Functions: EmptyPageInstructionFAASFunctions{FunctionsGateway: ""},
@@ -33,8 +35,8 @@ type EmptyPageInstructionFAASFunctions struct {
 	FunctionsGateway string
 }
 
-func (functions EmptyPageInstructionFAASFunctions) ReadPageInstructionByID(pageInstructionID, language string) storage.PageInstruction {
-	return storage.PageInstruction{ID: pageInstructionID, Path: "http://"}
+func (functions EmptyPageInstructionFAASFunctions) ReadPageInstructionByID(pageInstructionID, language string) entities.PageInstruction {
+	return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID, Path: "http://"}}
 }
 
 /// Mock Storage
@@ -48,8 +50,58 @@ func (store MockStorage) CreateJSON(setJson []byte) (uid string, err error) {
 
 // --------------------------------------------------------------------------------------------------------
 
+func TestPageInstructionCanBeCreatedWithSelectorsOfAvailabilityAndPagination(t *testing.T) {
+	pageInstructionForCreate := entities.PageInstruction{
+		PageInstruction:            storage.PageInstruction{Path: "http://"},
+		OldPriceOfItemSelector:     ".c-pdp-price__old",
+		AvailabilityOfItemSelector: ".c-product-tile__availability",
+		PaginationStrategies:       []string{"lastPage"},
+		LastPageSelector:           ".c-pagination__num:last-child"}
+
+	store := &RecordMockStorage{}
+
+	executor := Executor{
+		Functions: EmptyPageInstructionFAASFunctions{FunctionsGateway: ""},
+		Store:     store}
+
+	_, err := executor.CreatePageInstruction(pageInstructionForCreate, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	var savedPageInstruction map[string]interface{}
+	err = json.Unmarshal(store.SetJSON, &savedPageInstruction)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for predicate, value := range map[string]string{
+		"oldPriceOfItemSelector":     ".c-pdp-price__old",
+		"availabilityOfItemSelector": ".c-product-tile__availability",
+		"lastPageSelector":           ".c-pagination__num:last-child"} {
+		if savedPageInstruction[predicate] != value {
+			t.Errorf("Expect %v: %v, but got: %v", predicate, value, savedPageInstruction[predicate])
+		}
+	}
+
+	if _, ok := savedPageInstruction["paginationStrategies"]; !ok {
+		t.Errorf("Expect paginationStrategies saved to storage")
+	}
+}
+
+type RecordMockStorage struct {
+	SetJSON []byte
+}
+
+func (store *RecordMockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	store.SetJSON = setJson
+	return "0x12", nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+
 func TestPageInstructionCanNotBeCreated(t *testing.T) {
-	PageInstructionForCreate := storage.PageInstruction{}
+	PageInstructionForCreate := entities.PageInstruction{}
 
 	executor := Executor{
 		Functions: EmptyPageInstructionFAASFunctions{FunctionsGateway: ""},
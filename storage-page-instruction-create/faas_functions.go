@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"io/ioutil"
 	"log"
@@ -18,7 +19,7 @@ type FAASFunctions struct {
 	DatabaseGateway  string
 }
 
-func (functions FAASFunctions) ReadPageInstructionByID(pageInstructionID, language string) storage.PageInstruction {
+func (functions FAASFunctions) ReadPageInstructionByID(pageInstructionID, language string) entities.PageInstruction {
 	functionPath := fmt.Sprintf(
 		"%v/%v", functions.FunctionsGateway, "storage-page-instruction-read-by-id")
 
@@ -34,13 +35,13 @@ func (functions FAASFunctions) ReadPageInstructionByID(pageInstructionID, langua
 	encodedBody, err := json.Marshal(body)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.PageInstruction{ID: pageInstructionID}
+		return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
 	}
 
 	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.PageInstruction{ID: pageInstructionID}
+		return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
 	}
 
 	defer response.Body.Close()
@@ -48,15 +49,22 @@ func (functions FAASFunctions) ReadPageInstructionByID(pageInstructionID, langua
 	decodedResponse, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.PageInstruction{ID: pageInstructionID}
+		return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
 	}
 
-	var existPageInstruction storage.PageInstruction
+	encodedResponse := Response{}
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
+	}
+
+	var existPageInstruction entities.PageInstruction
 
-	err = json.Unmarshal(decodedResponse, &existPageInstruction)
+	err = json.Unmarshal([]byte(encodedResponse.Data), &existPageInstruction)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.PageInstruction{ID: pageInstructionID}
+		return entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
 	}
 
 	return existPageInstruction
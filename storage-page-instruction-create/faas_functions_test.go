@@ -2,6 +2,7 @@ package function
 
 import (
 	"encoding/json"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"io"
 	"io/ioutil"
@@ -40,16 +41,23 @@ func TestFAASFunctions_ReadPageInstructionByID(t *testing.T) {
 				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
 		}
 
-		existedPageInstructionInStorage := storage.PageInstruction{
-			ID:   "0x12",
-			Path: "http://"}
+		existedPageInstructionInStorage := entities.PageInstruction{
+			PageInstruction: storage.PageInstruction{
+				ID:   "0x12",
+				Path: "http://"},
+			AvailabilityOfItemSelector: ".c-product-tile__availability"}
 
 		encodedExistedPageInstructionInStorage, err := json.Marshal(existedPageInstructionInStorage)
 		if err != nil {
 			t.Error(err.Error())
 		}
 
-		_, err = io.WriteString(w, string(encodedExistedPageInstructionInStorage))
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedExistedPageInstructionInStorage)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
 		if err != nil {
 			t.Error(err.Error())
 		}
@@ -64,4 +72,8 @@ func TestFAASFunctions_ReadPageInstructionByID(t *testing.T) {
 	if instruction.ID != "0x12" {
 		t.Fatalf("Expect page instruction id: %v, but got: %v", PageInstructionIDForTest, instruction.ID)
 	}
+
+	if instruction.AvailabilityOfItemSelector != ".c-product-tile__availability" {
+		t.Fatalf("Expect availability selector of page instruction, but got: %v", instruction.AvailabilityOfItemSelector)
+	}
 }
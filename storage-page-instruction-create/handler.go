@@ -3,6 +3,7 @@ package function
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 )
 
@@ -10,7 +11,7 @@ type Request struct {
 	Language,
 	DatabaseGateway,
 	FunctionsGateway string
-	PageInstruction storage.PageInstruction
+	PageInstruction entities.PageInstruction
 }
 
 type Response struct{ Message, Data, Error string }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
 	"github.com/hecatoncheir/Storage"
@@ -30,6 +31,7 @@ func TestExecutor_DeleteEntityByID(t *testing.T) {
 
 	schema := `
 		path: string @index(term) .
+		pageInstructionIsActive: bool @index(bool) .
 		pageInPaginationSelector: string @index(term) .
 		previewImageOfSelector: string @index(term) .
 		pageParamPath: string @index(term) .
@@ -83,7 +85,7 @@ func TestExecutor_DeleteEntityByID(t *testing.T) {
 		t.Fatalf("Founded entity id: %v not created entity id: %v", entityFromStore.ID, createdEntityID)
 	}
 
-	err = executor.DeleteEntityByID(createdEntityID)
+	err = executor.HardDeleteEntityByID(createdEntityID)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -104,6 +106,69 @@ func TestExecutor_DeleteEntityByID(t *testing.T) {
 	}
 }
 
+func TestExecutor_SoftDeleteEntityByID(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		path: string @index(term) .
+		pageInstructionIsActive: bool @index(bool) .
+	`
+
+	err = setUpCompanySchema(schema, databaseClient)
+
+	entityForCreate := storage.PageInstruction{
+		Path: "//"}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	_, err = readActiveEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+	err = executor.DeleteEntityByID(createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = readActiveEntityByID(createdEntityID, databaseClient)
+	if err == nil || err.Error() != "entity by id not found" {
+		t.Fatalf("Soft deleted entity must be hidden from reads of active entities, error: %v", err)
+	}
+
+	entityFromStore, err := readEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf("Soft deleted entity must be kept in storage, error: %v", err)
+	}
+
+	if entityFromStore.ID != createdEntityID {
+		t.Fatalf("Founded entity id: %v not soft deleted entity id: %v", entityFromStore.ID, createdEntityID)
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
 func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
 	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
 	if err != nil {
@@ -213,6 +278,38 @@ func readEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) (ent
 	return foundedEntities.Entities[0], nil
 }
 
+// readActiveEntityByID read page instruction by ID like storage-page-instruction-read-by-id,
+// page instruction which is not active is not found
+func readActiveEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) (storage.PageInstruction, error) {
+	query := fmt.Sprintf(`{
+				pageInstructions(func: uid("%v")) @filter(has(path) and not eq(pageInstructionIsActive, false)) {
+					uid
+					path
+				}
+			}`, entityID)
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return storage.PageInstruction{}, err
+	}
+
+	var foundedEntities struct {
+		Entities []storage.PageInstruction `json:"pageInstructions"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedEntities)
+	if err != nil {
+		return storage.PageInstruction{}, err
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return storage.PageInstruction{}, errors.New("entity by id not found")
+	}
+
+	return foundedEntities.Entities[0], nil
+}
+
 func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
 	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"log"
 	"os"
@@ -31,12 +32,14 @@ var (
 	ErrPageInstructionDoesNotExist = errors.New("page instruction does not exist")
 )
 
-// ReadPageInstructionByID is a method for get all nodes of instructions by ID
-func (executor *Executor) ReadPageInstructionByID(pageInstructionID string) (storage.PageInstruction, error) {
+// ReadPageInstructionByID is a method for get all nodes of instructions by ID,
+// with selectors of old price, availability and pagination of page instruction.
+// Deleted page instruction which is not active is not found
+func (executor *Executor) ReadPageInstructionByID(pageInstructionID string) (entities.PageInstruction, error) {
 
 	if pageInstructionID == "" {
 		ExecutorLogger.Printf("Page pageInstruction can't be without ID")
-		return storage.PageInstruction{}, ErrPageInstructionCanNotBeWithoutID
+		return entities.PageInstruction{}, ErrPageInstructionCanNotBeWithoutID
 	}
 
 	variables := struct {
@@ -45,19 +48,31 @@ func (executor *Executor) ReadPageInstructionByID(pageInstructionID string) (sto
 		PageInstructionID: pageInstructionID}
 
 	queryTemplate, err := template.New("ReadPageInstructionByID").Parse(`{
-				pageInstructions(func: uid("{{.PageInstructionID}}")) @filter(has(path)) {
+				pageInstructions(func: uid("{{.PageInstructionID}}")) @filter(has(path) and not eq(pageInstructionIsActive, false)) {
 					uid
 					path
 					pageInPaginationSelector
 					pageParamPath
 					cityParamPath
+					cityInCookieKey
+					cityIdForCookie
 					itemSelector
 					nameOfItemSelector
+					linkOfItemSelector
+					previewImageOfSelector
 					priceOfItemSelector
+					oldPriceOfItemSelector
+					availabilityOfItemSelector
+					format
+					paginationStrategies
+					lastPageSelector
+					nextPageSelector
+					totalItemsSelector
+					itemsPerPage
 				}
 			}`)
 
-	pageInstruction := storage.PageInstruction{ID: pageInstructionID}
+	pageInstruction := entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
 
 	if err != nil {
 		ExecutorLogger.Println(err)
@@ -78,7 +93,7 @@ func (executor *Executor) ReadPageInstructionByID(pageInstructionID string) (sto
 	}
 
 	type PageInstructionsInStorage struct {
-		PageInstructions []storage.PageInstruction `json:"pageInstructions"`
+		PageInstructions []entities.PageInstruction `json:"pageInstructions"`
 	}
 
 	var foundedPageInstructions PageInstructionsInStorage
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"google.golang.org/grpc"
 	"os"
@@ -26,6 +27,7 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 
 	schema := `
 		path: string @index(term) .
+		pageInstructionIsActive: bool @index(bool) .
 		pageInPaginationSelector: string @index(term) .
 		previewImageOfSelector: string @index(term) .
 		pageParamPath: string @index(term) .
@@ -35,6 +37,14 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 		priceOfItemSelector: string @index(term) .
 		cityInCookieKey: string @index(term) .
 		cityIdForCookie: string @index(term) .
+		oldPriceOfItemSelector: string @index(term) .
+		availabilityOfItemSelector: string @index(term) .
+		format: string @index(exact) .
+		paginationStrategies: [string] .
+		lastPageSelector: string @index(term) .
+		nextPageSelector: string @index(term) .
+		totalItemsSelector: string @index(term) .
+		itemsPerPage: int .
 	`
 
 	err = setUpSchema(schema, databaseClient)
@@ -53,7 +63,14 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 		t.Error(err)
 	}
 
-	entityForCreate := storage.PageInstruction{Path: "//"}
+	entityForCreate := entities.PageInstruction{
+		PageInstruction:            storage.PageInstruction{Path: "//"},
+		OldPriceOfItemSelector:     ".c-pdp-price__old",
+		AvailabilityOfItemSelector: ".c-product-tile__availability",
+		Format:                     entities.FormatHTML,
+		PaginationStrategies:       []string{"lastPage"},
+		LastPageSelector:           ".c-pagination__num:last-child",
+		ItemsPerPage:               24}
 
 	createdEntityID, err := createEntity(entityForCreate, databaseClient)
 	if err != nil {
@@ -73,6 +90,25 @@ func TestExecutor_ReadInstructionByID(t *testing.T) {
 		t.Fatalf("ID: %v of founded entity in storage is not ID: %v of created entity", entityFoundedInStorage.ID, createdEntityID)
 	}
 
+	if entityFoundedInStorage.OldPriceOfItemSelector != entityForCreate.OldPriceOfItemSelector ||
+		entityFoundedInStorage.AvailabilityOfItemSelector != entityForCreate.AvailabilityOfItemSelector {
+		t.Fatalf("Selectors of old price and availability of founded entity in storage are not selectors of created entity")
+	}
+
+	if len(entityFoundedInStorage.PaginationStrategies) != 1 || entityFoundedInStorage.ItemsPerPage != 24 {
+		t.Fatalf("Pagination of founded entity in storage is not pagination of created entity")
+	}
+
+	err = deactivateEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = executor.ReadPageInstructionByID(createdEntityID)
+	if err != ErrPageInstructionDoesNotExist {
+		t.Fatalf("Not active entity must not be founded in storage, error: %v", err)
+	}
+
 	err = deleteEntityByID(createdEntityID, databaseClient)
 	if err != nil {
 		t.Fatalf(err.Error())
@@ -102,7 +138,7 @@ func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
 	return nil
 }
 
-func createEntity(entityForCreate storage.PageInstruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
+func createEntity(entityForCreate entities.PageInstruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
 	encodedEntity, err := json.Marshal(entityForCreate)
 	if err != nil {
 		return "", err
@@ -123,6 +159,26 @@ func createEntity(entityForCreate storage.PageInstruction, databaseClient *dataB
 	return uid, nil
 }
 
+func deactivateEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deactivateEntityData, err := json.Marshal(map[string]interface{}{"uid": entityID, "pageInstructionIsActive": false})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		SetJson:   deactivateEntityData,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
 	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
 	if err != nil {
@@ -2,6 +2,7 @@ package function
 
 import (
 	"github.com/hecatoncheir/Storage"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +20,46 @@ func TestPageInstructionCanBeReadByID(t *testing.T) {
 	if pageInstructionFromStore.ID != "0x12" {
 		t.Fatalf("Expected id of page instruction: '0x12', actual: %v", pageInstructionFromStore.ID)
 	}
+
+	if pageInstructionFromStore.OldPriceOfItemSelector != ".c-product-tile__checkout-section .c-pdp-price__old" {
+		t.Fatalf("Expected old price selector of page instruction, actual: %v", pageInstructionFromStore.OldPriceOfItemSelector)
+	}
+
+	if pageInstructionFromStore.AvailabilityOfItemSelector != ".c-product-tile__checkout-section .c-product-tile__availability" {
+		t.Fatalf("Expected availability selector of page instruction, actual: %v", pageInstructionFromStore.AvailabilityOfItemSelector)
+	}
+
+	if len(pageInstructionFromStore.PaginationStrategies) != 2 || pageInstructionFromStore.LastPageSelector == "" {
+		t.Fatalf("Expected pagination of page instruction, actual: %v", pageInstructionFromStore.PaginationStrategies)
+	}
+
+	if pageInstructionFromStore.ItemsPerPage != 24 {
+		t.Fatalf("Expected 24 items per page of page instruction, actual: %v", pageInstructionFromStore.ItemsPerPage)
+	}
+}
+
+func TestPageInstructionIsReadByIDOnlyIfItIsActive(t *testing.T) {
+	store := &QueryMockStore{}
+	executor := Executor{Store: store}
+
+	_, err := executor.ReadPageInstructionByID("0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !strings.Contains(store.Request, "not eq(pageInstructionIsActive, false)") {
+		t.Fatalf("Expected filter of not active page instruction in query, actual: %v", store.Request)
+	}
+}
+
+type QueryMockStore struct {
+	MockStore
+	Request string
+}
+
+func (store *QueryMockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+	return store.MockStore.Query(request)
 }
 
 type MockStore struct {
@@ -37,7 +78,13 @@ func (store MockStore) Query(request string) (response []byte, err error) {
 				"itemSelector": ".c-product-tile",
 				"nameOfItemSelector": ".c-product-tile__description .sel-product-tile-title",
 				"linkOfItemSelector": ".c-product-tile__description .sel-product-tile-title",
-				"priceOfItemSelector": ".c-product-tile__checkout-section .c-pdp-price__current"
+				"priceOfItemSelector": ".c-product-tile__checkout-section .c-pdp-price__current",
+				"oldPriceOfItemSelector": ".c-product-tile__checkout-section .c-pdp-price__old",
+				"availabilityOfItemSelector": ".c-product-tile__checkout-section .c-product-tile__availability",
+				"format": "html",
+				"paginationStrategies": ["lastPage", "nextPage"],
+				"lastPageSelector": ".c-pagination__num:last-child",
+				"itemsPerPage": 24
 			  }
 		   ]
 		}
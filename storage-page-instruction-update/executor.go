@@ -0,0 +1,253 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Storage"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	MutateJSON([]byte, []byte) error
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrPageInstructionCanNotBeWithoutID means that page instruction can't be without id
+	ErrPageInstructionCanNotBeWithoutID = errors.New("page instruction can not be without id")
+
+	// ErrPageInstructionDoesNotExist means than the page instruction does not exist in database
+	ErrPageInstructionDoesNotExist = errors.New("page instruction does not exist")
+
+	// ErrPageInstructionCanNotBeUpdated means that the page instruction can't be updated in database
+	ErrPageInstructionCanNotBeUpdated = errors.New("page instruction can not be updated")
+
+	// ErrVersionDoesNotExist means that the page instruction has no version with number for rollback
+	ErrVersionDoesNotExist = errors.New("version does not exist")
+)
+
+// UpdatePageInstruction change not empty fields of patch in page instruction after validation of selectors.
+// Replaced selectors are kept as version of page instruction.
+func (executor *Executor) UpdatePageInstruction(patch entities.PageInstruction) (entities.PageInstruction, error) {
+	if patch.ID == "" {
+		ExecutorLogger.Println(ErrPageInstructionCanNotBeWithoutID)
+		return patch, ErrPageInstructionCanNotBeWithoutID
+	}
+
+	current, _, err := executor.readPageInstruction(patch.ID)
+	if err != nil {
+		return patch, err
+	}
+
+	patch.Version = 0
+	patch.UpdatedAt = nil
+
+	versioning := executor.versioning()
+
+	fields, err := versioning.FieldsOf(current)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	patchFields, err := versioning.FieldsOf(patch)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	for predicate, value := range patchFields {
+		fields[predicate] = value
+	}
+
+	updated := entities.PageInstruction{}
+
+	encodedFields, err := json.Marshal(fields)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	err = json.Unmarshal(encodedFields, &updated)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	err = selectors.Validate(updated)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return current, err
+	}
+
+	return executor.replace(current, updated)
+}
+
+// RollbackPageInstruction replace selectors of page instruction by selectors of version with number,
+// current selectors are kept as new version, so rollback can be rolled back too
+func (executor *Executor) RollbackPageInstruction(pageInstructionID string, versionNumber int) (entities.PageInstruction, error) {
+	if pageInstructionID == "" {
+		ExecutorLogger.Println(ErrPageInstructionCanNotBeWithoutID)
+		return entities.PageInstruction{}, ErrPageInstructionCanNotBeWithoutID
+	}
+
+	current, versions, err := executor.readPageInstruction(pageInstructionID)
+	if err != nil {
+		return current, err
+	}
+
+	for _, version := range versions {
+		if version.Number != versionNumber {
+			continue
+		}
+
+		previous := entities.PageInstruction{}
+
+		err = json.Unmarshal([]byte(version.Snapshot), &previous)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return current, ErrPageInstructionCanNotBeUpdated
+		}
+
+		return executor.replace(current, previous)
+	}
+
+	return current, ErrVersionDoesNotExist
+}
+
+// replace save current selectors as version and set next selectors to page instruction in one transaction.
+// Predicates which are set in current and empty in next are deleted.
+func (executor *Executor) replace(current, next entities.PageInstruction) (entities.PageInstruction, error) {
+	versioning := executor.versioning()
+
+	currentFields, err := versioning.FieldsOf(current)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	nextFields, err := versioning.FieldsOf(next)
+	if err != nil {
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	if mutation.IsSameFields(currentFields, nextFields) {
+		return current, nil
+	}
+
+	err = versioning.Replace(mutation.Replacement{
+		EntityID:  current.ID,
+		Version:   current.Version,
+		UpdatedAt: current.UpdatedAt,
+		Current:   currentFields,
+		Next:      nextFields})
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return current, ErrPageInstructionCanNotBeUpdated
+	}
+
+	updated, _, err := executor.readPageInstruction(current.ID)
+	if err != nil {
+		return next, err
+	}
+
+	return updated, nil
+}
+
+func (executor *Executor) versioning() *mutation.Versioning {
+	return &mutation.Versioning{
+		Store:              executor.Store,
+		VersionPredicate:   "pageInstructionVersion",
+		UpdatedAtPredicate: "pageInstructionUpdatedAt"}
+}
+
+// readPageInstruction return page instruction by ID with versions of it
+func (executor *Executor) readPageInstruction(pageInstructionID string) (entities.PageInstruction, []mutation.Version, error) {
+	pageInstruction := entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: pageInstructionID}}
+
+	variables := struct {
+		PageInstructionID string
+	}{
+		PageInstructionID: pageInstructionID}
+
+	queryTemplate, err := template.New("ReadPageInstructionWithVersions").Parse(`{
+				pageInstructions(func: uid("{{.PageInstructionID}}")) @filter(has(path)) {
+					uid
+					path
+					pageInPaginationSelector
+					pageParamPath
+					cityParamPath
+					cityInCookieKey
+					cityIdForCookie
+					itemSelector
+					nameOfItemSelector
+					linkOfItemSelector
+					previewImageOfSelector
+					priceOfItemSelector
+					oldPriceOfItemSelector
+					availabilityOfItemSelector
+					format
+					paginationStrategies
+					lastPageSelector
+					nextPageSelector
+					totalItemsSelector
+					itemsPerPage
+					pageInstructionVersion
+					pageInstructionUpdatedAt
+					has_version {
+						uid
+						versionNumber
+						versionActiveFrom
+						versionActiveUntil
+						versionSnapshot
+					}
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return pageInstruction, nil, ErrPageInstructionCanNotBeUpdated
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return pageInstruction, nil, ErrPageInstructionCanNotBeUpdated
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return pageInstruction, nil, ErrPageInstructionCanNotBeUpdated
+	}
+
+	type pageInstructionWithVersions struct {
+		entities.PageInstruction
+		Versions []mutation.Version `json:"has_version"`
+	}
+
+	var foundedPageInstructions struct {
+		PageInstructions []pageInstructionWithVersions `json:"pageInstructions"`
+	}
+
+	err = json.Unmarshal(response, &foundedPageInstructions)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return pageInstruction, nil, ErrPageInstructionCanNotBeUpdated
+	}
+
+	if len(foundedPageInstructions.PageInstructions) == 0 {
+		return pageInstruction, nil, ErrPageInstructionDoesNotExist
+	}
+
+	found := foundedPageInstructions.PageInstructions[0]
+
+	return found.PageInstruction, found.Versions, nil
+}
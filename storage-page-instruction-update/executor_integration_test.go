@@ -0,0 +1,175 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_UpdatePageInstruction(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		path: string @index(term) .
+		itemSelector: string @index(term) .
+		nameOfItemSelector: string @index(term) .
+		priceOfItemSelector: string @index(term) .
+		paginationStrategies: [string] .
+		nextPageSelector: string @index(term) .
+		totalItemsSelector: string @index(term) .
+		pageInstructionVersion: int .
+		pageInstructionUpdatedAt: dateTime .
+		has_version: uid @count .
+		versionNumber: int .
+		versionActiveFrom: dateTime .
+		versionActiveUntil: dateTime .
+		versionSnapshot: string .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	entityForCreate := entities.PageInstruction{
+		PageInstruction: storage.PageInstruction{
+			Path:               "catalog/",
+			ItemSelector:       ".item",
+			NameOfItemSelector: ".name"},
+		PaginationStrategies: []string{"max_number", "next_link"},
+		NextPageSelector:     "a.next"}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	defer func() {
+		err = deleteEntityByID(createdEntityID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	executor := Executor{Store: &mutation.Store{Store: storage.Store{DatabaseGateway: DatabaseGateway}}}
+
+	updated, err := executor.UpdatePageInstruction(entities.PageInstruction{
+		PageInstruction:      storage.PageInstruction{ID: createdEntityID, ItemSelector: ".product"},
+		PaginationStrategies: []string{"total_items"},
+		TotalItemsSelector:   ".total"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updated.ItemSelector != ".product" || updated.NameOfItemSelector != ".name" || updated.TotalItemsSelector != ".total" {
+		t.Fatalf("Page instruction in storage is not patched page instruction: %v", updated)
+	}
+
+	if len(updated.PaginationStrategies) != 1 || updated.PaginationStrategies[0] != "total_items" {
+		t.Fatalf("Expected strategies of patch only in storage, actual: %v", updated.PaginationStrategies)
+	}
+
+	if updated.Version != 2 {
+		t.Fatalf("Expected second version of page instruction, actual: %v", updated.Version)
+	}
+
+	rolledBack, err := executor.RollbackPageInstruction(createdEntityID, 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if rolledBack.ItemSelector != ".item" || rolledBack.TotalItemsSelector != "" || len(rolledBack.PaginationStrategies) != 2 {
+		t.Fatalf("Page instruction in storage is not page instruction of first version: %v", rolledBack)
+	}
+
+	_, versions, err := executor.readPageInstruction(createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions of page instruction in storage, actual: %v", len(versions))
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate entities.PageInstruction, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", nil
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Functions/shared/selectors"
+	"github.com/hecatoncheir/Storage"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestPageInstructionCanBeUpdatedAndRolledBack(t *testing.T) {
+	store := NewMockStore(map[string]interface{}{
+		"uid":                "0x12",
+		"path":               "catalog/",
+		"itemSelector":       ".item",
+		"nameOfItemSelector": ".name"})
+
+	executor := Executor{Store: store}
+
+	updated, err := executor.UpdatePageInstruction(entities.PageInstruction{PageInstruction: storage.PageInstruction{
+		ID:                  "0x12",
+		ItemSelector:        ".product",
+		PriceOfItemSelector: "xpath:.//span[@class='price']"}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updated.ItemSelector != ".product" || updated.NameOfItemSelector != ".name" || updated.Path != "catalog/" {
+		t.Errorf("Expected patched page instruction, actual: %v", updated)
+	}
+
+	if updated.Version != 2 || updated.UpdatedAt == nil {
+		t.Errorf("Expected second version, actual: %v", updated.Version)
+	}
+
+	if len(store.Versions) != 1 || store.Versions[0].Number != 1 || store.Versions[0].ActiveUntil == nil {
+		t.Fatalf("Expected first version is kept, actual: %v", store.Versions)
+	}
+
+	if !strings.Contains(store.Versions[0].Snapshot, `"itemSelector":".item"`) {
+		t.Errorf("Expected selectors of first version, actual: %v", store.Versions[0].Snapshot)
+	}
+
+	rolledBack, err := executor.RollbackPageInstruction("0x12", 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if rolledBack.ItemSelector != ".item" || rolledBack.PriceOfItemSelector != "" || rolledBack.Version != 3 {
+		t.Errorf("Expected selectors of first version, actual: %v", rolledBack)
+	}
+
+	if len(store.Versions) != 2 || store.Versions[1].Number != 2 {
+		t.Errorf("Expected second version is kept, actual: %v", store.Versions)
+	}
+
+	if store.Mutations != 2 {
+		t.Errorf("Expected one mutation for every change, actual: %v", store.Mutations)
+	}
+
+	_, err = executor.RollbackPageInstruction("0x12", 5)
+	if err != ErrVersionDoesNotExist {
+		t.Errorf("Expected: %v, actual: %v", ErrVersionDoesNotExist, err)
+	}
+}
+
+func TestPaginationStrategiesOfPageInstructionCanBeReplaced(t *testing.T) {
+	store := NewMockStore(map[string]interface{}{
+		"uid":                  "0x12",
+		"path":                 "catalog/",
+		"itemSelector":         ".item",
+		"paginationStrategies": []interface{}{"max_number", "next_link"},
+		"nextPageSelector":     "a.next"})
+
+	executor := Executor{Store: store}
+
+	updated, err := executor.UpdatePageInstruction(entities.PageInstruction{
+		PageInstruction:      storage.PageInstruction{ID: "0x12"},
+		PaginationStrategies: []string{"total_items"},
+		TotalItemsSelector:   ".total"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(updated.PaginationStrategies) != 1 || updated.PaginationStrategies[0] != "total_items" {
+		t.Errorf("Expected strategies of patch only, actual: %v", updated.PaginationStrategies)
+	}
+
+	if updated.NextPageSelector != "a.next" || updated.TotalItemsSelector != ".total" {
+		t.Errorf("Expected patched page instruction, actual: %v", updated)
+	}
+
+	if store.Mutations != 1 {
+		t.Errorf("Expected one mutation, actual: %v", store.Mutations)
+	}
+}
+
+// MockStore keep one page instruction with versions in memory
+type MockStore struct {
+	PageInstruction map[string]interface{}
+	Versions        []mutation.Version
+	Mutations       int
+}
+
+func NewMockStore(pageInstruction map[string]interface{}) *MockStore {
+	return &MockStore{PageInstruction: pageInstruction}
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	pageInstruction := map[string]interface{}{}
+	for predicate, value := range store.PageInstruction {
+		pageInstruction[predicate] = value
+	}
+
+	pageInstruction["has_version"] = store.Versions
+
+	return json.Marshal(map[string]interface{}{"pageInstructions": []interface{}{pageInstruction}})
+}
+
+func (store *MockStore) MutateJSON(setJSON, deleteJSON []byte) error {
+	store.Mutations++
+
+	if deleteJSON != nil {
+		deletion := map[string]interface{}{}
+
+		err := json.Unmarshal(deleteJSON, &deletion)
+		if err != nil {
+			return err
+		}
+
+		for predicate := range deletion {
+			if predicate != "uid" {
+				delete(store.PageInstruction, predicate)
+			}
+		}
+	}
+
+	fields := map[string]interface{}{}
+
+	err := json.Unmarshal(setJSON, &fields)
+	if err != nil {
+		return err
+	}
+
+	var pageInstructionWithVersion struct {
+		Versions []mutation.Version `json:"has_version"`
+	}
+
+	err = json.Unmarshal(setJSON, &pageInstructionWithVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range pageInstructionWithVersion.Versions {
+		version.ID = fmt.Sprintf("0x%d", 100+len(store.Versions))
+		store.Versions = append(store.Versions, version)
+	}
+
+	delete(fields, "has_version")
+
+	// Values of list are added to list like Dgraph does
+	for predicate, value := range fields {
+		list, isList := value.([]interface{})
+		currentList, isCurrentList := store.PageInstruction[predicate].([]interface{})
+		if isList && isCurrentList {
+			value = append(currentList, list...)
+		}
+
+		store.PageInstruction[predicate] = value
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestPageInstructionCanNotBeUpdatedWithInvalidSelectors(t *testing.T) {
+	store := NewMockStore(map[string]interface{}{"uid": "0x12", "path": "catalog/", "itemSelector": ".item"})
+
+	executor := Executor{Store: store}
+
+	tests := []struct {
+		patch entities.PageInstruction
+		err   string
+	}{
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x12", NameOfItemSelector: "div[class="}}, "selector is not valid: nameOfItemSelector"},
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x12", PriceOfItemSelector: "xpath://span[@class='price'"}}, "selector is not valid: priceOfItemSelector"},
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x12", ItemSelector: "$.products[", NameOfItemSelector: "name"}, Format: entities.FormatJSON}, "selector is not valid: itemSelector"},
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x12"}, Format: "xml"}, selectors.ErrFormatOfPageIsUnknown.Error()},
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ID: "0x12"}, PaginationStrategies: []string{"random"}}, "pagination strategy is unknown: random"},
+		{entities.PageInstruction{PageInstruction: storage.PageInstruction{ItemSelector: ".product"}}, ErrPageInstructionCanNotBeWithoutID.Error()}}
+
+	for _, test := range tests {
+		_, err := executor.UpdatePageInstruction(test.patch)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Expected: %v, actual: %v", test.err, err)
+		}
+	}
+
+	if len(store.Versions) != 0 || store.PageInstruction["itemSelector"] != ".item" {
+		t.Errorf("Expected page instruction is not changed, actual: %v", store.PageInstruction)
+	}
+}
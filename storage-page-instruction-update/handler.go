@@ -0,0 +1,95 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+)
+
+// Request for update of page instruction by not empty fields of PageInstruction.
+// If RollbackToVersion is set, selectors of version with this number are restored
+// and only ID of PageInstruction is used.
+type Request struct {
+	DatabaseGateway   string
+	PageInstruction   entities.PageInstruction
+	RollbackToVersion int
+}
+
+type Response struct{ Message, Data, Error string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{
+		Store: &mutation.Store{Store: storage.Store{DatabaseGateway: request.DatabaseGateway}}}
+
+	var updatedPageInstruction entities.PageInstruction
+
+	if request.RollbackToVersion > 0 {
+		updatedPageInstruction, err = executor.RollbackPageInstruction(request.PageInstruction.ID, request.RollbackToVersion)
+	} else {
+		updatedPageInstruction, err = executor.UpdatePageInstruction(request.PageInstruction)
+	}
+
+	if err != nil {
+		warning := fmt.Sprintf(
+			"UpdatePageInstruction error: %v", err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedPageInstruction, err := json.Marshal(updatedPageInstruction)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal page instruction error: %v. Error: %v", updatedPageInstruction, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedPageInstruction)}
+
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
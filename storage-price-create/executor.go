@@ -3,7 +3,7 @@ package function
 import (
 	"encoding/json"
 	"errors"
-	"github.com/hecatoncheir/Storage"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"log"
 	"os"
 )
@@ -13,7 +13,7 @@ type Storage interface {
 }
 
 type Functions interface {
-	ReadPriceByID(string, string) storage.Price
+	ReadPriceByID(string, string) entities.Price
 }
 
 type Executor struct {
@@ -29,7 +29,7 @@ var (
 )
 
 //// CreatePrice make price and save it to storage
-func (executor *Executor) CreatePrice(price storage.Price, language string) (storage.Price, error) {
+func (executor *Executor) CreatePrice(price entities.Price, language string) (entities.Price, error) {
 	price.IsActive = true
 
 	encodedProduct, err := json.Marshal(price)
@@ -2,13 +2,19 @@ package function
 
 import (
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
+	"strings"
 	"testing"
 )
 
 // ------------------------------------------------------------------------------------------------------
 func TestPriceCanBeCreated(t *testing.T) {
-	priceForCreate := storage.Price{ID: "0x12", Value: 0.0, IsActive: true}
+	priceForCreate := entities.Price{
+		Price:        storage.Price{ID: "0x12", Value: 0.0, IsActive: true},
+		Availability: "out_of_stock",
+		Currency:     "RUB",
+		OldValue:     100.0}
 
 	executor := Executor{
 		Functions: EmptyPriceFAASFunctions{FunctionsGateway: ""},
@@ -26,6 +32,10 @@ func TestPriceCanBeCreated(t *testing.T) {
 	if createdPrice.Value != 0.0 {
 		t.Errorf("Expect: %v, but got: %v", priceForCreate.Value, createdPrice.Value)
 	}
+
+	if createdPrice.Availability != "out_of_stock" {
+		t.Errorf("Expect: %v, but got: %v", priceForCreate.Availability, createdPrice.Availability)
+	}
 }
 
 /// Mock FAAS functions
@@ -33,8 +43,8 @@ type EmptyPriceFAASFunctions struct {
 	FunctionsGateway string
 }
 
-func (functions EmptyPriceFAASFunctions) ReadPriceByID(priceID, language string) storage.Price {
-	return storage.Price{ID: priceID, Value: 0.0}
+func (functions EmptyPriceFAASFunctions) ReadPriceByID(priceID, language string) entities.Price {
+	return entities.Price{Price: storage.Price{ID: priceID, Value: 0.0}, Availability: "out_of_stock"}
 }
 
 /// Mock Storage
@@ -43,13 +53,21 @@ type MockStorage struct {
 }
 
 func (store MockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	if !strings.Contains(string(setJson), `"priceAvailability":"out_of_stock"`) {
+		return "", errors.New("price without availability")
+	}
+
+	if !strings.Contains(string(setJson), `"priceCurrency":"RUB","priceOldValue":100`) {
+		return "", errors.New("price without currency and old value")
+	}
+
 	return "0x12", nil
 }
 
 // --------------------------------------------------------------------------------------------------------
 
 func TestPriceCanNotBeCreated(t *testing.T) {
-	priceForCreate := storage.Price{ID: "0x12", Value: 0.0, IsActive: true}
+	priceForCreate := entities.Price{Price: storage.Price{ID: "0x12", Value: 0.0, IsActive: true}}
 
 	executor := Executor{
 		Functions: EmptyPriceFAASFunctions{FunctionsGateway: ""},
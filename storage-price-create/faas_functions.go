@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/hecatoncheir/Storage"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -18,7 +18,7 @@ type FAASFunctions struct {
 	DatabaseGateway  string
 }
 
-func (functions FAASFunctions) ReadPriceByID(priceID, language string) storage.Price {
+func (functions FAASFunctions) ReadPriceByID(priceID, language string) entities.Price {
 	functionPath := fmt.Sprintf(
 		"%v/%v", functions.FunctionsGateway, "storage-price-read-by-id")
 
@@ -34,13 +34,13 @@ func (functions FAASFunctions) ReadPriceByID(priceID, language string) storage.P
 	encodedBody, err := json.Marshal(body)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.Price{}
+		return entities.Price{}
 	}
 
 	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.Price{}
+		return entities.Price{}
 	}
 
 	defer response.Body.Close()
@@ -48,15 +48,22 @@ func (functions FAASFunctions) ReadPriceByID(priceID, language string) storage.P
 	decodedResponse, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.Price{}
+		return entities.Price{}
 	}
 
-	var existPrice storage.Price
+	encodedResponse := Response{}
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.Price{}
+	}
+
+	var existPrice entities.Price
 
-	err = json.Unmarshal(decodedResponse, &existPrice)
+	err = json.Unmarshal([]byte(encodedResponse.Data), &existPrice)
 	if err != nil {
 		FAASLogger.Println(err)
-		return storage.Price{}
+		return entities.Price{}
 	}
 
 	return existPrice
@@ -2,6 +2,7 @@ package function
 
 import (
 	"encoding/json"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"io"
 	"io/ioutil"
@@ -42,18 +43,25 @@ func TestFAASFunctions_ReadPriceByID(t *testing.T) {
 				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
 		}
 
-		existedPriceInStorage := storage.Price{
-			ID:       "0x12",
-			Value:    0.1,
-			DateTime: time.Now().UTC(),
-			IsActive: true}
+		existedPriceInStorage := entities.Price{
+			Price: storage.Price{
+				ID:       "0x12",
+				Value:    0.1,
+				DateTime: time.Now().UTC(),
+				IsActive: true},
+			Availability: "in_stock"}
 
 		encodedExistedPriceInStorage, err := json.Marshal(existedPriceInStorage)
 		if err != nil {
 			t.Error(err.Error())
 		}
 
-		_, err = io.WriteString(w, string(encodedExistedPriceInStorage))
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedExistedPriceInStorage)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
 		if err != nil {
 			t.Error(err.Error())
 		}
@@ -72,4 +80,8 @@ func TestFAASFunctions_ReadPriceByID(t *testing.T) {
 	if price.Value != 0.1 {
 		t.Fatalf("Expect price valut is 0.1, but got: %v", price.Value)
 	}
+
+	if price.Availability != "in_stock" {
+		t.Fatalf("Expect price availability is in_stock, but got: %v", price.Availability)
+	}
 }
@@ -1,48 +1,40 @@
 package function
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"os"
+	"github.com/hecatoncheir/Functions/shared/mutation"
 )
 
-type Storage interface {
-	DeleteJSON([]byte) error
-}
-
 type Executor struct {
-	Store Storage
+	Store mutation.Storage
 }
 
-var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+// IsActivePredicate is a predicate which is false for deleted price
+const IsActivePredicate = "priceIsActive"
+
+// IncomingEdges are predicates of other entities with edges to price
+var IncomingEdges = []string{"has_price"}
 
 var (
 	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
-	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+	ErrEntityCanNotBeWithoutID = mutation.ErrEntityCanNotBeWithoutID
 
 	// ErrEntityByIDCanNotBeDeleted means that the entity can't be deleted from database
-	ErrEntityByIDCanNotBeDeleted = errors.New("entity by id can not be deleted")
+	ErrEntityByIDCanNotBeDeleted = mutation.ErrEntityByIDCanNotBeDeleted
 )
 
-// DeleteEntityByID is a method for delete city by ID
+// DeleteEntityByID is a method for soft delete of price by ID, price is kept in database as not active
 func (executor *Executor) DeleteEntityByID(entityID string) error {
+	return executor.deletion().DeleteEntityByID(entityID)
+}
 
-	if entityID == "" {
-		ExecutorLogger.Println(ErrEntityCanNotBeWithoutID)
-		return ErrEntityCanNotBeWithoutID
-	}
-
-	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
-	if err != nil {
-		return err
-	}
-
-	err = executor.Store.DeleteJSON(deleteEntityData)
-	if err != nil {
-		ExecutorLogger.Println(err)
-		return ErrEntityByIDCanNotBeDeleted
-	}
+// HardDeleteEntityByID is a method for delete price by ID with all edges of other entities to it
+func (executor *Executor) HardDeleteEntityByID(entityID string) error {
+	return executor.deletion().HardDeleteEntityByID(entityID)
+}
 
-	return nil
+func (executor *Executor) deletion() *mutation.Deletion {
+	return &mutation.Deletion{
+		Store:             executor.Store,
+		IsActivePredicate: IsActivePredicate,
+		IncomingEdges:     IncomingEdges}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"log"
 	"os"
@@ -32,8 +33,8 @@ var (
 )
 
 // ReadPriceByID is a method for get all nodes of categories by ID
-func (executor *Executor) ReadPriceByID(priceID, language string) (storage.Price, error) {
-	price := storage.Price{}
+func (executor *Executor) ReadPriceByID(priceID, language string) (entities.Price, error) {
+	price := entities.Price{}
 
 	if priceID == "" {
 		ExecutorLogger.Printf("Price can't be without ID")
@@ -53,6 +54,11 @@ func (executor *Executor) ReadPriceByID(priceID, language string) (storage.Price
 					priceValue
 					priceDateTime
 					priceCity
+					priceAvailability
+					priceCurrency
+					priceOldValue
+					priceDiscount
+					priceMaxValue
 					priceIsActive
 					belongs_to_product @filter(eq(productIsActive, true)) {
 						uid
@@ -80,7 +86,7 @@ func (executor *Executor) ReadPriceByID(priceID, language string) (storage.Price
 				}
 			}`)
 
-	price = storage.Price{ID: priceID}
+	price = entities.Price{Price: storage.Price{ID: priceID}}
 	if err != nil {
 		log.Println(err)
 		return price, ErrPriceByIDCanNotBeFound
@@ -100,7 +106,7 @@ func (executor *Executor) ReadPriceByID(priceID, language string) (storage.Price
 	}
 
 	type PricesInStore struct {
-		Prices []storage.Price `json:"prices"`
+		Prices []entities.Price `json:"prices"`
 	}
 
 	var foundedPrices PricesInStore
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"google.golang.org/grpc"
 	"os"
@@ -29,6 +30,11 @@ func TestExecutor_ReadPriceByID(t *testing.T) {
 		pricesValue: float @index(float) .
 		priceDateTime: dateTime @index(day) .
 		priceIsActive: bool @index(bool) .
+		priceAvailability: string @index(exact) .
+		priceCurrency: string .
+		priceOldValue: float .
+		priceDiscount: float .
+		priceMaxValue: float .
 		belongs_to_city: uid @count .
 		belongs_to_product: uid @count .
 		belongs_to_company: uid @count .
@@ -54,10 +60,19 @@ func TestExecutor_ReadPriceByID(t *testing.T) {
 	testPriceValue := 0.1
 	testPriceDateTime := time.Now().UTC()
 
-	entityForCreate := storage.Price{
-		Value:    testPriceValue,
-		DateTime: testPriceDateTime,
-		IsActive: true}
+	testPriceAvailability := "pre_order"
+	testPriceCurrency := "RUB"
+	testPriceOldValue := 0.2
+
+	entityForCreate := entities.Price{
+		Price: storage.Price{
+			Value:    testPriceValue,
+			DateTime: testPriceDateTime,
+			IsActive: true},
+		Availability: testPriceAvailability,
+		Currency:     testPriceCurrency,
+		OldValue:     testPriceOldValue,
+		Discount:     50}
 
 	createdEntityID, err := createEntity(entityForCreate, databaseClient)
 	if err != nil {
@@ -85,6 +100,14 @@ func TestExecutor_ReadPriceByID(t *testing.T) {
 		t.Fatalf("DateTime: %v of founded price in storage is not DateTime: %v of created price", entityFoundedInStorage.Value, testPriceValue)
 	}
 
+	if entityFoundedInStorage.Availability != testPriceAvailability {
+		t.Fatalf("Availability: %v of founded price in storage is not availability: %v of created price", entityFoundedInStorage.Availability, testPriceAvailability)
+	}
+
+	if entityFoundedInStorage.Currency != testPriceCurrency || entityFoundedInStorage.OldValue != testPriceOldValue {
+		t.Fatalf("Currency and old value of founded price in storage are not values of created price: %v", entityFoundedInStorage)
+	}
+
 	err = deleteEntityByID(createdEntityID, databaseClient)
 	if err != nil {
 		t.Fatalf(err.Error())
@@ -114,7 +137,7 @@ func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
 	return nil
 }
 
-func createEntity(entityForCreate storage.Price, databaseClient *dataBaseClient.Dgraph) (string, error) {
+func createEntity(entityForCreate entities.Price, databaseClient *dataBaseClient.Dgraph) (string, error) {
 	encodedEntity, err := json.Marshal(entityForCreate)
 	if err != nil {
 		return "", err
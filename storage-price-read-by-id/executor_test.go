@@ -23,6 +23,14 @@ func TestPriceCanBeReadByID(t *testing.T) {
 	if priceFromStore.Value != 0.0 {
 		t.Fatalf("Expected value of price: 0, actual: %v", priceFromStore.Value)
 	}
+
+	if priceFromStore.Availability != "in_stock" {
+		t.Fatalf("Expected availability of price: 'in_stock', actual: %v", priceFromStore.Availability)
+	}
+
+	if priceFromStore.Currency != "RUB" || priceFromStore.OldValue != 100.0 || priceFromStore.Discount != 100.0 {
+		t.Fatalf("Expected currency and discount of price, actual: %v", priceFromStore)
+	}
 }
 
 type MockStore struct {
@@ -38,6 +46,10 @@ func (store MockStore) Query(request string) (response []byte, err error) {
 				"uid": "0x12",
 				"priceValue": 0.0,
 				"priceDateTime" : "2017-05-01T16:27:18.543653798Z",
+				"priceAvailability": "in_stock",
+				"priceCurrency": "RUB",
+				"priceOldValue": 100.0,
+				"priceDiscount": 100.0,
 				"priceIsActive": true,
 				"belongs_to_city": [],
 				"belongs_to_product": [],
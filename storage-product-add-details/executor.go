@@ -0,0 +1,178 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	MutateJSON([]byte, []byte) error
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrProductCanNotBeWithoutID means that details can't be added to product without id
+	ErrProductCanNotBeWithoutID = errors.New("product can not be without id")
+
+	// ErrProductDetailsAreEmpty means that nothing of details can be added to product
+	ErrProductDetailsAreEmpty = errors.New("product details are empty")
+
+	// ErrProductDoesNotExist means than the active product does not exist in database
+	ErrProductDoesNotExist = errors.New("product does not exist")
+
+	// ErrProductDetailsCanNotBeAdded means that the details can't be added to product in database
+	ErrProductDetailsCanNotBeAdded = errors.New("product details can not be added")
+)
+
+// Specification of product from response of product-page-parser
+type Specification struct {
+	Name, Value string
+}
+
+// ProductDetails is a response of product-page-parser,
+// price of details is stored by storage-product-ingest and is not used here.
+type ProductDetails struct {
+	Description    string
+	SKU            string
+	GTIN           string
+	Images         []string
+	Specifications []Specification
+}
+
+// AddDetailsToProduct replace description, SKU, GTIN, images and specifications of active product
+// by not empty values of details in one transaction. Replaced specifications are deleted.
+func (executor *Executor) AddDetailsToProduct(productID string, details ProductDetails) error {
+	if productID == "" {
+		ExecutorLogger.Println(ErrProductCanNotBeWithoutID)
+		return ErrProductCanNotBeWithoutID
+	}
+
+	set := map[string]interface{}{"uid": productID}
+
+	for predicate, value := range map[string]string{
+		"productDescription": details.Description,
+		"productSku":         details.SKU,
+		"productGtin":        details.GTIN} {
+		if value != "" {
+			set[predicate] = value
+		}
+	}
+
+	if len(details.Images) > 0 {
+		set["productImages"] = details.Images
+	}
+
+	if len(details.Specifications) > 0 {
+		var specifications []entities.Specification
+		for _, specification := range details.Specifications {
+			specifications = append(specifications, entities.Specification{Name: specification.Name, Value: specification.Value})
+		}
+
+		set["has_specification"] = specifications
+	}
+
+	if len(set) == 1 {
+		ExecutorLogger.Println(ErrProductDetailsAreEmpty)
+		return ErrProductDetailsAreEmpty
+	}
+
+	product, err := executor.readProduct(productID)
+	if err != nil {
+		return err
+	}
+
+	// Values of list predicates are added to list by set, so replaced predicates are deleted before set
+	deletion := map[string]interface{}{"uid": productID}
+	deletions := []interface{}{deletion}
+
+	for predicate := range set {
+		if predicate != "uid" {
+			deletion[predicate] = nil
+		}
+	}
+
+	if _, ok := set["has_specification"]; ok {
+		for _, specification := range product.Specifications {
+			deletions = append(deletions, map[string]string{"uid": specification.ID})
+		}
+	}
+
+	encodedSet, err := json.Marshal(set)
+	if err != nil {
+		return ErrProductDetailsCanNotBeAdded
+	}
+
+	encodedDeletions, err := json.Marshal(deletions)
+	if err != nil {
+		return ErrProductDetailsCanNotBeAdded
+	}
+
+	err = executor.Store.MutateJSON(encodedSet, encodedDeletions)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return ErrProductDetailsCanNotBeAdded
+	}
+
+	return nil
+}
+
+// readProduct return active product by ID with uid of specifications of it
+func (executor *Executor) readProduct(productID string) (entities.Product, error) {
+	variables := struct {
+		ProductID string
+	}{
+		ProductID: productID}
+
+	queryTemplate, err := template.New("ReadProductSpecifications").Parse(`{
+				products(func: uid("{{.ProductID}}")) @filter(eq(productIsActive, true)) {
+					uid
+					has_specification {
+						uid
+					}
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return entities.Product{}, ErrProductDetailsCanNotBeAdded
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return entities.Product{}, ErrProductDetailsCanNotBeAdded
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return entities.Product{}, ErrProductDetailsCanNotBeAdded
+	}
+
+	var foundedProducts struct {
+		Products []entities.Product `json:"products"`
+	}
+
+	err = json.Unmarshal(response, &foundedProducts)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return entities.Product{}, ErrProductDetailsCanNotBeAdded
+	}
+
+	if len(foundedProducts.Products) == 0 {
+		return entities.Product{}, ErrProductDoesNotExist
+	}
+
+	return foundedProducts.Products[0], nil
+}
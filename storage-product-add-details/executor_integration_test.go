@@ -0,0 +1,256 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_AddDetailsToProduct(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productIsActive: bool @index(bool) .
+		productDescription: string .
+		productSku: string @index(exact) .
+		productGtin: string @index(exact) .
+		productImages: [string] .
+		has_specification: uid @count .
+		specificationName: string @index(term) .
+		specificationValue: string @index(term) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	productForCreate := entities.Product{
+		Product:        storage.Product{IsActive: true},
+		SKU:            "old-sku",
+		Images:         []string{"https://www.shop.ru/images/old.jpg"},
+		Specifications: []entities.Specification{{Name: "Color", Value: "white"}}}
+
+	productID, err := createEntity(productForCreate, databaseClient)
+	if err != nil || productID == "" {
+		t.Fatalf("Product does not create: %v", err)
+	}
+
+	defer func() {
+		err = deleteEntityByID(productID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	createdProduct, err := readProductByID(productID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(createdProduct.Specifications) != 1 {
+		t.Fatalf("Expected 1 specification of created product, actual: %v", createdProduct.Specifications)
+	}
+
+	oldSpecificationID := createdProduct.Specifications[0].ID
+
+	executor := Executor{Store: &mutation.Store{Store: storage.Store{DatabaseGateway: DatabaseGateway}}}
+
+	details := ProductDetails{
+		Description:    "Good phone.",
+		SKU:            "12345",
+		Images:         []string{"https://www.shop.ru/images/1.jpg", "https://www.shop.ru/images/2.jpg"},
+		Specifications: []Specification{{Name: "Color", Value: "black"}, {Name: "Weight", Value: "150 g"}}}
+
+	err = executor.AddDetailsToProduct(productID, details)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	productFoundedInStorage, err := readProductByID(productID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if productFoundedInStorage.Description != "Good phone." || productFoundedInStorage.SKU != "12345" {
+		t.Fatalf("Expected description and SKU of details, actual: %v", productFoundedInStorage)
+	}
+
+	if len(productFoundedInStorage.Images) != 2 {
+		t.Fatalf("Expected only images of details, actual: %v", productFoundedInStorage.Images)
+	}
+
+	if len(productFoundedInStorage.Specifications) != 2 {
+		t.Fatalf("Expected only specifications of details, actual: %v", productFoundedInStorage.Specifications)
+	}
+
+	for _, specification := range productFoundedInStorage.Specifications {
+		if specification.ID == oldSpecificationID || specification.Value == "white" {
+			t.Fatalf("Expected old specification is replaced, actual: %v", productFoundedInStorage.Specifications)
+		}
+
+		err = deleteEntityByID(specification.ID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	oldSpecification, err := readSpecificationByID(oldSpecificationID, databaseClient)
+	if err == nil {
+		t.Fatalf("Expected old specification is deleted, actual: %v", oldSpecification)
+	}
+
+	err = executor.AddDetailsToProduct("0x12", details)
+	if err != ErrProductDoesNotExist {
+		t.Fatalf("Expected error: %v, actual: %v", ErrProductDoesNotExist, err)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	databaseMutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), databaseMutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func readProductByID(entityID string, databaseClient *dataBaseClient.Dgraph) (entities.Product, error) {
+	query := `{
+				products(func: uid("` + entityID + `")) @filter(eq(productIsActive, true)) {
+					uid
+					productIsActive
+					productDescription
+					productSku
+					productGtin
+					productImages
+					has_specification {
+						uid
+						specificationName
+						specificationValue
+					}
+				}
+			}`
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return entities.Product{}, err
+	}
+
+	var foundedEntities struct {
+		Entities []entities.Product `json:"products"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedEntities)
+	if err != nil {
+		return entities.Product{}, err
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return entities.Product{}, errors.New("entity does not exist")
+	}
+
+	return foundedEntities.Entities[0], nil
+}
+
+func readSpecificationByID(entityID string, databaseClient *dataBaseClient.Dgraph) (entities.Specification, error) {
+	query := `{
+				specifications(func: uid("` + entityID + `")) @filter(has(specificationName)) {
+					uid
+					specificationName
+					specificationValue
+				}
+			}`
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return entities.Specification{}, err
+	}
+
+	var foundedEntities struct {
+		Entities []entities.Specification `json:"specifications"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedEntities)
+	if err != nil {
+		return entities.Specification{}, err
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return entities.Specification{}, errors.New("entity does not exist")
+	}
+
+	return foundedEntities.Entities[0], nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	databaseMutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &databaseMutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
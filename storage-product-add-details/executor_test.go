@@ -0,0 +1,162 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestDetailsCanBeAddedToProduct(t *testing.T) {
+	store := &MockStore{Products: `{"products":[{"uid":"0x12","has_specification":[{"uid":"0x20"}]}]}`}
+	executor := Executor{Store: store}
+
+	details := ProductDetails{
+		Description:    "Good phone.",
+		SKU:            "12345",
+		Images:         []string{"https://www.shop.ru/images/1.jpg", "https://www.shop.ru/images/2.jpg"},
+		Specifications: []Specification{{Name: "Color", Value: "black"}}}
+
+	err := executor.AddDetailsToProduct("0x12", details)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Mutations != 1 {
+		t.Fatalf("Expected details added to product in one mutation, actual: %v", store.Mutations)
+	}
+
+	var set struct {
+		ID             string   `json:"uid"`
+		Description    string   `json:"productDescription"`
+		SKU            string   `json:"productSku"`
+		GTIN           *string  `json:"productGtin"`
+		Images         []string `json:"productImages"`
+		Specifications []struct {
+			Name  string `json:"specificationName"`
+			Value string `json:"specificationValue"`
+		} `json:"has_specification"`
+	}
+
+	err = json.Unmarshal(store.SetJSON, &set)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if set.ID != "0x12" || set.Description != "Good phone." || set.SKU != "12345" || len(set.Images) != 2 {
+		t.Fatalf("Expected details set to product, actual: %v", string(store.SetJSON))
+	}
+
+	if set.GTIN != nil {
+		t.Fatalf("Expected empty GTIN is not set to product, actual: %v", string(store.SetJSON))
+	}
+
+	if len(set.Specifications) != 1 || set.Specifications[0].Name != "Color" || set.Specifications[0].Value != "black" {
+		t.Fatalf("Expected specifications set to product, actual: %v", set.Specifications)
+	}
+
+	var deletions []map[string]interface{}
+
+	err = json.Unmarshal(store.DeleteJSON, &deletions)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(deletions) != 2 || deletions[0]["uid"] != "0x12" || deletions[1]["uid"] != "0x20" {
+		t.Fatalf("Expected replaced predicates of product and old specification deleted, actual: %v", string(store.DeleteJSON))
+	}
+
+	for _, predicate := range []string{"productDescription", "productSku", "productImages", "has_specification"} {
+		value, ok := deletions[0][predicate]
+		if !ok || value != nil {
+			t.Errorf("Expected all values of %v deleted, actual: %v", predicate, string(store.DeleteJSON))
+		}
+	}
+
+	if _, ok := deletions[0]["productGtin"]; ok {
+		t.Errorf("Expected GTIN of product is not deleted, actual: %v", string(store.DeleteJSON))
+	}
+}
+
+type MockStore struct {
+	Products   string
+	SetJSON    []byte
+	DeleteJSON []byte
+	Mutations  int
+}
+
+func (store *MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Products), nil
+}
+
+func (store *MockStore) MutateJSON(setJSON, deleteJSON []byte) error {
+	store.Mutations++
+	store.SetJSON = setJSON
+	store.DeleteJSON = deleteJSON
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestSpecificationsOfProductAreNotDeletedWithoutNewSpecifications(t *testing.T) {
+	store := &MockStore{Products: `{"products":[{"uid":"0x12","has_specification":[{"uid":"0x20"}]}]}`}
+	executor := Executor{Store: store}
+
+	err := executor.AddDetailsToProduct("0x12", ProductDetails{GTIN: "4600000000011"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	var deletions []map[string]interface{}
+
+	err = json.Unmarshal(store.DeleteJSON, &deletions)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(deletions) != 1 {
+		t.Fatalf("Expected specifications of product are kept, actual deletions: %v", string(store.DeleteJSON))
+	}
+
+	if _, ok := deletions[0]["has_specification"]; ok {
+		t.Fatalf("Expected specifications of product are kept, actual deletions: %v", string(store.DeleteJSON))
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestDetailsCanNotBeAddedToProduct(t *testing.T) {
+	executor := Executor{Store: &MockStore{Products: `{"products":[]}`}}
+
+	err := executor.AddDetailsToProduct("", ProductDetails{SKU: "12345"})
+	if err != ErrProductCanNotBeWithoutID {
+		t.Fatalf("Expected error: %v, actual: %v", ErrProductCanNotBeWithoutID, err)
+	}
+
+	err = executor.AddDetailsToProduct("0x12", ProductDetails{})
+	if err != ErrProductDetailsAreEmpty {
+		t.Fatalf("Expected error: %v, actual: %v", ErrProductDetailsAreEmpty, err)
+	}
+
+	err = executor.AddDetailsToProduct("0x12", ProductDetails{SKU: "12345"})
+	if err != ErrProductDoesNotExist {
+		t.Fatalf("Expected error: %v, actual: %v", ErrProductDoesNotExist, err)
+	}
+
+	executor = Executor{Store: ErrorMockStore{}}
+
+	err = executor.AddDetailsToProduct("0x12", ProductDetails{SKU: "12345"})
+	if err != ErrProductDetailsCanNotBeAdded {
+		t.Fatalf("Expected error: %v, actual: %v", ErrProductDetailsCanNotBeAdded, err)
+	}
+}
+
+type ErrorMockStore struct{}
+
+func (store ErrorMockStore) Query(request string) ([]byte, error) {
+	return []byte(`{"products":[{"uid":"0x12"}]}`), nil
+}
+
+func (store ErrorMockStore) MutateJSON(setJSON, deleteJSON []byte) error {
+	return errors.New("")
+}
@@ -97,7 +97,7 @@ func TestExecutor_DeleteEntityByID(t *testing.T) {
 		t.Fatalf("Founded entity id: %v not created entity id: %v", entityFromStore.ID, createdEntityID)
 	}
 
-	err = executor.DeleteEntityByID(createdEntityID)
+	err = executor.HardDeleteEntityByID(createdEntityID)
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
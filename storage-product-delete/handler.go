@@ -6,7 +6,12 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ PriceID, DatabaseGateway string }
+// Request for delete of product by ID, product is only deactivated if HardDelete is not set
+type Request struct {
+	ProductID, DatabaseGateway string
+	HardDelete                 bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -31,7 +36,13 @@ func Handle(req []byte) string {
 	}
 
 	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
-	err = executor.DeleteEntityByID(request.PriceID)
+
+	if request.HardDelete {
+		err = executor.HardDeleteEntityByID(request.ProductID)
+	} else {
+		err = executor.DeleteEntityByID(request.ProductID)
+	}
+
 	if err != nil {
 		warning := fmt.Sprintf(
 			"DeleteEntityByID error: %v", err)
@@ -0,0 +1,268 @@
+package function
+
+import (
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"log"
+	"os"
+	"time"
+)
+
+type Storage interface {
+	AddEntityToOtherEntity(string, string, string) error
+}
+
+type Functions interface {
+	ReadProductByIRI(string, string) (storage.Product, error)
+	ReadProductsByName(string, string) ([]storage.Product, error)
+	CreateProduct(storage.Product, string) (storage.Product, error)
+	CreatePrice(entities.Price, string) (entities.Price, error)
+}
+
+type Executor struct {
+	Store     Storage
+	Functions Functions
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+const (
+	// StatusCreated means that the product is created with price
+	StatusCreated = "created"
+
+	// StatusUpdated means that the product is in the database already and new price is added to it
+	StatusUpdated = "updated"
+
+	// StatusFailed means that the product or price of it can't be saved
+	StatusFailed = "failed"
+)
+
+var (
+	// ErrCompanyCanNotBeWithoutID means that products can't be ingested without company
+	ErrCompanyCanNotBeWithoutID = errors.New("company can not be without id")
+
+	// ErrCategoryCanNotBeWithoutID means that products can't be ingested without category
+	ErrCategoryCanNotBeWithoutID = errors.New("category can not be without id")
+
+	// ErrProductCanNotBeWithoutName means that product can't be created without name
+	ErrProductCanNotBeWithoutName = errors.New("product can not be without name")
+
+	// ErrProductCanNotBeWithoutIRI means that product can't be found or created without IRI
+	ErrProductCanNotBeWithoutIRI = errors.New("product can not be without iri")
+
+	// ErrProductDoesNotExist means that storage-product-read-by-iri function found no product with IRI
+	ErrProductDoesNotExist = errors.New("product does not exist")
+
+	// ErrProductsByNameNotFound means that storage-product-read-by-name function found no product with the name
+	ErrProductsByNameNotFound = errors.New("products by name not found")
+
+	// ErrProductAlreadyExist means that storage-product-create function found product with the name of new product
+	ErrProductAlreadyExist = errors.New("product already exist")
+
+	// ErrProductCanNotBeLinked means that the product can't be added to company, category or price
+	ErrProductCanNotBeLinked = errors.New("product can not be linked")
+)
+
+// City of price of product
+type City struct {
+	ID, Name, Code string
+}
+
+// Price of product parsed by page-parser function
+type Price struct {
+	Value        float64
+	MaxValue     float64
+	OldValue     float64
+	Discount     float64
+	Currency     string
+	Availability string
+	DateTime     time.Time
+	City         City
+}
+
+// Product is a product parsed by page-parser function
+type Product struct {
+	Name             string
+	IRI              string
+	PreviewImageLink string
+	Price            Price
+}
+
+// IngestedProduct is a result of ingest of one product
+type IngestedProduct struct {
+	Name      string
+	ProductID string
+	PriceID   string
+	Status    string
+	Error     string
+}
+
+// IngestReport is a result of ingest of all products
+type IngestReport struct {
+	Created, Updated, Failed int
+	Products                 []IngestedProduct
+}
+
+// IngestProducts create products, which are not exist yet by IRI, create prices of all products and
+// link products with company, category and prices with city of them.
+// Product of other shop, which is not found by IRI but exists with the same name, is linked as updated product.
+// City by cityID is used only for prices without city.
+func (executor *Executor) IngestProducts(products []Product, companyID, categoryID, cityID, language string) (IngestReport, error) {
+	report := IngestReport{}
+
+	if companyID == "" {
+		ExecutorLogger.Println(ErrCompanyCanNotBeWithoutID)
+		return report, ErrCompanyCanNotBeWithoutID
+	}
+
+	if categoryID == "" {
+		ExecutorLogger.Println(ErrCategoryCanNotBeWithoutID)
+		return report, ErrCategoryCanNotBeWithoutID
+	}
+
+	for _, product := range products {
+		ingestedProduct := executor.ingestProduct(product, companyID, categoryID, cityID, language)
+
+		switch ingestedProduct.Status {
+		case StatusCreated:
+			report.Created++
+		case StatusUpdated:
+			report.Updated++
+		default:
+			report.Failed++
+		}
+
+		report.Products = append(report.Products, ingestedProduct)
+	}
+
+	return report, nil
+}
+
+func (executor *Executor) ingestProduct(product Product, companyID, categoryID, cityID, language string) IngestedProduct {
+	ingestedProduct := IngestedProduct{Name: product.Name, Status: StatusFailed}
+
+	if product.Name == "" {
+		ingestedProduct.Error = ErrProductCanNotBeWithoutName.Error()
+		return ingestedProduct
+	}
+
+	if product.IRI == "" {
+		ingestedProduct.Error = ErrProductCanNotBeWithoutIRI.Error()
+		return ingestedProduct
+	}
+
+	existProduct, err := executor.Functions.ReadProductByIRI(product.IRI, language)
+	if err != nil {
+		ExecutorLogger.Printf("Product by IRI: %v can not be read. Error: %v", product.IRI, err)
+		ingestedProduct.Error = err.Error()
+		return ingestedProduct
+	}
+
+	if existProduct.ID != "" {
+		ingestedProduct.ProductID = existProduct.ID
+	} else {
+		productForCreate := storage.Product{
+			Name:             product.Name,
+			IRI:              product.IRI,
+			PreviewImageLink: product.PreviewImageLink}
+
+		createdProduct, err := executor.Functions.CreateProduct(productForCreate, language)
+		if err == ErrProductAlreadyExist {
+			createdProduct, err = executor.productByName(product.Name, language)
+			existProduct = createdProduct
+		}
+
+		if err != nil {
+			ExecutorLogger.Printf("Product: %v can not be created. Error: %v", product.Name, err)
+			ingestedProduct.Error = err.Error()
+			return ingestedProduct
+		}
+
+		ingestedProduct.ProductID = createdProduct.ID
+	}
+
+	priceForCreate := entities.Price{
+		Price: storage.Price{
+			Value:    product.Price.Value,
+			DateTime: product.Price.DateTime},
+		Availability: product.Price.Availability,
+		Currency:     product.Price.Currency,
+		OldValue:     product.Price.OldValue,
+		Discount:     product.Price.Discount,
+		MaxValue:     product.Price.MaxValue}
+
+	if priceForCreate.DateTime.IsZero() {
+		priceForCreate.DateTime = time.Now().UTC()
+	}
+
+	createdPrice, err := executor.Functions.CreatePrice(priceForCreate, language)
+	if err != nil {
+		ExecutorLogger.Printf("Price of product: %v can not be created. Error: %v", product.Name, err)
+		ingestedProduct.Error = err.Error()
+		return ingestedProduct
+	}
+
+	ingestedProduct.PriceID = createdPrice.ID
+
+	cityOfPrice := product.Price.City.ID
+	if cityOfPrice == "" {
+		cityOfPrice = cityID
+	}
+
+	err = executor.linkProduct(ingestedProduct.ProductID, createdPrice.ID, companyID, categoryID, cityOfPrice)
+	if err != nil {
+		ingestedProduct.Error = err.Error()
+		return ingestedProduct
+	}
+
+	ingestedProduct.Status = StatusCreated
+	if existProduct.ID != "" {
+		ingestedProduct.Status = StatusUpdated
+	}
+
+	return ingestedProduct
+}
+
+// productByName return product with the same name, storage-product-read-by-name function
+// find all products with names which contain the name, so others are skipped
+func (executor *Executor) productByName(productName, language string) (storage.Product, error) {
+	existProducts, err := executor.Functions.ReadProductsByName(productName, language)
+	if err != nil {
+		ExecutorLogger.Printf("Products by name: %v can not be read. Error: %v", productName, err)
+		return storage.Product{}, err
+	}
+
+	for _, existProduct := range existProducts {
+		if existProduct.Name == productName {
+			return existProduct, nil
+		}
+	}
+
+	return storage.Product{}, ErrProductAlreadyExist
+}
+
+// linkProduct set quads of predicates about product, price, company, category and city
+func (executor *Executor) linkProduct(productID, priceID, companyID, categoryID, cityID string) error {
+	quads := [][3]string{
+		{priceID, "belongs_to_product", productID},
+		{productID, "has_price", priceID},
+		{companyID, "has_product", productID},
+		{productID, "belongs_to_company", companyID},
+		{categoryID, "has_product", productID},
+		{productID, "belongs_to_category", categoryID}}
+
+	if cityID != "" {
+		quads = append(quads, [3]string{priceID, "belongs_to_city", cityID})
+	}
+
+	for _, quad := range quads {
+		err := executor.Store.AddEntityToOtherEntity(quad[0], quad[1], quad[2])
+		if err != nil {
+			ExecutorLogger.Printf("Entity with ID: %v can not be added to entity with ID: %v by: %v", quad[2], quad[0], quad[1])
+			return ErrProductCanNotBeLinked
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestIntegration_IngestProducts(t *testing.T) {
+	t.Skip("Database and FAAS must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "192.168.99.101:31332"
+	}
+
+	FunctionsGateway := os.Getenv("FunctionsGateway")
+	if FunctionsGateway == "" {
+		FunctionsGateway = "http://192.168.99.101:31112/function"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		companyName: string @lang @index(term) .
+		categoryName: string @lang @index(term) .
+		productName: string @lang @index(term, trigram) .
+		productIri: string @index(term) .
+		productIsActive: bool @index(bool) .
+		priceValue: float @index(float) .
+		priceDateTime: dateTime @index(day) .
+		priceIsActive: bool @index(bool) .
+		has_product: uid @count .
+		has_price: uid @count .
+		belongs_to_product: uid .
+		belongs_to_company: uid .
+		belongs_to_category: uid .
+		belongs_to_city: uid .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	companyID, err := createEntity(map[string]interface{}{"companyName": "Test company"}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	categoryID, err := createEntity(map[string]interface{}{"categoryName": "Test category"}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	createdEntities := []string{companyID, categoryID}
+
+	defer func() {
+		for _, entityID := range createdEntities {
+			err = deleteEntityByID(entityID, databaseClient)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+		}
+	}()
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  DatabaseGateway,
+			FunctionsGateway: FunctionsGateway}}
+
+	Language := "ru"
+
+	time.Sleep(3 * time.Second)
+
+	products := []Product{{
+		Name:  "Test ingested product",
+		IRI:   "http://shop/test-ingested-product",
+		Price: Price{Value: 100, Currency: "RUB", Availability: "in_stock"}}}
+
+	report, err := executor.IngestProducts(products, companyID, categoryID, "", Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Created != 1 || report.Failed != 0 {
+		t.Fatalf("Expect: 1 created product, but got: %v", report)
+	}
+
+	createdProduct := report.Products[0]
+	createdEntities = append(createdEntities, createdProduct.ProductID, createdProduct.PriceID)
+
+	report, err = executor.IngestProducts(products, companyID, categoryID, "", Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Updated != 1 || report.Failed != 0 {
+		t.Fatalf("Expect: 1 updated product, but got: %v", report)
+	}
+
+	updatedProduct := report.Products[0]
+	createdEntities = append(createdEntities, updatedProduct.PriceID)
+
+	if updatedProduct.ProductID != createdProduct.ProductID {
+		t.Fatalf("Expect product: %v is updated, but got: %v", createdProduct.ProductID, updatedProduct.ProductID)
+	}
+
+	productInStorage, err := readProductByID(createdProduct.ProductID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(productInStorage.Prices) != 2 {
+		t.Fatalf("Expect 2 prices of product: %v, but got: %v", createdProduct.ProductID, len(productInStorage.Prices))
+	}
+
+	if len(productInStorage.Companies) != 1 || productInStorage.Companies[0].ID != companyID {
+		t.Fatalf("Expect product: %v in company: %v, but got: %v", createdProduct.ProductID, companyID, productInStorage.Companies)
+	}
+
+	if len(productInStorage.Categories) != 1 || productInStorage.Categories[0].ID != categoryID {
+		t.Fatalf("Expect product: %v in category: %v, but got: %v", createdProduct.ProductID, categoryID, productInStorage.Categories)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate map[string]interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func readProductByID(productID string, databaseClient *dataBaseClient.Dgraph) (storage.Product, error) {
+	query := fmt.Sprintf(`{
+				products(func: uid("%v")) @filter(has(productIri)) {
+					uid
+					productIri
+					has_price {
+						uid
+					}
+					belongs_to_company {
+						uid
+					}
+					belongs_to_category {
+						uid
+					}
+				}
+			}`, productID)
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return storage.Product{}, err
+	}
+
+	type productsInStore struct {
+		Products []storage.Product `json:"products"`
+	}
+
+	var foundedProducts productsInStore
+
+	err = json.Unmarshal(response.GetJson(), &foundedProducts)
+	if err != nil {
+		return storage.Product{}, err
+	}
+
+	if len(foundedProducts.Products) == 0 {
+		return storage.Product{}, ErrProductDoesNotExist
+	}
+
+	return foundedProducts.Products[0], nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
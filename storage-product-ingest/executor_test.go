@@ -0,0 +1,261 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"testing"
+)
+
+// ------------------------------------------------------------------------------------------------------
+func TestProductsCanBeIngested(t *testing.T) {
+	products := []Product{
+		{Name: "New product", IRI: "http://shop/new", Price: Price{Value: 100}},
+		{Name: "Exist product with other name", IRI: "http://shop/exist", Price: Price{Value: 200, Availability: "out_of_stock"}},
+		{Name: "Product without price", IRI: "http://shop/without-price", Price: Price{Value: 300}},
+		{Name: "", IRI: "http://shop/without-name", Price: Price{Value: 400}},
+		{Name: "Product without IRI", Price: Price{Value: 500}},
+		{Name: "Product which can not be read", IRI: "http://shop/failed", Price: Price{Value: 600}}}
+
+	functions := &MockFAASFunctions{}
+	store := &MockStorage{Quads: map[string]string{}}
+
+	executor := Executor{
+		Functions: functions,
+		Store:     store}
+
+	report, err := executor.IngestProducts(products, "0x1", "0x2", "0x3", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Created != 1 || report.Updated != 1 || report.Failed != 4 {
+		t.Fatalf("Expect: 1 created, 1 updated, 4 failed, but got: %v", report)
+	}
+
+	if report.Products[0].ProductID != "0x12" || report.Products[0].Status != StatusCreated {
+		t.Errorf("Expect created product: %v, but got: %v", "0x12", report.Products[0])
+	}
+
+	if report.Products[1].ProductID != "0x13" || report.Products[1].Status != StatusUpdated {
+		t.Errorf("Expect updated product: %v, but got: %v", "0x13", report.Products[1])
+	}
+
+	if report.Products[2].Error == "" {
+		t.Errorf("Expect error of product: %v", report.Products[2].Name)
+	}
+
+	if report.Products[4].Error != ErrProductCanNotBeWithoutIRI.Error() {
+		t.Errorf("Expect: %v, but got: %v", ErrProductCanNotBeWithoutIRI, report.Products[4].Error)
+	}
+
+	if report.Products[5].Error == "" {
+		t.Errorf("Expect error of product: %v", report.Products[5].Name)
+	}
+
+	for _, createdProduct := range functions.CreatedProducts {
+		if createdProduct.IRI == "http://shop/exist" || createdProduct.IRI == "http://shop/failed" {
+			t.Errorf("Expect product: %v is not created if it exists or can not be read by IRI", createdProduct.IRI)
+		}
+	}
+
+	if store.Quads["0x13 has_price"] != "0x200" {
+		t.Errorf("Expect price: %v of product: %v, but got: %v", "0x200", "0x13", store.Quads["0x13 has_price"])
+	}
+
+	if store.Quads["0x200 belongs_to_city"] != "0x3" {
+		t.Errorf("Expect city: %v of price: %v, but got: %v", "0x3", "0x200", store.Quads["0x200 belongs_to_city"])
+	}
+
+	if store.Quads["0x2 has_product"] != "0x13" {
+		t.Errorf("Expect product: %v in category: %v, but got: %v", "0x13", "0x2", store.Quads["0x2 has_product"])
+	}
+}
+
+func TestProductsCanBeIngestedWithCityAndDiscountOfPrice(t *testing.T) {
+	products := []Product{
+		{
+			Name: "New product",
+			IRI:  "http://shop/new",
+			Price: Price{
+				Value:    900,
+				MaxValue: 1200,
+				OldValue: 1000,
+				Discount: 10,
+				Currency: "RUB",
+				City:     City{ID: "0x4", Name: "Saint Petersburg", Code: "spb"}}},
+		{Name: "Exist product", IRI: "http://shop/exist", Price: Price{Value: 200, Availability: "in_stock"}}}
+
+	functions := &MockFAASFunctions{}
+	store := &MockStorage{Quads: map[string]string{}}
+
+	executor := Executor{
+		Functions: functions,
+		Store:     store}
+
+	report, err := executor.IngestProducts(products, "0x1", "0x2", "0x3", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Created != 1 || report.Updated != 1 {
+		t.Fatalf("Expect: 1 created, 1 updated, but got: %v", report)
+	}
+
+	if store.Quads["0x900 belongs_to_city"] != "0x4" {
+		t.Errorf("Expect city: %v of price: %v, but got: %v", "0x4", "0x900", store.Quads["0x900 belongs_to_city"])
+	}
+
+	if store.Quads["0x200 belongs_to_city"] != "0x3" {
+		t.Errorf("Expect city: %v of price without city, but got: %v", "0x3", store.Quads["0x200 belongs_to_city"])
+	}
+
+	createdPrice := functions.CreatedPrices[0]
+	if createdPrice.Currency != "RUB" || createdPrice.OldValue != 1000 || createdPrice.Discount != 10 || createdPrice.MaxValue != 1200 {
+		t.Errorf("Expect price with currency, old value, discount and max value, but got: %v", createdPrice)
+	}
+}
+
+func TestProductOfOtherShopCanBeIngestedToExistProductWithTheSameName(t *testing.T) {
+	products := []Product{
+		{Name: "Product of other shop", IRI: "http://other-shop/product", Price: Price{Value: 700}},
+		{Name: "Product", IRI: "http://other-shop/similar-product", Price: Price{Value: 800}}}
+
+	functions := &MockFAASFunctions{}
+	store := &MockStorage{Quads: map[string]string{}}
+
+	executor := Executor{
+		Functions: functions,
+		Store:     store}
+
+	report, err := executor.IngestProducts(products, "0x5", "0x2", "0x3", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Updated != 1 || report.Failed != 1 {
+		t.Fatalf("Expect: 1 updated, 1 failed, but got: %v", report)
+	}
+
+	if report.Products[0].ProductID != "0x15" || report.Products[0].Status != StatusUpdated {
+		t.Errorf("Expect product of other shop linked to exist product: %v, but got: %v", "0x15", report.Products[0])
+	}
+
+	if store.Quads["0x5 has_product"] != "0x15" || store.Quads["0x15 has_price"] != "0x700" {
+		t.Errorf("Expect exist product: %v linked to company: %v and price: %v, but got: %v", "0x15", "0x5", "0x700", store.Quads)
+	}
+
+	if report.Products[1].Error != ErrProductAlreadyExist.Error() {
+		t.Errorf("Expect: %v for product with only similar names, but got: %v", ErrProductAlreadyExist, report.Products[1])
+	}
+}
+
+/// Mock FAAS functions
+type MockFAASFunctions struct {
+	CreatedProducts []storage.Product
+	CreatedPrices   []entities.Price
+}
+
+func (functions *MockFAASFunctions) ReadProductByIRI(productIRI, language string) (storage.Product, error) {
+	if productIRI == "http://shop/exist" {
+		return storage.Product{ID: "0x13", Name: "Exist product", IRI: productIRI}, nil
+	}
+
+	if productIRI == "http://shop/failed" {
+		return storage.Product{}, errors.New("product by iri can not be found")
+	}
+
+	return storage.Product{}, nil
+}
+
+func (functions *MockFAASFunctions) ReadProductsByName(productName, language string) ([]storage.Product, error) {
+	return []storage.Product{
+		{ID: "0x14", Name: "Product of other shop with case"},
+		{ID: "0x15", Name: "Product of other shop"}}, nil
+}
+
+func (functions *MockFAASFunctions) CreateProduct(product storage.Product, language string) (storage.Product, error) {
+	if product.IRI == "http://other-shop/product" || product.IRI == "http://other-shop/similar-product" {
+		return product, ErrProductAlreadyExist
+	}
+
+	product.ID = "0x12"
+	functions.CreatedProducts = append(functions.CreatedProducts, product)
+	return product, nil
+}
+
+func (functions *MockFAASFunctions) CreatePrice(price entities.Price, language string) (entities.Price, error) {
+	if price.Value == 300 {
+		return price, errors.New("price can't be created")
+	}
+
+	if price.Value == 200 && price.Availability == "" {
+		return price, errors.New("price without availability")
+	}
+
+	price.ID = fmt.Sprintf("0x%v", price.Value)
+	functions.CreatedPrices = append(functions.CreatedPrices, price)
+	return price, nil
+}
+
+/// Mock Storage
+type MockStorage struct {
+	Quads map[string]string
+}
+
+func (store *MockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
+	store.Quads[entityID+" "+field] = addedEntityID
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductsCanNotBeIngestedWithoutCompanyOrCategory(t *testing.T) {
+	executor := Executor{Functions: &MockFAASFunctions{}}
+
+	_, err := executor.IngestProducts(nil, "", "0x2", "", "ru")
+	if err != ErrCompanyCanNotBeWithoutID {
+		t.Fatalf(err.Error())
+	}
+
+	_, err = executor.IngestProducts(nil, "0x1", "", "", "ru")
+	if err != ErrCategoryCanNotBeWithoutID {
+		t.Fatalf(err.Error())
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductCanNotBeLinked(t *testing.T) {
+	products := []Product{{Name: "New product", IRI: "http://shop/new", Price: Price{Value: 100}}}
+
+	executor := Executor{
+		Functions: &MockFAASFunctions{},
+		Store:     ErrorMockStorage{}}
+
+	report, err := executor.IngestProducts(products, "0x1", "0x2", "", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if report.Failed != 1 {
+		t.Fatalf("Expect 1 failed product, but got: %v", report.Failed)
+	}
+
+	if report.Products[0].Error != ErrProductCanNotBeLinked.Error() {
+		t.Errorf("Expect: %v, but got: %v", ErrProductCanNotBeLinked, report.Products[0].Error)
+	}
+}
+
+type ErrorMockStorage struct{}
+
+func (store ErrorMockStorage) AddEntityToOtherEntity(entityID, field, addedEntityID string) error {
+	var status error
+
+	if field == "belongs_to_category" {
+		status = errors.New("")
+	}
+
+	return status
+}
+
+// --------------------------------------------------------------------------------------------------------
@@ -0,0 +1,184 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+)
+
+var FAASLogger = log.New(os.Stdout, "FAASFunctions: ", log.Lshortfile)
+
+type FAASFunctions struct {
+	FunctionsGateway string
+	DatabaseGateway  string
+}
+
+// ReadProductByIRI return product with the same IRI or empty product if it does not exist
+func (functions FAASFunctions) ReadProductByIRI(productIRI, language string) (storage.Product, error) {
+	body := struct {
+		Language        string
+		ProductIRI      string
+		DatabaseGateway string
+	}{
+		Language:        language,
+		ProductIRI:      productIRI,
+		DatabaseGateway: functions.DatabaseGateway}
+
+	var existProduct storage.Product
+
+	response, err := functions.call("storage-product-read-by-iri", body)
+	if err != nil && err.Error() == ErrProductDoesNotExist.Error() {
+		return existProduct, nil
+	}
+
+	if err != nil {
+		FAASLogger.Println(err)
+		return existProduct, err
+	}
+
+	err = json.Unmarshal([]byte(response.Data), &existProduct)
+	if err != nil {
+		FAASLogger.Println(err)
+		return existProduct, err
+	}
+
+	return existProduct, nil
+}
+
+// ReadProductsByName return products with names which contain the name or no products if they do not exist
+func (functions FAASFunctions) ReadProductsByName(productName, language string) ([]storage.Product, error) {
+	body := struct {
+		Language        string
+		ProductName     string
+		DatabaseGateway string
+	}{
+		Language:        language,
+		ProductName:     productName,
+		DatabaseGateway: functions.DatabaseGateway}
+
+	var existProducts []storage.Product
+
+	response, err := functions.call("storage-product-read-by-name", body)
+	if err != nil && err.Error() == ErrProductsByNameNotFound.Error() {
+		return existProducts, nil
+	}
+
+	if err != nil {
+		FAASLogger.Println(err)
+		return existProducts, err
+	}
+
+	err = json.Unmarshal([]byte(response.Data), &existProducts)
+	if err != nil {
+		FAASLogger.Println(err)
+		return existProducts, err
+	}
+
+	return existProducts, nil
+}
+
+// CreateProduct return ErrProductAlreadyExist if product with the name of it exists
+func (functions FAASFunctions) CreateProduct(product storage.Product, language string) (storage.Product, error) {
+	body := struct {
+		Language,
+		DatabaseGateway,
+		FunctionsGateway string
+		Product storage.Product
+	}{
+		Language:         language,
+		DatabaseGateway:  functions.DatabaseGateway,
+		FunctionsGateway: functions.FunctionsGateway,
+		Product:          product}
+
+	response, err := functions.call("storage-product-create", body)
+	if err != nil && err.Error() == ErrProductAlreadyExist.Error() {
+		return product, ErrProductAlreadyExist
+	}
+
+	if err != nil {
+		FAASLogger.Println(err)
+		return product, err
+	}
+
+	var createdProduct storage.Product
+
+	err = json.Unmarshal([]byte(response.Data), &createdProduct)
+	if err != nil {
+		FAASLogger.Println(err)
+		return product, err
+	}
+
+	return createdProduct, nil
+}
+
+func (functions FAASFunctions) CreatePrice(price entities.Price, language string) (entities.Price, error) {
+	body := struct {
+		Language,
+		DatabaseGateway,
+		FunctionsGateway string
+		Price entities.Price
+	}{
+		Language:         language,
+		DatabaseGateway:  functions.DatabaseGateway,
+		FunctionsGateway: functions.FunctionsGateway,
+		Price:            price}
+
+	response, err := functions.call("storage-price-create", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return price, err
+	}
+
+	var createdPrice entities.Price
+
+	err = json.Unmarshal([]byte(response.Data), &createdPrice)
+	if err != nil {
+		FAASLogger.Println(err)
+		return price, err
+	}
+
+	return createdPrice, nil
+}
+
+// call send body to function and decode response of it
+func (functions FAASFunctions) call(functionName string, body interface{}) (Response, error) {
+	functionPath := fmt.Sprintf(
+		"%v/%v", functions.FunctionsGateway, functionName)
+
+	encodedResponse := Response{}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	defer response.Body.Close()
+
+	decodedResponse, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	if encodedResponse.Error != "" {
+		return encodedResponse, errors.New(encodedResponse.Error)
+	}
+
+	return encodedResponse, nil
+}
@@ -0,0 +1,217 @@
+package function
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFAASFunctions_ReadProductByIRI(t *testing.T) {
+	LanguageForTest := "ru"
+	ProductIRIForTest := "http://shop/product"
+	DatabaseGatewayForTest := "http://TestDatabaseGateway"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/storage-product-read-by-iri", func(w http.ResponseWriter, r *http.Request) {
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		var responseBodyEncoded map[string]string
+		err = json.Unmarshal(encodedBody, &responseBodyEncoded)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if responseBodyEncoded["DatabaseGateway"] != DatabaseGatewayForTest {
+			t.Fatalf(
+				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
+		}
+
+		if responseBodyEncoded["ProductIRI"] != ProductIRIForTest {
+			_, err = io.WriteString(w, `{"Error": "product does not exist"}`)
+			if err != nil {
+				t.Error(err.Error())
+			}
+
+			return
+		}
+
+		existedProductInStorage := storage.Product{ID: "0x12", IRI: ProductIRIForTest, IsActive: true}
+
+		encodedExistedProductInStorage, err := json.Marshal(existedProductInStorage)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedExistedProductInStorage)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL, DatabaseGateway: DatabaseGatewayForTest}
+
+	product, err := faas.ReadProductByIRI(ProductIRIForTest, LanguageForTest)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if product.ID != "0x12" {
+		t.Fatalf("Expect product with id: %v, but got: %v", "0x12", product)
+	}
+
+	product, err = faas.ReadProductByIRI("http://shop/other", LanguageForTest)
+	if err != nil {
+		t.Fatalf("Expect no error for product which does not exist, but got: %v", err)
+	}
+
+	if product.ID != "" {
+		t.Fatalf("Expect empty product, but got: %v", product)
+	}
+}
+
+func TestFAASFunctions_CreateProductAndPrice(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/storage-product-create", func(w http.ResponseWriter, r *http.Request) {
+		var requestBody struct {
+			Language string
+			Product  storage.Product
+		}
+
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		err = json.Unmarshal(encodedBody, &requestBody)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if requestBody.Product.Name == "Exist product" {
+			_, err = io.WriteString(w, `{"Error": "product already exist"}`)
+			if err != nil {
+				t.Error(err.Error())
+			}
+
+			return
+		}
+
+		requestBody.Product.ID = "0x12"
+
+		encodedProduct, err := json.Marshal(requestBody.Product)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedProduct)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	mux.HandleFunc("/storage-price-create", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"Error": "price can't be created"}`)
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL}
+
+	product, err := faas.CreateProduct(storage.Product{Name: "Test product"}, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if product.ID != "0x12" || product.Name != "Test product" {
+		t.Fatalf("Expect product with id: %v, but got: %v", "0x12", product)
+	}
+
+	_, err = faas.CreateProduct(storage.Product{Name: "Exist product"}, "ru")
+	if err != ErrProductAlreadyExist {
+		t.Fatalf("Expect: %v, but got: %v", ErrProductAlreadyExist, err)
+	}
+
+	_, err = faas.CreatePrice(entities.Price{Price: storage.Price{Value: 100}}, "ru")
+	if err == nil || err.Error() != "price can't be created" {
+		t.Fatalf("Expect error of storage-price-create, but got: %v", err)
+	}
+}
+
+func TestFAASFunctions_ReadProductsByName(t *testing.T) {
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requestBody struct {
+			Language    string
+			ProductName string
+		}
+
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		err = json.Unmarshal(encodedBody, &requestBody)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if r.URL.Path != "/storage-product-read-by-name" {
+			t.Errorf("Expect request to storage-product-read-by-name, but got: %v", r.URL.Path)
+		}
+
+		response := `{"Error": "products by name not found"}`
+		if requestBody.ProductName == "Exist product" {
+			response = `{"Data": "[{\"uid\":\"0x13\",\"productName\":\"Exist product\"}]"}`
+		}
+
+		_, err = io.WriteString(w, response)
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	testServer := httptest.NewServer(testHandler)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL}
+
+	products, err := faas.ReadProductsByName("Exist product", "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(products) != 1 || products[0].ID != "0x13" {
+		t.Fatalf("Expect product with id: %v, but got: %v", "0x13", products)
+	}
+
+	products, err = faas.ReadProductsByName("New product", "ru")
+	if err != nil || len(products) != 0 {
+		t.Fatalf("Expect no products without error, but got: %v, %v", products, err)
+	}
+}
@@ -0,0 +1,91 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Storage"
+)
+
+type Request struct {
+	Language,
+	CompanyID,
+	CategoryID,
+	CityID,
+	DatabaseGateway,
+	FunctionsGateway string
+	Products []Product
+}
+
+type Response struct{ Message, Data, Error string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: request.DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  request.DatabaseGateway,
+			FunctionsGateway: request.FunctionsGateway}}
+
+	report, err := executor.IngestProducts(
+		request.Products, request.CompanyID, request.CategoryID, request.CityID, request.Language)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"IngestProducts error: %v", err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedReport, err := json.Marshal(report)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal ingest report error: %v. Error: %v", report, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedReport)}
+
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
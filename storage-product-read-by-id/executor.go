@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"github.com/hecatoncheir/Storage"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"log"
 	"os"
 	"text/template"
@@ -16,6 +16,9 @@ type Storage interface {
 
 type Executor struct {
 	Store Storage
+
+	// OnlyAvailable means that prices of offers which are out of stock are skipped
+	OnlyAvailable bool
 }
 
 var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
@@ -31,9 +34,10 @@ var (
 	ErrProductDoesNotExist = errors.New("product does not exist")
 )
 
-// ReadProductByID is a method for get all nodes of categories by ID
-func (executor *Executor) ReadProductByID(productID, language string) (storage.Product, error) {
-	product := storage.Product{}
+// ReadProductByID is a method for get all nodes of categories by ID,
+// product is returned with details of detail page of it
+func (executor *Executor) ReadProductByID(productID, language string) (entities.Product, error) {
+	product := entities.Product{}
 
 	if productID == "" {
 		ExecutorLogger.Println("Product can't be without ID")
@@ -41,11 +45,13 @@ func (executor *Executor) ReadProductByID(productID, language string) (storage.P
 	}
 
 	variables := struct {
-		ProductID string
-		Language  string
+		ProductID     string
+		Language      string
+		OnlyAvailable bool
 	}{
-		ProductID: productID,
-		Language:  language}
+		ProductID:     productID,
+		Language:      language,
+		OnlyAvailable: executor.OnlyAvailable}
 
 	queryTemplate, err := template.New("ReadProductByID").Parse(`{
 				products(func: uid("{{.ProductID}}")) @filter(has(productName)) {
@@ -54,6 +60,15 @@ func (executor *Executor) ReadProductByID(productID, language string) (storage.P
 					productIri
 					previewImageLink
 					productIsActive
+					productDescription
+					productSku
+					productGtin
+					productImages
+					has_specification {
+						uid
+						specificationName
+						specificationValue
+					}
 					belongs_to_category @filter(eq(categoryIsActive, true)) {
 						uid
 						categoryName: categoryName@{{.Language}}
@@ -87,10 +102,15 @@ func (executor *Executor) ReadProductByID(productID, language string) (storage.P
 							}
 						}
 					}
-					has_price @filter(eq(priceIsActive, true)) (orderasc: priceDateTime) {
+					has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) (orderasc: priceDateTime) {
 						uid
 						priceValue
 						priceDateTime
+						priceAvailability
+						priceCurrency
+						priceOldValue
+						priceDiscount
+						priceMaxValue
 						priceCity
 						priceIsActive
 						belongs_to_product @filter(eq(productIsActive, true)) {
@@ -99,10 +119,15 @@ func (executor *Executor) ReadProductByID(productID, language string) (storage.P
 							productIri
 							previewImageLink
 							productIsActive
-							has_price @filter(eq(priceIsActive, true)) {
+							has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) {
 								uid
 								priceValue
 								priceDateTime
+								priceAvailability
+								priceCurrency
+								priceOldValue
+								priceDiscount
+								priceMaxValue
 								priceCity
 								priceIsActive
 							}
@@ -137,7 +162,7 @@ func (executor *Executor) ReadProductByID(productID, language string) (storage.P
 	}
 
 	type productsInStore struct {
-		Products []storage.Product `json:"products"`
+		Products []entities.Product `json:"products"`
 	}
 
 	var foundedProducts productsInStore
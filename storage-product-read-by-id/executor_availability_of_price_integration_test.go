@@ -0,0 +1,116 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestExecutor_ReadProductByIDWithOnlyAvailablePrices(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productName: string @lang @index(term, trigram) .
+		productIri: string @index(term) .
+		productIsActive: bool @index(bool) .
+		has_price: uid @count .
+		priceValue: float @index(float) .
+		priceDateTime: dateTime @index(hour) .
+		priceIsActive: bool @index(bool) .
+		priceAvailability: string @index(exact) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	Language := "ru"
+	entityTestName := "Test product with availability"
+
+	entityForCreate := entities.Product{
+		Product: storage.Product{
+			Name:     entityTestName,
+			IRI:      "//",
+			IsActive: true},
+		Prices: []entities.Price{
+			{
+				Price:        storage.Price{Value: 10, DateTime: time.Now().UTC(), IsActive: true},
+				Availability: "in_stock"},
+			{
+				Price:        storage.Price{DateTime: time.Now().UTC(), IsActive: true},
+				Availability: "out_of_stock"}}}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	err = addOtherLanguageForCompanyName(createdEntityID, entityTestName, Language, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	entityFoundedInStorage, err := executor.ReadProductByID(createdEntityID, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(entityFoundedInStorage.Prices) != 2 {
+		t.Fatalf("Expected 2 prices of founded product in storage, actual: %v", len(entityFoundedInStorage.Prices))
+	}
+
+	availabilities := map[string]bool{}
+	for _, price := range entityFoundedInStorage.Prices {
+		availabilities[price.Availability] = true
+	}
+
+	if !availabilities["in_stock"] || !availabilities["out_of_stock"] {
+		t.Fatalf("Expected prices of founded product in storage with availability, actual: %v", entityFoundedInStorage.Prices)
+	}
+
+	executor.OnlyAvailable = true
+
+	availableEntityFoundedInStorage, err := executor.ReadProductByID(createdEntityID, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(availableEntityFoundedInStorage.Prices) != 1 {
+		t.Fatalf("Expected 1 available price of founded product in storage, actual: %v", len(availableEntityFoundedInStorage.Prices))
+	}
+
+	if availableEntityFoundedInStorage.Prices[0].Availability != "in_stock" {
+		t.Fatalf("Expected price of founded product in storage 'in_stock', actual: %v", availableEntityFoundedInStorage.Prices[0].Availability)
+	}
+
+	for _, price := range entityFoundedInStorage.Prices {
+		err = deleteEntityByID(price.ID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	dataBaseClient "github.com/dgraph-io/dgo"
 	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"google.golang.org/grpc"
 	"os"
@@ -54,10 +55,11 @@ func TestExecutor_ReadProductByID(t *testing.T) {
 	entityTestName := "Test product name"
 	entityTestIRI := "//"
 
-	entityForCreate := storage.Product{
-		Name:     entityTestName,
-		IRI:      entityTestIRI,
-		IsActive: true}
+	entityForCreate := entities.Product{
+		Product: storage.Product{
+			Name:     entityTestName,
+			IRI:      entityTestIRI,
+			IsActive: true}}
 
 	createdEntityID, err := createEntity(entityForCreate, databaseClient)
 	if err != nil {
@@ -119,7 +121,7 @@ func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
 	return nil
 }
 
-func createEntity(entityForCreate storage.Product, databaseClient *dataBaseClient.Dgraph) (string, error) {
+func createEntity(entityForCreate entities.Product, databaseClient *dataBaseClient.Dgraph) (string, error) {
 	encodedEntity, err := json.Marshal(entityForCreate)
 	if err != nil {
 		return "", err
@@ -23,6 +23,14 @@ func TestProductCanBeReadByID(t *testing.T) {
 	if productFromStore.Name != "Test product" {
 		t.Fatalf("Expected name of product: 'Test product', actual: %v", productFromStore.Name)
 	}
+
+	if len(productFromStore.Prices) != 1 || productFromStore.Prices[0].Availability != "out_of_stock" {
+		t.Fatalf("Expected price of product with availability: 'out_of_stock', actual: %v", productFromStore.Prices)
+	}
+
+	if productFromStore.SKU != "12345" || len(productFromStore.Specifications) != 1 {
+		t.Fatalf("Expected details of product, actual: %v", productFromStore)
+	}
 }
 
 type MockStore struct {
@@ -40,9 +48,18 @@ func (store MockStore) Query(request string) (response []byte, err error) {
 				 "productIri":"http://",
 				 "previewImageLink":"http://",
 				 "productIsActive":true,
+				 "productSku":"12345",
+				 "has_specification":[{"uid":"0x20","specificationName":"Color","specificationValue":"black"}],
 				 "belongs_to_company": [],
 				 "belongs_to_category": [],
-				 "has_price": []
+				 "has_price": [
+					{
+					   "uid":"0x13",
+					   "priceValue":0,
+					   "priceAvailability":"out_of_stock",
+					   "priceIsActive":true
+					}
+				 ]
 			  }
 		   ]
 		}
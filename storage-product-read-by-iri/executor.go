@@ -0,0 +1,101 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"log"
+	"os"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrProductCanNotBeWithoutIRI means that product can't be found in storage without IRI of it
+	ErrProductCanNotBeWithoutIRI = errors.New("product can not be without iri")
+
+	// ErrProductByIRICanNotBeFound means that the product can't be found in database
+	ErrProductByIRICanNotBeFound = errors.New("product by iri can not be found")
+
+	// ErrProductDoesNotExist means than the product does not exist in database
+	ErrProductDoesNotExist = errors.New("product does not exist")
+)
+
+// ReadProductByIRI is a method for get active product with the same IRI
+func (executor *Executor) ReadProductByIRI(productIRI, language string) (entities.Product, error) {
+	product := entities.Product{}
+
+	if productIRI == "" {
+		ExecutorLogger.Println("Product can't be without IRI")
+		return product, ErrProductCanNotBeWithoutIRI
+	}
+
+	variables := struct {
+		ProductIRI string
+		Language   string
+	}{
+		ProductIRI: productIRI,
+		Language:   language}
+
+	queryTemplate, err := template.New("ReadProductByIRI").Parse(`{
+				products(func: eq(productIri, "{{.ProductIRI}}")) @filter(eq(productIsActive, true)) {
+					uid
+					productName: productName@{{.Language}}
+					productIri
+					previewImageLink
+					productIsActive
+					belongs_to_company @filter(eq(companyIsActive, true)) {
+						uid
+						companyName: companyName@{{.Language}}
+						companyIri
+						companyIsActive
+					}
+				}
+			}`)
+
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return product, ErrProductByIRICanNotBeFound
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return product, ErrProductByIRICanNotBeFound
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return product, ErrProductByIRICanNotBeFound
+	}
+
+	type productsInStore struct {
+		Products []entities.Product `json:"products"`
+	}
+
+	var foundedProducts productsInStore
+
+	err = json.Unmarshal(response, &foundedProducts)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return product, ErrProductByIRICanNotBeFound
+	}
+
+	if len(foundedProducts.Products) == 0 {
+		return product, ErrProductDoesNotExist
+	}
+
+	return foundedProducts.Products[0], nil
+}
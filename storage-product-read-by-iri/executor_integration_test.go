@@ -0,0 +1,180 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestExecutor_ReadProductByIRI(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productName: string @lang @index(term, trigram) .
+		productIri: string @index(exact, term) .
+		productImageLink: string @index(term) .
+		productIsActive: bool @index(bool) .
+		belongs_to_category: uid .
+		belongs_to_company: uid .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	Language := "ru"
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+	_, err = executor.ReadProductByIRI("", Language)
+	if err != ErrProductCanNotBeWithoutIRI {
+		t.Error(err)
+	}
+
+	entityTestName := "Test product name"
+	entityTestIRI := "http://shop/product-for-read-by-iri"
+
+	_, err = executor.ReadProductByIRI(entityTestIRI, Language)
+	if err != ErrProductDoesNotExist {
+		t.Error(err)
+	}
+
+	entityForCreate := entities.Product{
+		Product: storage.Product{
+			Name:     entityTestName,
+			IRI:      entityTestIRI,
+			IsActive: true}}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	defer func() {
+		err = deleteEntityByID(createdEntityID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	err = addOtherLanguageForProductName(createdEntityID, entityTestName, Language, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	entityFoundedInStorage, err := executor.ReadProductByIRI(entityTestIRI, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if entityFoundedInStorage.ID != createdEntityID {
+		t.Fatalf("ID: %v of founded product in storage is not ID: %v of created product", entityFoundedInStorage.ID, createdEntityID)
+	}
+
+	if entityFoundedInStorage.Name != entityTestName {
+		t.Fatalf("Name: '%v' of founded product in storage is not value: '%v' of created product", entityFoundedInStorage.Name, entityTestName)
+	}
+
+	_, err = executor.ReadProductByIRI(entityTestIRI+"/other", Language)
+	if err != ErrProductDoesNotExist {
+		t.Fatalf("Expected: %v for product with other IRI, actual: %v", ErrProductDoesNotExist, err)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate entities.Product, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", nil
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func addOtherLanguageForProductName(entityID, name, language string, databaseClient *dataBaseClient.Dgraph) error {
+	forEntityNamePredicate := fmt.Sprintf(`<%s> <productName> %s .`, entityID, "\""+name+"\""+"@"+language)
+
+	mutation := &dataBaseAPI.Mutation{
+		SetNquads: []byte(forEntityNamePredicate),
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	_, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Storage"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestProductCanBeReadByIRI(t *testing.T) {
+	IRIOfTestedProduct := "http://shop/product"
+
+	store := &MockStore{}
+	executor := Executor{Store: store}
+
+	productFromStore, err := executor.ReadProductByIRI(IRIOfTestedProduct, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if productFromStore.ID != "0x12" {
+		t.Fatalf("Expected id of product: '0x12', actual: %v", productFromStore.ID)
+	}
+
+	if productFromStore.IRI != IRIOfTestedProduct {
+		t.Fatalf("Expected IRI of product: %v, actual: %v", IRIOfTestedProduct, productFromStore.IRI)
+	}
+
+	if !strings.Contains(store.Request, `eq(productIri, "http://shop/product")`) {
+		t.Fatalf("Expected query of product by IRI, actual: %v", store.Request)
+	}
+}
+
+type MockStore struct {
+	storage.Store
+	Request string
+}
+
+func (store *MockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+
+	resp := `
+		{
+		   "products":[
+			  {
+				 "uid":"0x12",
+				 "productName":"Test product",
+				 "productIsActive":true,
+				 "productIri": "http://shop/product",
+				 "previewImageLink": "http://",
+				 "belongs_to_company":[]
+			  }
+		   ]
+		}
+	`
+
+	return []byte(resp), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestProductCanNotBeReadWithoutIRI(t *testing.T) {
+	executor := Executor{Store: &MockStore{}}
+
+	_, err := executor.ReadProductByIRI("", "ru")
+	if err != ErrProductCanNotBeWithoutIRI {
+		t.Fatalf(err.Error())
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestProductCanBeReadByIRIAndItCanBeEmpty(t *testing.T) {
+	executor := Executor{Store: EmptyMockStore{}}
+
+	_, err := executor.ReadProductByIRI("http://shop/product", "ru")
+	if err != ErrProductDoesNotExist {
+		t.Fatalf(err.Error())
+	}
+}
+
+type EmptyMockStore struct {
+	storage.Store
+}
+
+func (store EmptyMockStore) Query(request string) (response []byte, err error) {
+
+	resp := `
+		{
+		   "products":[]
+		}
+	`
+
+	return []byte(resp), nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"github.com/hecatoncheir/Storage"
 	"log"
 	"os"
@@ -16,6 +17,9 @@ type Storage interface {
 
 type Executor struct {
 	Store Storage
+
+	// OnlyAvailable means that prices of offers which are out of stock are skipped
+	OnlyAvailable bool
 }
 
 var logger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
@@ -29,16 +33,18 @@ var (
 )
 
 // ReadProductsByNameWithPagination is a method for get all nodes by product name for page
-func (executor *Executor) ReadProductsByNameWithPagination(productName, language string, currentPage, itemsPerPage int) (*storage.ProductsByNameForPage, error) {
+func (executor *Executor) ReadProductsByNameWithPagination(productName, language string, currentPage, itemsPerPage int) (*entities.ProductsByNameForPage, error) {
 	variables := struct {
 		ProductName, Language             string
 		CurrentPage, ItemsPerPage, Offset int
+		OnlyAvailable                     bool
 	}{
-		ProductName:  productName,
-		ItemsPerPage: itemsPerPage,
-		CurrentPage:  currentPage,
-		Offset:       currentPage*itemsPerPage - itemsPerPage,
-		Language:     language}
+		ProductName:   productName,
+		ItemsPerPage:  itemsPerPage,
+		CurrentPage:   currentPage,
+		Offset:        currentPage*itemsPerPage - itemsPerPage,
+		Language:      language,
+		OnlyAvailable: executor.OnlyAvailable}
 
 	queryTemplate, err := template.New("productsByPage").Parse(`{
 				all as counters(func: regexp(productName@{{.Language}}, /{{.ProductName}}/i))
@@ -86,10 +92,15 @@ func (executor *Executor) ReadProductsByNameWithPagination(productName, language
 							}
 						}
 					}
-					has_price @filter(eq(priceIsActive, true)) (orderdesc: priceDateTime) {
+					has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) (orderdesc: priceDateTime) {
 						uid
 						priceValue
 						priceDateTime
+						priceAvailability
+						priceCurrency
+						priceOldValue
+						priceDiscount
+						priceMaxValue
 						priceCity
 						priceIsActive
 						belongs_to_product @filter(eq(productIsActive, true)) {
@@ -98,10 +109,15 @@ func (executor *Executor) ReadProductsByNameWithPagination(productName, language
 							productIri
 							previewImageLink
 							productIsActive
-							has_price @filter(eq(priceIsActive, true)) {
+							has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) {
 								uid
 								priceValue
 								priceDateTime
+								priceAvailability
+								priceCurrency
+								priceOldValue
+								priceDiscount
+								priceMaxValue
 								priceCity
 								priceIsActive
 							}
@@ -140,8 +156,8 @@ func (executor *Executor) ReadProductsByNameWithPagination(productName, language
 	}
 
 	type productsInStorage struct {
-		Total                    []map[string]int  `json:"counters"`
-		AllProductsFoundedByName []storage.Product `json:"products"`
+		Total                    []map[string]int   `json:"counters"`
+		AllProductsFoundedByName []entities.Product `json:"products"`
 	}
 
 	var foundedProducts productsInStorage
@@ -152,13 +168,14 @@ func (executor *Executor) ReadProductsByNameWithPagination(productName, language
 		return nil, ErrProductsByNameCanNotBeFound
 	}
 
-	foundedProductsByNameForPage := storage.ProductsByNameForPage{
-		Products:                foundedProducts.AllProductsFoundedByName,
-		CurrentPage:             currentPage,
-		TotalProductsForOnePage: itemsPerPage,
-		SearchedName:            productName,
-		TotalProductsFound:      foundedProducts.Total[0]["total"],
-		Language:                language}
+	foundedProductsByNameForPage := entities.ProductsByNameForPage{
+		ProductsByNameForPage: storage.ProductsByNameForPage{
+			CurrentPage:             currentPage,
+			TotalProductsForOnePage: itemsPerPage,
+			SearchedName:            productName,
+			TotalProductsFound:      foundedProducts.Total[0]["total"],
+			Language:                language},
+		Products: foundedProducts.AllProductsFoundedByName}
 
 	if len(foundedProducts.AllProductsFoundedByName) == 0 {
 		return &foundedProductsByNameForPage, ErrProductsByNameNotFound
@@ -0,0 +1,134 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestExecutor_ReadProductsByNameWithPaginationWithOnlyAvailablePrices(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productName: string @lang @index(term, trigram) .
+		productIri: string @index(term) .
+		productIsActive: bool @index(bool) .
+		has_price: uid @count .
+		priceValue: float @index(float) .
+		priceDateTime: dateTime @index(hour) .
+		priceIsActive: bool @index(bool) .
+		priceAvailability: string @index(exact) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	Language := "ru"
+	entityTestName := "Тестовый продукт с наличием"
+
+	productID, err := createProductWithPrices(
+		entities.Product{
+			Product: storage.Product{Name: entityTestName, IsActive: true},
+			Prices: []entities.Price{
+				{
+					Price:        storage.Price{Value: 10, DateTime: time.Now().UTC(), IsActive: true},
+					Availability: "in_stock"},
+				{
+					Price:        storage.Price{DateTime: time.Now().UTC(), IsActive: true},
+					Availability: "out_of_stock"}}},
+		Language, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	productsForPage, err := executor.ReadProductsByNameWithPagination(entityTestName, Language, 1, 10)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(productsForPage.Products) != 1 || len(productsForPage.Products[0].Prices) != 2 {
+		t.Fatalf("Expected 1 product with 2 prices, actual: %v", productsForPage.Products)
+	}
+
+	availabilities := map[string]bool{}
+	for _, price := range productsForPage.Products[0].Prices {
+		availabilities[price.Availability] = true
+	}
+
+	if !availabilities["in_stock"] || !availabilities["out_of_stock"] {
+		t.Fatalf("Expected prices of product with availability, actual: %v", productsForPage.Products[0].Prices)
+	}
+
+	executor.OnlyAvailable = true
+
+	availableProductsForPage, err := executor.ReadProductsByNameWithPagination(entityTestName, Language, 1, 10)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(availableProductsForPage.Products) != 1 || len(availableProductsForPage.Products[0].Prices) != 1 {
+		t.Fatalf("Expected 1 product with 1 available price, actual: %v", availableProductsForPage.Products)
+	}
+
+	if availableProductsForPage.Products[0].Prices[0].Availability != "in_stock" {
+		t.Fatalf("Expected price of product 'in_stock', actual: %v", availableProductsForPage.Products[0].Prices[0].Availability)
+	}
+
+	for _, price := range productsForPage.Products[0].Prices {
+		err = deleteEntityByID(price.ID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	err = deleteEntityByID(productID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+func createProductWithPrices(product entities.Product, language string, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedProduct, err := json.Marshal(product)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedProduct,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	productID := assigned.Uids["blank-0"]
+
+	err = addOtherLanguageForEntityName(productID, product.Name, language, databaseClient)
+	if err != nil {
+		return productID, err
+	}
+
+	return productID, nil
+}
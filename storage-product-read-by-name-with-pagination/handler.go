@@ -13,6 +13,9 @@ type Request struct {
 
 	CurrentPage,
 	ItemsPerPage int
+
+	// OnlyAvailable means that prices of offers which are out of stock are skipped
+	OnlyAvailable bool
 }
 
 type Response struct{ Message, Data, Error string }
@@ -38,7 +41,9 @@ func Handle(req []byte) string {
 		return string(response)
 	}
 
-	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
+	executor := Executor{
+		Store:         &storage.Store{DatabaseGateway: request.DatabaseGateway},
+		OnlyAvailable: request.OnlyAvailable}
 	companies, err := executor.ReadProductsByNameWithPagination(request.ProductName, request.Language, request.CurrentPage, request.ItemsPerPage)
 	if err != nil {
 		warning := fmt.Sprintf(
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"github.com/hecatoncheir/Storage"
+	"github.com/hecatoncheir/Functions/shared/entities"
 	"log"
 	"os"
 	"text/template"
@@ -16,6 +16,9 @@ type Storage interface {
 
 type Executor struct {
 	Store Storage
+
+	// OnlyAvailable means that prices of offers which are out of stock are skipped
+	OnlyAvailable bool
 }
 
 var logger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
@@ -29,14 +32,16 @@ var (
 )
 
 // ReadProductsByName is a method for get all nodes by product name
-func (executor *Executor) ReadProductsByName(productName, language string) ([]storage.Product, error) {
+func (executor *Executor) ReadProductsByName(productName, language string) ([]entities.Product, error) {
 
 	variables := struct {
-		ProductName string
-		Language    string
+		ProductName   string
+		Language      string
+		OnlyAvailable bool
 	}{
-		ProductName: productName,
-		Language:    language}
+		ProductName:   productName,
+		Language:      language,
+		OnlyAvailable: executor.OnlyAvailable}
 
 	queryTemplate, err := template.New("ReadProductsByName").Parse(`{
 				products(func: regexp(productName@{{.Language}}, /{{.ProductName}}/)) 
@@ -81,10 +86,15 @@ func (executor *Executor) ReadProductsByName(productName, language string) ([]st
 							}
 						}
 					}
-					has_price @filter(eq(priceIsActive, true)) {
+					has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) {
 						uid
 						priceValue
 						priceDateTime
+						priceAvailability
+						priceCurrency
+						priceOldValue
+						priceDiscount
+						priceMaxValue
 						priceIsActive
 						belongs_to_company @filter(eq(companyIsActive, true)) {
 							uid
@@ -98,10 +108,15 @@ func (executor *Executor) ReadProductsByName(productName, language string) ([]st
 							productIri
 							previewImageLink
 							productIsActive
-							has_price @filter(eq(priceIsActive, true)) {
+							has_price @filter(eq(priceIsActive, true){{if .OnlyAvailable}} AND NOT eq(priceAvailability, "out_of_stock"){{end}}) {
 								uid
 								priceValue
 								priceDateTime
+								priceAvailability
+								priceCurrency
+								priceOldValue
+								priceDiscount
+								priceMaxValue
 								priceIsActive
 								belongs_to_company @filter(eq(companyIsActive, true)) {
 									uid
@@ -139,7 +154,7 @@ func (executor *Executor) ReadProductsByName(productName, language string) ([]st
 	}
 
 	type productsInStorage struct {
-		AllProductsFoundedByName []storage.Product `json:"products"`
+		AllProductsFoundedByName []entities.Product `json:"products"`
 	}
 
 	var foundedProducts productsInStorage
@@ -0,0 +1,116 @@
+package function
+
+import (
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestExecutor_ReadProductsByNameWithOnlyAvailablePrices(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productName: string @lang @index(term, trigram) .
+		productIri: string @index(term) .
+		productIsActive: bool @index(bool) .
+		has_price: uid @count .
+		priceValue: float @index(float) .
+		priceDateTime: dateTime @index(hour) .
+		priceIsActive: bool @index(bool) .
+		priceAvailability: string @index(exact) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	Language := "ru"
+	entityTestName := "Test product with availability"
+
+	entityForCreate := entities.Product{
+		Product: storage.Product{
+			Name:     entityTestName,
+			IRI:      "//",
+			IsActive: true},
+		Prices: []entities.Price{
+			{
+				Price:        storage.Price{Value: 10, DateTime: time.Now().UTC(), IsActive: true},
+				Availability: "in_stock"},
+			{
+				Price:        storage.Price{DateTime: time.Now().UTC(), IsActive: true},
+				Availability: "out_of_stock"}}}
+
+	createdEntityID, err := createEntity(entityForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	err = addOtherLanguageForEntityName(createdEntityID, entityTestName, Language, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	foundedEntities, err := executor.ReadProductsByName(entityTestName, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(foundedEntities) != 1 || len(foundedEntities[0].Prices) != 2 {
+		t.Fatalf("Expected 1 entity with 2 prices found in database, actual: %v", foundedEntities)
+	}
+
+	availabilities := map[string]bool{}
+	for _, price := range foundedEntities[0].Prices {
+		availabilities[price.Availability] = true
+	}
+
+	if !availabilities["in_stock"] || !availabilities["out_of_stock"] {
+		t.Fatalf("Expected prices of founded entity with availability, actual: %v", foundedEntities[0].Prices)
+	}
+
+	executor.OnlyAvailable = true
+
+	availableEntities, err := executor.ReadProductsByName(entityTestName, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(availableEntities) != 1 || len(availableEntities[0].Prices) != 1 {
+		t.Fatalf("Expected 1 entity with 1 available price found in database, actual: %v", availableEntities)
+	}
+
+	if availableEntities[0].Prices[0].Availability != "in_stock" {
+		t.Fatalf("Expected price of founded entity 'in_stock', actual: %v", availableEntities[0].Prices[0].Availability)
+	}
+
+	for _, price := range foundedEntities[0].Prices {
+		err = deleteEntityByID(price.ID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	err = deleteEntityByID(createdEntityID, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
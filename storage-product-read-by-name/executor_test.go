@@ -28,6 +28,10 @@ func TestProductCanBeReadByName(t *testing.T) {
 	if productsFromStore[0].Name != nameOfTestedProduct {
 		t.Fatalf("Expected name of product: 'Test product', actual: %v", productsFromStore[0].Name)
 	}
+
+	if len(productsFromStore[0].Prices) != 1 || productsFromStore[0].Prices[0].Availability != "out_of_stock" {
+		t.Fatalf("Expected price of product with availability: 'out_of_stock', actual: %v", productsFromStore[0].Prices)
+	}
 }
 
 type MockStore struct {
@@ -47,7 +51,14 @@ func (store MockStore) Query(request string) (response []byte, err error) {
 				 "previewImageLink": "http://",
 				 "belongs_to_company":[],
 				 "belongs_to_category":[],
-				 "has_price":[]
+				 "has_price":[
+					{
+					   "uid":"0x14",
+					   "priceValue":0,
+					   "priceAvailability":"out_of_stock",
+					   "priceIsActive":true
+					}
+				 ]
 			  },
 			  {  
 				 "uid":"0x13",
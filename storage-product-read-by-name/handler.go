@@ -6,7 +6,13 @@ import (
 	"github.com/hecatoncheir/Storage"
 )
 
-type Request struct{ Language, ProductName, DatabaseGateway string }
+// Request for read products.
+// OnlyAvailable means that prices of offers which are out of stock are skipped.
+type Request struct {
+	Language, ProductName, DatabaseGateway string
+	OnlyAvailable                          bool
+}
+
 type Response struct{ Message, Data, Error string }
 
 // Handle a serverless request
@@ -30,7 +36,9 @@ func Handle(req []byte) string {
 		return string(response)
 	}
 
-	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
+	executor := Executor{
+		Store:         &storage.Store{DatabaseGateway: request.DatabaseGateway},
+		OnlyAvailable: request.OnlyAvailable}
 	companies, err := executor.ReadProductsByName(request.ProductName, request.Language)
 	if err != nil {
 		warning := fmt.Sprintf(
@@ -0,0 +1,123 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"log"
+	"os"
+)
+
+type Storage interface {
+	CreateJSON([]byte) (string, error)
+	AddLanguage(string, string, string) error
+}
+
+type Functions interface {
+	ReadProductsByName(string, string) []storage.Product
+	ReadProductByID(string, string) entities.Product
+}
+
+type Executor struct {
+	Store     Storage
+	Functions Functions
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+var (
+	// ErrProductCanNotBeWithoutID means that the product can't be found in storage for update
+	ErrProductCanNotBeWithoutID = errors.New("product can not be without id")
+
+	// ErrProductByIDNotFound means that the product is not in the database
+	ErrProductByIDNotFound = errors.New("product by id not found")
+
+	// ErrProductPatchIsEmpty means that the patch has no one field for update
+	ErrProductPatchIsEmpty = errors.New("product patch is empty")
+
+	// ErrProductAlreadyExist means that the other product with new name is in the database already
+	ErrProductAlreadyExist = errors.New("product already exist")
+
+	// ErrProductCanNotBeUpdated means that the patch can't be saved to database
+	ErrProductCanNotBeUpdated = errors.New("product can't be updated")
+)
+
+// ProductPatch is a partial update of product, only not nil fields are changed.
+// Names are new names of product by languages, like {"ru": "Смартфон", "en": "Smartphone"}.
+type ProductPatch struct {
+	ID               string
+	IRI              *string
+	PreviewImageLink *string
+	IsActive         *bool
+	Names            map[string]string
+}
+
+// UpdateProduct apply patch to product and return updated product with prices and details,
+// so relations of product, like has_price, are kept
+func (executor *Executor) UpdateProduct(patch ProductPatch, language string) (entities.Product, error) {
+	if patch.ID == "" {
+		ExecutorLogger.Println(ErrProductCanNotBeWithoutID)
+		return entities.Product{}, ErrProductCanNotBeWithoutID
+	}
+
+	existProduct := executor.Functions.ReadProductByID(patch.ID, language)
+	if existProduct.ID == "" {
+		ExecutorLogger.Printf("Product with ID: %v not found", patch.ID)
+		return existProduct, ErrProductByIDNotFound
+	}
+
+	for nameLanguage, name := range patch.Names {
+		// Search by name match part of name, so only product with same name is other product
+		for _, productWithName := range executor.Functions.ReadProductsByName(name, nameLanguage) {
+			if productWithName.Name == name && productWithName.ID != patch.ID {
+				ExecutorLogger.Printf("Product with name: %v exist: %v", name, productWithName)
+				return entities.Product{Product: productWithName}, ErrProductAlreadyExist
+			}
+		}
+	}
+
+	fields := map[string]interface{}{"uid": patch.ID}
+
+	if patch.IRI != nil {
+		fields["productIri"] = *patch.IRI
+	}
+
+	if patch.PreviewImageLink != nil {
+		fields["previewImageLink"] = *patch.PreviewImageLink
+	}
+
+	if patch.IsActive != nil {
+		fields["productIsActive"] = *patch.IsActive
+	}
+
+	if len(fields) == 1 && len(patch.Names) == 0 {
+		return existProduct, ErrProductPatchIsEmpty
+	}
+
+	if len(fields) > 1 {
+		encodedFields, err := json.Marshal(fields)
+		if err != nil {
+			return existProduct, ErrProductCanNotBeUpdated
+		}
+
+		// Set of fields with uid of product change existing product
+		_, err = executor.Store.CreateJSON(encodedFields)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return existProduct, ErrProductCanNotBeUpdated
+		}
+	}
+
+	for nameLanguage, name := range patch.Names {
+		err := executor.Store.AddLanguage(patch.ID, "productName", "\""+name+"\""+"@"+nameLanguage)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return existProduct, ErrProductCanNotBeUpdated
+		}
+	}
+
+	updatedProduct := executor.Functions.ReadProductByID(patch.ID, language)
+
+	return updatedProduct, nil
+}
@@ -0,0 +1,189 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestIntegration_UpdateProduct(t *testing.T) {
+	t.Skip("Database and FAAS must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	FunctionsGateway := os.Getenv("FunctionsGateway")
+	if FunctionsGateway == "" {
+		FunctionsGateway = "http://localhost:8080/function"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		productName: string @lang @index(term) .
+		productIri: string @index(term) .
+		previewImageLink: string @index(term) .
+		productIsActive: bool @index(bool) .
+		priceValue: float @index(float) .
+		priceIsActive: bool @index(bool) .
+		has_price: uid @count .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	Language := "ru"
+	ProductName := "Test product for update"
+
+	productForCreate := storage.Product{
+		IRI:      "http://shop/products/1",
+		IsActive: true,
+		Prices:   []storage.Price{{Value: 100, IsActive: true}}}
+
+	createdProductID, err := createProduct(productForCreate, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer func() {
+		err = deleteProductByID(createdProductID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	err = addLanguageForProductName(createdProductID, ProductName, Language, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  DatabaseGateway,
+			FunctionsGateway: FunctionsGateway}}
+
+	_, err = executor.UpdateProduct(ProductPatch{ID: createdProductID}, Language)
+	if err != ErrProductPatchIsEmpty {
+		t.Fatalf("Expected: %v, actual: %v", ErrProductPatchIsEmpty, err)
+	}
+
+	iri := "http://shop/products/2"
+
+	patch := ProductPatch{
+		ID:    createdProductID,
+		IRI:   &iri,
+		Names: map[string]string{"en": "Test product for update in english"}}
+
+	updatedProduct, err := executor.UpdateProduct(patch, Language)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updatedProduct.ID != createdProductID || updatedProduct.IRI != iri || updatedProduct.Name != ProductName {
+		t.Fatalf("Expected updated product with new IRI, actual: %v", updatedProduct)
+	}
+
+	if len(updatedProduct.Prices) != 1 || updatedProduct.Prices[0].Value != 100 {
+		t.Fatalf("Expected price of product is kept, actual: %v", updatedProduct.Prices)
+	}
+
+	productInEnglish := executor.Functions.ReadProductByID(createdProductID, "en")
+
+	if productInEnglish.Name != "Test product for update in english" {
+		t.Fatalf("Expected name of product in english, actual: %v", productInEnglish.Name)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createProduct(productForCreate storage.Product, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedProduct, err := json.Marshal(productForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedProduct,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func addLanguageForProductName(productID, name, language string, databaseClient *dataBaseClient.Dgraph) error {
+	forProductNamePredicate := fmt.Sprintf(`<%s> <productName> %s .`, productID, "\""+name+"\""+"@"+language)
+
+	mutation := &dataBaseAPI.Mutation{
+		SetNquads: []byte(forProductNamePredicate),
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	_, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteProductByID(productID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteProductData, err := json.Marshal(map[string]string{"uid": productID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteProductData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"testing"
+)
+
+// ------------------------------------------------------------------------------------------------------
+func TestProductCanBeUpdated(t *testing.T) {
+	iri := "http://shop/products/1"
+	isActive := false
+
+	patch := ProductPatch{
+		ID:       "0x12",
+		IRI:      &iri,
+		IsActive: &isActive,
+		Names:    map[string]string{"en": "Updated product"}}
+
+	store := &MockStorage{}
+
+	executor := Executor{
+		Functions: ProductsFAASFunctions{},
+		Store:     store}
+
+	updatedProduct, err := executor.UpdateProduct(patch, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if updatedProduct.ID != "0x12" {
+		t.Errorf("Expect: %v, but got: %v", "0x12", updatedProduct.ID)
+	}
+
+	if len(updatedProduct.Prices) != 1 || updatedProduct.Prices[0].Availability != "in_stock" ||
+		updatedProduct.Description != "Test description" {
+		t.Errorf("Expect prices and details of updated product, but got: %v", updatedProduct)
+	}
+
+	var fields map[string]interface{}
+
+	err = json.Unmarshal(store.SetJSON, &fields)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if fields["uid"] != "0x12" || fields["productIri"] != iri || fields["productIsActive"] != false {
+		t.Errorf("Expect patch of IRI and IsActive, but got: %v", fields)
+	}
+
+	if _, ok := fields["previewImageLink"]; ok {
+		t.Errorf("Expect previewImageLink is not changed, but got: %v", fields["previewImageLink"])
+	}
+
+	if store.Language != "\"Updated product\"@en" {
+		t.Errorf("Expect: %v, but got: %v", "\"Updated product\"@en", store.Language)
+	}
+}
+
+/// Mock FAAS functions
+type ProductsFAASFunctions struct{}
+
+func (functions ProductsFAASFunctions) ReadProductsByName(productName, language string) []storage.Product {
+	if productName == "Other product" {
+		return []storage.Product{{ID: "0x13", Name: "Other product", IsActive: true}}
+	}
+
+	if productName == "Product" {
+		return []storage.Product{{ID: "0x13", Name: "Other product", IsActive: true}}
+	}
+
+	if productName == "Test product" {
+		return []storage.Product{{ID: "0x12", Name: "Test product", IsActive: true}}
+	}
+
+	return []storage.Product{}
+}
+
+func (functions ProductsFAASFunctions) ReadProductByID(productID, language string) entities.Product {
+	if productID != "0x12" {
+		return entities.Product{}
+	}
+
+	return entities.Product{
+		Product:     storage.Product{ID: productID, Name: "Test product"},
+		Prices:      []entities.Price{{Price: storage.Price{ID: "0x14", Value: 100}, Availability: "in_stock"}},
+		Description: "Test description"}
+}
+
+/// Mock Storage
+type MockStorage struct {
+	SetJSON  []byte
+	Language string
+}
+
+func (store *MockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	store.SetJSON = setJson
+	return "", nil
+}
+
+func (store *MockStorage) AddLanguage(subject, predicate, object string) error {
+	store.Language = object
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductCanBeRenamedToOwnName(t *testing.T) {
+	patch := ProductPatch{ID: "0x12", Names: map[string]string{"ru": "Test product"}}
+
+	store := &MockStorage{}
+
+	executor := Executor{Functions: ProductsFAASFunctions{}, Store: store}
+
+	_, err := executor.UpdateProduct(patch, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.SetJSON != nil {
+		t.Errorf("Expect only name is changed, but got: %s", store.SetJSON)
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductCanBeRenamedToPartOfNameOfOtherProduct(t *testing.T) {
+	patch := ProductPatch{ID: "0x12", Names: map[string]string{"ru": "Product"}}
+
+	store := &MockStorage{}
+
+	executor := Executor{Functions: ProductsFAASFunctions{}, Store: store}
+
+	_, err := executor.UpdateProduct(patch, "ru")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Language != "\"Product\"@ru" {
+		t.Errorf("Expect: %v, but got: %v", "\"Product\"@ru", store.Language)
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductCanNotBeRenamedToNameOfOtherProduct(t *testing.T) {
+	patch := ProductPatch{ID: "0x12", Names: map[string]string{"ru": "Other product"}}
+
+	executor := Executor{Functions: ProductsFAASFunctions{}, Store: &MockStorage{}}
+
+	existProduct, err := executor.UpdateProduct(patch, "ru")
+	if err != ErrProductAlreadyExist {
+		t.Fatalf("Expect: %v, but got: %v", ErrProductAlreadyExist, err)
+	}
+
+	if existProduct.ID != "0x13" {
+		t.Errorf("Expect: %v, but got: %v", "0x13", existProduct.ID)
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------
+func TestProductCanNotBeUpdated(t *testing.T) {
+	link := "http://shop/images/1.jpg"
+
+	executor := Executor{Functions: ProductsFAASFunctions{}, Store: ErrorMockStorage{}}
+
+	_, err := executor.UpdateProduct(ProductPatch{ID: "0x12", PreviewImageLink: &link}, "ru")
+	if err != ErrProductCanNotBeUpdated {
+		t.Errorf("Expect: %v, but got: %v", ErrProductCanNotBeUpdated, err)
+	}
+
+	_, err = executor.UpdateProduct(ProductPatch{ID: "0x12"}, "ru")
+	if err != ErrProductPatchIsEmpty {
+		t.Errorf("Expect: %v, but got: %v", ErrProductPatchIsEmpty, err)
+	}
+
+	_, err = executor.UpdateProduct(ProductPatch{ID: "0x14", PreviewImageLink: &link}, "ru")
+	if err != ErrProductByIDNotFound {
+		t.Errorf("Expect: %v, but got: %v", ErrProductByIDNotFound, err)
+	}
+
+	_, err = executor.UpdateProduct(ProductPatch{PreviewImageLink: &link}, "ru")
+	if err != ErrProductCanNotBeWithoutID {
+		t.Errorf("Expect: %v, but got: %v", ErrProductCanNotBeWithoutID, err)
+	}
+}
+
+type ErrorMockStorage struct{}
+
+func (store ErrorMockStorage) CreateJSON(setJson []byte) (uid string, err error) {
+	return "", errors.New("")
+}
+
+func (store ErrorMockStorage) AddLanguage(subject, predicate, object string) error {
+	return nil
+}
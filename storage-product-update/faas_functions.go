@@ -0,0 +1,111 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hecatoncheir/Functions/shared/entities"
+	"github.com/hecatoncheir/Storage"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+)
+
+var FAASLogger = log.New(os.Stdout, "FAASFunctions: ", log.Lshortfile)
+
+type FAASFunctions struct {
+	FunctionsGateway string
+	DatabaseGateway  string
+}
+
+func (functions FAASFunctions) ReadProductsByName(productName, language string) []storage.Product {
+	body := struct {
+		Language        string
+		ProductName     string
+		DatabaseGateway string
+	}{
+		Language:        language,
+		ProductName:     productName,
+		DatabaseGateway: functions.DatabaseGateway}
+
+	response, err := functions.call("storage-product-read-by-name", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil
+	}
+
+	var existProducts []storage.Product
+
+	err = json.Unmarshal([]byte(response.Data), &existProducts)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil
+	}
+
+	return existProducts
+}
+
+func (functions FAASFunctions) ReadProductByID(productID, language string) entities.Product {
+	body := struct {
+		Language        string
+		ProductID       string
+		DatabaseGateway string
+	}{
+		Language:        language,
+		ProductID:       productID,
+		DatabaseGateway: functions.DatabaseGateway}
+
+	response, err := functions.call("storage-product-read-by-id", body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.Product{}
+	}
+
+	var existProduct entities.Product
+
+	err = json.Unmarshal([]byte(response.Data), &existProduct)
+	if err != nil {
+		FAASLogger.Println(err)
+		return entities.Product{}
+	}
+
+	return existProduct
+}
+
+// call send body to function and decode response of it
+func (functions FAASFunctions) call(functionName string, body interface{}) (Response, error) {
+	functionPath := fmt.Sprintf(
+		"%v/%v", functions.FunctionsGateway, functionName)
+
+	encodedResponse := Response{}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	defer response.Body.Close()
+
+	decodedResponse, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	if encodedResponse.Error != "" {
+		return encodedResponse, errors.New(encodedResponse.Error)
+	}
+
+	return encodedResponse, nil
+}
@@ -0,0 +1,164 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"regexp"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+	AddLanguage(string, string, string) error
+}
+
+type Functions interface {
+	ReadEntitiesByName(string, string, string) []NamedEntity
+}
+
+type Executor struct {
+	Store     Storage
+	Functions Functions
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// NamePredicates are predicates of names of entities which can be translated
+var NamePredicates = map[string]string{
+	"category": "categoryName",
+	"company":  "companyName",
+	"city":     "cityName",
+	"product":  "productName"}
+
+// languagePattern match code of language, like "ru" or "en-US"
+var languagePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]+)*$`)
+
+var (
+	// ErrEntityIsUnknown means that entity is not one of NamePredicates
+	ErrEntityIsUnknown = errors.New("entity is unknown")
+
+	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
+	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+
+	// ErrLanguageIsNotValid means that language of translation is not a code of language
+	ErrLanguageIsNotValid = errors.New("language is not valid")
+
+	// ErrTranslationCanNotBeWithoutName means that name of translation is empty
+	ErrTranslationCanNotBeWithoutName = errors.New("translation can not be without name")
+
+	// ErrEntityDoesNotExist means than the entity with name predicate does not exist in database
+	ErrEntityDoesNotExist = errors.New("entity does not exist")
+
+	// ErrEntityAlreadyExist means that the other entity with name of translation is in the database already
+	ErrEntityAlreadyExist = errors.New("entity already exist")
+
+	// ErrTranslationCanNotBeAdded means that the translation can't be added to database
+	ErrTranslationCanNotBeAdded = errors.New("translation can not be added")
+)
+
+// Translation is a name of entity in language
+type Translation struct {
+	Entity, EntityID, Language, Name string
+}
+
+// NamedEntity is an entity found by name
+type NamedEntity struct {
+	ID, Name string
+}
+
+// AddTranslation add name in language to existing entity, name of entity in this language is replaced.
+// Translation is not added if other entity has the same name in this language.
+func (executor *Executor) AddTranslation(translation Translation) (Translation, error) {
+	predicate, ok := NamePredicates[translation.Entity]
+	if !ok {
+		ExecutorLogger.Println(ErrEntityIsUnknown)
+		return translation, ErrEntityIsUnknown
+	}
+
+	if translation.EntityID == "" {
+		ExecutorLogger.Println(ErrEntityCanNotBeWithoutID)
+		return translation, ErrEntityCanNotBeWithoutID
+	}
+
+	if !languagePattern.MatchString(translation.Language) {
+		ExecutorLogger.Println(ErrLanguageIsNotValid)
+		return translation, ErrLanguageIsNotValid
+	}
+
+	if translation.Name == "" {
+		ExecutorLogger.Println(ErrTranslationCanNotBeWithoutName)
+		return translation, ErrTranslationCanNotBeWithoutName
+	}
+
+	exists, err := executor.entityExists(translation.EntityID, predicate)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translation, ErrTranslationCanNotBeAdded
+	}
+
+	if !exists {
+		return translation, ErrEntityDoesNotExist
+	}
+
+	// Search by name match part of name, so only entity with same name is other entity
+	for _, entityWithName := range executor.Functions.ReadEntitiesByName(translation.Entity, translation.Name, translation.Language) {
+		if entityWithName.Name == translation.Name && entityWithName.ID != translation.EntityID {
+			ExecutorLogger.Printf("%v with name: %v exist: %v", translation.Entity, translation.Name, entityWithName)
+			return translation, ErrEntityAlreadyExist
+		}
+	}
+
+	err = executor.Store.AddLanguage(translation.EntityID, predicate, "\""+translation.Name+"\""+"@"+translation.Language)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translation, ErrTranslationCanNotBeAdded
+	}
+
+	return translation, nil
+}
+
+// entityExists return true if node by ID has name predicate
+func (executor *Executor) entityExists(entityID, predicate string) (bool, error) {
+	variables := struct {
+		EntityID  string
+		Predicate string
+	}{
+		EntityID:  entityID,
+		Predicate: predicate}
+
+	queryTemplate, err := template.New("EntityExists").Parse(`{
+				entities(func: uid("{{.EntityID}}")) @filter(has({{.Predicate}})) {
+					uid
+				}
+			}`)
+	if err != nil {
+		return false, err
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		return false, err
+	}
+
+	var foundedEntities struct {
+		Entities []struct {
+			ID string `json:"uid"`
+		} `json:"entities"`
+	}
+
+	err = json.Unmarshal(response, &foundedEntities)
+	if err != nil {
+		return false, err
+	}
+
+	return len(foundedEntities.Entities) > 0, nil
+}
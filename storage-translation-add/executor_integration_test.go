@@ -0,0 +1,214 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestIntegration_AddTranslation(t *testing.T) {
+	t.Skip("Database and FAAS must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	FunctionsGateway := os.Getenv("FunctionsGateway")
+	if FunctionsGateway == "" {
+		FunctionsGateway = "http://localhost:8080/function"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		categoryName: string @lang @index(term) .
+		categoryIsActive: bool @index(bool) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  DatabaseGateway,
+			FunctionsGateway: FunctionsGateway}}
+
+	_, err = executor.AddTranslation(Translation{Entity: "category", EntityID: "0x12", Language: "en", Name: "Smartphones"})
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+
+	createdCategoryID, err := createCategory("Смартфоны для перевода", "ru", databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer func() {
+		err = deleteCategoryByID(createdCategoryID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	otherCategoryID, err := createCategory("Other smartphones for translation", "en", databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer func() {
+		err = deleteCategoryByID(otherCategoryID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	translation := Translation{Entity: "category", EntityID: createdCategoryID, Language: "en", Name: "Smartphones for translation"}
+
+	_, err = executor.AddTranslation(translation)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	name, err := readCategoryName(createdCategoryID, "en", databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if name != translation.Name {
+		t.Fatalf("Expected name of category in english: %v, actual: %v", translation.Name, name)
+	}
+
+	translation.Name = "Other smartphones for translation"
+
+	_, err = executor.AddTranslation(translation)
+	if err != ErrEntityAlreadyExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityAlreadyExist, err)
+	}
+}
+
+func readCategoryName(categoryID, language string, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	query := fmt.Sprintf(`{
+				categories(func: uid("%v")) {
+					categoryName: categoryName@%v
+				}
+			}`, categoryID, language)
+
+	transaction := databaseClient.NewTxn()
+	response, err := transaction.Query(context.Background(), query)
+	if err != nil {
+		return "", err
+	}
+
+	var foundedCategories struct {
+		Categories []storage.Category `json:"categories"`
+	}
+
+	err = json.Unmarshal(response.GetJson(), &foundedCategories)
+	if err != nil {
+		return "", err
+	}
+
+	if len(foundedCategories.Categories) == 0 {
+		return "", nil
+	}
+
+	return foundedCategories.Categories[0].Name, nil
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createCategory(name, language string, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedCategory, err := json.Marshal(storage.Category{IsActive: true})
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedCategory,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	err = addLanguageForCategoryName(uid, name, language, databaseClient)
+	if err != nil {
+		return uid, err
+	}
+
+	return uid, nil
+}
+
+func addLanguageForCategoryName(categoryID, name, language string, databaseClient *dataBaseClient.Dgraph) error {
+	forCategoryNamePredicate := fmt.Sprintf(`<%s> <categoryName> %s .`, categoryID, "\""+name+"\""+"@"+language)
+
+	mutation := &dataBaseAPI.Mutation{
+		SetNquads: []byte(forCategoryNamePredicate),
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	_, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteCategoryByID(categoryID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteCategoryData, err := json.Marshal(map[string]string{"uid": categoryID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteCategoryData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
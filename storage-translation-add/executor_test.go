@@ -0,0 +1,129 @@
+package function
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationCanBeAdded(t *testing.T) {
+	store := &MockStore{}
+
+	executor := Executor{Store: store, Functions: MockFunctions{}}
+
+	translation := Translation{Entity: "category", EntityID: "0x12", Language: "en", Name: "Phones"}
+
+	addedTranslation, err := executor.AddTranslation(translation)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if addedTranslation != translation {
+		t.Errorf("Expected: %v, actual: %v", translation, addedTranslation)
+	}
+
+	if !strings.Contains(store.Request, "has(categoryName)") {
+		t.Errorf("Expected query of category, actual: %v", store.Request)
+	}
+
+	if store.Subject != "0x12" || store.Predicate != "categoryName" || store.Object != `"Phones"@en` {
+		t.Errorf("Expected name in english, actual: %v %v %v", store.Subject, store.Predicate, store.Object)
+	}
+}
+
+type MockStore struct {
+	Request, Subject, Predicate, Object string
+}
+
+func (store *MockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+	return []byte(`{"entities": [{"uid": "0x12"}]}`), nil
+}
+
+func (store *MockStore) AddLanguage(subject, predicate, object string) error {
+	store.Subject, store.Predicate, store.Object = subject, predicate, object
+	return nil
+}
+
+type MockFunctions struct {
+	Entities []NamedEntity
+}
+
+func (functions MockFunctions) ReadEntitiesByName(entity, name, language string) []NamedEntity {
+	return functions.Entities
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationCanNotBeAddedWithNameOfOtherEntity(t *testing.T) {
+	store := &MockStore{}
+
+	executor := Executor{
+		Store: store,
+		Functions: MockFunctions{Entities: []NamedEntity{
+			{ID: "0x13", Name: "Phones and tablets"},
+			{ID: "0x14", Name: "Phones"}}}}
+
+	_, err := executor.AddTranslation(Translation{Entity: "category", EntityID: "0x12", Language: "en", Name: "Phones"})
+	if err != ErrEntityAlreadyExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityAlreadyExist, err)
+	}
+
+	if store.Object != "" {
+		t.Errorf("Expected name is not added, actual: %v", store.Object)
+	}
+
+	executor.Functions = MockFunctions{Entities: []NamedEntity{
+		{ID: "0x12", Name: "Phones"},
+		{ID: "0x13", Name: "Phones and tablets"}}}
+
+	_, err = executor.AddTranslation(Translation{Entity: "category", EntityID: "0x12", Language: "en", Name: "Phones"})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if store.Object != `"Phones"@en` {
+		t.Errorf("Expected name of same entity is added again, actual: %v", store.Object)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationCanNotBeAddedToNotExistingEntity(t *testing.T) {
+	executor := Executor{Store: EmptyMockStore{}, Functions: MockFunctions{}}
+
+	_, err := executor.AddTranslation(Translation{Entity: "city", EntityID: "0x12", Language: "en", Name: "Moscow"})
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+}
+
+type EmptyMockStore struct{}
+
+func (store EmptyMockStore) Query(request string) (response []byte, err error) {
+	return []byte(`{"entities": []}`), nil
+}
+
+func (store EmptyMockStore) AddLanguage(subject, predicate, object string) error {
+	return errors.New("entity does not exist")
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationCanNotBeAddedWithoutRequiredFields(t *testing.T) {
+	executor := Executor{Store: &MockStore{}, Functions: MockFunctions{}}
+
+	tests := []struct {
+		translation Translation
+		err         error
+	}{
+		{Translation{Entity: "price", EntityID: "0x12", Language: "en", Name: "Price"}, ErrEntityIsUnknown},
+		{Translation{Entity: "company", Language: "en", Name: "Shop"}, ErrEntityCanNotBeWithoutID},
+		{Translation{Entity: "company", EntityID: "0x12", Language: "en\"", Name: "Shop"}, ErrLanguageIsNotValid},
+		{Translation{Entity: "product", EntityID: "0x12", Language: "en"}, ErrTranslationCanNotBeWithoutName}}
+
+	for _, test := range tests {
+		_, err := executor.AddTranslation(test.translation)
+		if err != test.err {
+			t.Errorf("Expected: %v, actual: %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var FAASLogger = log.New(os.Stdout, "FAASFunctions: ", log.Lshortfile)
+
+type FAASFunctions struct {
+	FunctionsGateway string
+	DatabaseGateway  string
+}
+
+// ReadEntitiesByName call read by name function of entity, like storage-category-read-by-name
+func (functions FAASFunctions) ReadEntitiesByName(entity, name, language string) []NamedEntity {
+	// Name of entity in request of function is like CategoryName
+	body := map[string]string{
+		"Language":                     language,
+		strings.Title(entity) + "Name": name,
+		"DatabaseGateway":              functions.DatabaseGateway}
+
+	response, err := functions.call(fmt.Sprintf("storage-%v-read-by-name", entity), body)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil
+	}
+
+	var existEntities []map[string]interface{}
+
+	err = json.Unmarshal([]byte(response.Data), &existEntities)
+	if err != nil {
+		FAASLogger.Println(err)
+		return nil
+	}
+
+	var namedEntities []NamedEntity
+	for _, existEntity := range existEntities {
+		id, _ := existEntity["uid"].(string)
+		entityName, _ := existEntity[NamePredicates[entity]].(string)
+		namedEntities = append(namedEntities, NamedEntity{ID: id, Name: entityName})
+	}
+
+	return namedEntities
+}
+
+// call send body to function and decode response of it
+func (functions FAASFunctions) call(functionName string, body interface{}) (Response, error) {
+	functionPath := fmt.Sprintf(
+		"%v/%v", functions.FunctionsGateway, functionName)
+
+	encodedResponse := Response{}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	response, err := http.Post(functionPath, "application/json", bytes.NewBuffer(encodedBody))
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	defer response.Body.Close()
+
+	decodedResponse, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	err = json.Unmarshal(decodedResponse, &encodedResponse)
+	if err != nil {
+		return encodedResponse, err
+	}
+
+	if encodedResponse.Error != "" {
+		return encodedResponse, errors.New(encodedResponse.Error)
+	}
+
+	return encodedResponse, nil
+}
@@ -0,0 +1,86 @@
+package function
+
+import (
+	"encoding/json"
+	"github.com/hecatoncheir/Storage"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFAASFunctions_ReadEntitiesByName(t *testing.T) {
+
+	LanguageForTest := "en"
+	CategoryNameForTest := "Phones"
+	DatabaseGatewayForTest := "http://"
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodedBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Read body of request error: %v", err)
+		}
+
+		var responseBodyEncoded map[string]string
+		err = json.Unmarshal(encodedBody, &responseBodyEncoded)
+		if err != nil {
+			t.Errorf("Unmarshal body of request error: %v", err)
+		}
+
+		if responseBodyEncoded["Language"] != LanguageForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", LanguageForTest, responseBodyEncoded["Language"])
+		}
+
+		if responseBodyEncoded["CategoryName"] != CategoryNameForTest {
+			t.Fatalf("Expected: \"%v\", but got: %v", CategoryNameForTest, responseBodyEncoded["CategoryName"])
+		}
+
+		if responseBodyEncoded["DatabaseGateway"] != DatabaseGatewayForTest {
+			t.Fatalf(
+				"Expected: \"%v\", but got: %v", DatabaseGatewayForTest, responseBodyEncoded["DatabaseGateway"])
+		}
+
+		existedCategoriesInStorage := []storage.Category{
+			{
+				ID:       "0x12",
+				Name:     "Phones",
+				IsActive: true},
+			{
+				ID:       "0x13",
+				Name:     "Phones and tablets",
+				IsActive: true}}
+
+		encodedExistedCategoriesInStorage, err := json.Marshal(existedCategoriesInStorage)
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		encodedResponse, err := json.Marshal(Response{Data: string(encodedExistedCategoriesInStorage)})
+		if err != nil {
+			t.Error(err.Error())
+		}
+
+		_, err = io.WriteString(w, string(encodedResponse))
+		if err != nil {
+			t.Error(err.Error())
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/storage-category-read-by-name", testHandler)
+
+	testServer := httptest.NewServer(mux)
+	defer testServer.Close()
+
+	faas := &FAASFunctions{FunctionsGateway: testServer.URL, DatabaseGateway: DatabaseGatewayForTest}
+	categories := faas.ReadEntitiesByName("category", CategoryNameForTest, LanguageForTest)
+
+	if len(categories) != 2 {
+		t.Fatalf("Expected 2 categories, but got: %v", len(categories))
+	}
+
+	if categories[0] != (NamedEntity{ID: "0x12", Name: "Phones"}) {
+		t.Fatalf("Expected category with id and name, but got: %v", categories[0])
+	}
+}
@@ -0,0 +1,75 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Storage"
+)
+
+type Request struct {
+	DatabaseGateway,
+	FunctionsGateway string
+	Translation Translation
+}
+
+type Response struct{ Message, Error, Data string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{
+		Store: &storage.Store{DatabaseGateway: request.DatabaseGateway},
+		Functions: &FAASFunctions{
+			DatabaseGateway:  request.DatabaseGateway,
+			FunctionsGateway: request.FunctionsGateway}}
+	translation, err := executor.AddTranslation(request.Translation)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"AddTranslation error: %v", err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedTranslation, err := json.Marshal(translation)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal translation error: %v. Error: %v", translation, err)
+
+		errorResponse := Response{Error: err.Error(), Message: warning, Data: string(req)}
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedTranslation)}
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}
@@ -0,0 +1,121 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// NamePredicates are predicates of names of entities which can be translated
+var NamePredicates = map[string]string{
+	"category": "categoryName",
+	"company":  "companyName",
+	"city":     "cityName",
+	"product":  "productName"}
+
+var (
+	// ErrEntityIsUnknown means that entity is not one of NamePredicates
+	ErrEntityIsUnknown = errors.New("entity is unknown")
+
+	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
+	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+
+	// ErrTranslationsCanNotBeFound means that the translations can't be found in database
+	ErrTranslationsCanNotBeFound = errors.New("translations can not be found")
+
+	// ErrEntityDoesNotExist means than the entity with name predicate does not exist in database
+	ErrEntityDoesNotExist = errors.New("entity does not exist")
+)
+
+// Translations are names of entity by languages, like {"ru": "Смартфоны", "en": "Smartphones"}
+type Translations struct {
+	Entity, EntityID string
+	Names            map[string]string
+}
+
+// ReadTranslations return names of entity in all languages
+func (executor *Executor) ReadTranslations(entity, entityID string) (Translations, error) {
+	translations := Translations{Entity: entity, EntityID: entityID, Names: map[string]string{}}
+
+	predicate, ok := NamePredicates[entity]
+	if !ok {
+		ExecutorLogger.Println(ErrEntityIsUnknown)
+		return translations, ErrEntityIsUnknown
+	}
+
+	if entityID == "" {
+		ExecutorLogger.Println(ErrEntityCanNotBeWithoutID)
+		return translations, ErrEntityCanNotBeWithoutID
+	}
+
+	variables := struct {
+		EntityID  string
+		Predicate string
+	}{
+		EntityID:  entityID,
+		Predicate: predicate}
+
+	queryTemplate, err := template.New("ReadTranslations").Parse(`{
+				entities(func: uid("{{.EntityID}}")) @filter(has({{.Predicate}})) {
+					uid
+					{{.Predicate}}@*
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translations, ErrTranslationsCanNotBeFound
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translations, ErrTranslationsCanNotBeFound
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translations, ErrTranslationsCanNotBeFound
+	}
+
+	var foundedEntities struct {
+		Entities []map[string]interface{} `json:"entities"`
+	}
+
+	err = json.Unmarshal(response, &foundedEntities)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return translations, ErrTranslationsCanNotBeFound
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return translations, ErrEntityDoesNotExist
+	}
+
+	// Names in languages are returned as "categoryName@en", name without language is skipped
+	for key, value := range foundedEntities.Entities[0] {
+		name, isText := value.(string)
+		if !isText || !strings.HasPrefix(key, predicate+"@") {
+			continue
+		}
+
+		translations.Names[strings.TrimPrefix(key, predicate+"@")] = name
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,157 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func TestIntegration_ReadTranslations(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		categoryName: string @lang @index(term) .
+		categoryIsActive: bool @index(bool) .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	FakeCategoryID := "0x12"
+
+	_, err = executor.ReadTranslations("category", FakeCategoryID)
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+
+	createdCategoryID, err := createCategory("Смартфоны", "ru", databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	defer func() {
+		err = deleteCategoryByID(createdCategoryID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	err = addLanguageForCategoryName(createdCategoryID, "Smartphones", "en", databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	translations, err := executor.ReadTranslations("category", createdCategoryID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(translations.Names) != 2 || translations.Names["ru"] != "Смартфоны" || translations.Names["en"] != "Smartphones" {
+		t.Fatalf("Expected names of category in russian and english, actual: %v", translations.Names)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createCategory(name, language string, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedCategory, err := json.Marshal(storage.Category{IsActive: true})
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedCategory,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	err = addLanguageForCategoryName(uid, name, language, databaseClient)
+	if err != nil {
+		return uid, err
+	}
+
+	return uid, nil
+}
+
+func addLanguageForCategoryName(categoryID, name, language string, databaseClient *dataBaseClient.Dgraph) error {
+	forCategoryNamePredicate := fmt.Sprintf(`<%s> <categoryName> %s .`, categoryID, "\""+name+"\""+"@"+language)
+
+	mutation := &dataBaseAPI.Mutation{
+		SetNquads: []byte(forCategoryNamePredicate),
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	_, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteCategoryByID(categoryID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteCategoryData, err := json.Marshal(map[string]string{"uid": categoryID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteCategoryData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package function
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationsCanBeRead(t *testing.T) {
+	store := &MockStore{}
+
+	executor := Executor{Store: store}
+
+	translations, err := executor.ReadTranslations("company", "0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if !strings.Contains(store.Request, "companyName@*") {
+		t.Errorf("Expected query of all languages of name, actual: %v", store.Request)
+	}
+
+	if len(translations.Names) != 2 {
+		t.Fatalf("Expected names in 2 languages, actual: %v", translations.Names)
+	}
+
+	if translations.Names["ru"] != "Магазин" || translations.Names["en"] != "Shop" {
+		t.Errorf("Expected names of company, actual: %v", translations.Names)
+	}
+}
+
+type MockStore struct {
+	Request string
+}
+
+func (store *MockStore) Query(request string) (response []byte, err error) {
+	store.Request = request
+
+	resp := `
+		{
+		   "entities":[
+			  {
+				 "uid":"0x12",
+				 "companyName":"Default name",
+				 "companyName@ru":"Магазин",
+				 "companyName@en":"Shop"
+			  }
+		   ]
+		}
+	`
+
+	return []byte(resp), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestTranslationsCanNotBeReadOfNotExistingEntity(t *testing.T) {
+	executor := Executor{Store: EmptyMockStore{}}
+
+	_, err := executor.ReadTranslations("product", "0x12")
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+
+	_, err = executor.ReadTranslations("price", "0x12")
+	if err != ErrEntityIsUnknown {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityIsUnknown, err)
+	}
+}
+
+type EmptyMockStore struct{}
+
+func (store EmptyMockStore) Query(request string) (response []byte, err error) {
+	return []byte(`{"entities": []}`), nil
+}
@@ -0,0 +1,221 @@
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hecatoncheir/Functions/shared/mutation"
+	"log"
+	"os"
+	"sort"
+	"text/template"
+	"time"
+)
+
+type Storage interface {
+	Query(string) ([]byte, error)
+}
+
+type Executor struct {
+	Store Storage
+}
+
+var ExecutorLogger = log.New(os.Stdout, "Executor: ", log.Lshortfile)
+
+// versioned is predicates of entity which is updated with versions
+type versioned struct {
+	Filter, VersionPredicate, UpdatedAtPredicate string
+	Predicates                                   string
+}
+
+// VersionedEntities are entities which keep versions by update functions
+var VersionedEntities = map[string]versioned{
+	"instruction": {
+		Filter:             "instructionLanguage",
+		VersionPredicate:   "instructionVersion",
+		UpdatedAtPredicate: "instructionUpdatedAt",
+		Predicates: `instructionIsActive
+					has_page {
+						uid
+						pageInstructionVersion
+					}`},
+	"pageInstruction": {
+		Filter:             "path",
+		VersionPredicate:   "pageInstructionVersion",
+		UpdatedAtPredicate: "pageInstructionUpdatedAt",
+		Predicates: `path
+					pageInPaginationSelector
+					pageParamPath
+					cityParamPath
+					cityInCookieKey
+					cityIdForCookie
+					itemSelector
+					nameOfItemSelector
+					linkOfItemSelector
+					previewImageOfSelector
+					priceOfItemSelector
+					oldPriceOfItemSelector
+					availabilityOfItemSelector
+					format
+					paginationStrategies
+					lastPageSelector
+					nextPageSelector
+					totalItemsSelector
+					itemsPerPage`}}
+
+var (
+	// ErrEntityIsUnknown means that entity is not one of VersionedEntities
+	ErrEntityIsUnknown = errors.New("entity is unknown")
+
+	// ErrEntityCanNotBeWithoutID means that entity can't be found in storage for make some operation
+	ErrEntityCanNotBeWithoutID = errors.New("entity can not be without id")
+
+	// ErrEntityDoesNotExist means than the entity does not exist in database
+	ErrEntityDoesNotExist = errors.New("entity does not exist")
+
+	// ErrVersionsCanNotBeFound means that the versions can't be found in database
+	ErrVersionsCanNotBeFound = errors.New("versions can not be found")
+
+	// ErrVersionIsNotFound means that no version of entity was active at time
+	ErrVersionIsNotFound = errors.New("version is not found")
+)
+
+// ReadVersions return all versions of entity ordered by number, last one is current version
+// which is active from time of update of entity without ActiveUntil
+func (executor *Executor) ReadVersions(entity, entityID string) ([]mutation.Version, error) {
+	predicates, ok := VersionedEntities[entity]
+	if !ok {
+		ExecutorLogger.Println(ErrEntityIsUnknown)
+		return nil, ErrEntityIsUnknown
+	}
+
+	if entityID == "" {
+		ExecutorLogger.Println(ErrEntityCanNotBeWithoutID)
+		return nil, ErrEntityCanNotBeWithoutID
+	}
+
+	variables := struct {
+		EntityID string
+		versioned
+	}{
+		EntityID:  entityID,
+		versioned: predicates}
+
+	queryTemplate, err := template.New("ReadVersions").Parse(`{
+				entities(func: uid("{{.EntityID}}")) @filter(has({{.Filter}})) {
+					{{.Predicates}}
+					{{.VersionPredicate}}
+					{{.UpdatedAtPredicate}}
+					has_version {
+						uid
+						versionNumber
+						versionActiveFrom
+						versionActiveUntil
+						versionSnapshot
+					}
+				}
+			}`)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrVersionsCanNotBeFound
+	}
+
+	queryBuf := bytes.Buffer{}
+	err = queryTemplate.Execute(&queryBuf, variables)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrVersionsCanNotBeFound
+	}
+
+	response, err := executor.Store.Query(queryBuf.String())
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrVersionsCanNotBeFound
+	}
+
+	var foundedEntities struct {
+		Entities []map[string]json.RawMessage `json:"entities"`
+	}
+
+	err = json.Unmarshal(response, &foundedEntities)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrVersionsCanNotBeFound
+	}
+
+	if len(foundedEntities.Entities) == 0 {
+		return nil, ErrEntityDoesNotExist
+	}
+
+	fields := foundedEntities.Entities[0]
+
+	current := mutation.Version{Number: 1}
+
+	var versions []mutation.Version
+
+	if encodedVersions, ok := fields["has_version"]; ok {
+		err = json.Unmarshal(encodedVersions, &versions)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return nil, ErrVersionsCanNotBeFound
+		}
+	}
+
+	if encodedNumber, ok := fields[predicates.VersionPredicate]; ok {
+		err = json.Unmarshal(encodedNumber, &current.Number)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return nil, ErrVersionsCanNotBeFound
+		}
+	}
+
+	if encodedUpdatedAt, ok := fields[predicates.UpdatedAtPredicate]; ok {
+		err = json.Unmarshal(encodedUpdatedAt, &current.ActiveFrom)
+		if err != nil {
+			ExecutorLogger.Println(err)
+			return nil, ErrVersionsCanNotBeFound
+		}
+	}
+
+	// Snapshot of current version has same predicates as snapshots which are kept by update functions
+	for _, predicate := range []string{"uid", "has_version", predicates.VersionPredicate, predicates.UpdatedAtPredicate} {
+		delete(fields, predicate)
+	}
+
+	snapshot, err := json.Marshal(fields)
+	if err != nil {
+		ExecutorLogger.Println(err)
+		return nil, ErrVersionsCanNotBeFound
+	}
+
+	current.Snapshot = string(snapshot)
+
+	versions = append(versions, current)
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Number < versions[j].Number })
+
+	return versions, nil
+}
+
+// ReadVersionActiveAt return version of entity which was active at time,
+// for example selectors of page instruction during crawl
+func (executor *Executor) ReadVersionActiveAt(entity, entityID string, activeAt time.Time) (mutation.Version, error) {
+	versions, err := executor.ReadVersions(entity, entityID)
+	if err != nil {
+		return mutation.Version{}, err
+	}
+
+	for _, version := range versions {
+		if version.ActiveFrom != nil && activeAt.Before(*version.ActiveFrom) {
+			continue
+		}
+
+		if version.ActiveUntil != nil && !activeAt.Before(*version.ActiveUntil) {
+			continue
+		}
+
+		return version, nil
+	}
+
+	return mutation.Version{}, ErrVersionIsNotFound
+}
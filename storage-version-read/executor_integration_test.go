@@ -0,0 +1,162 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	dataBaseClient "github.com/dgraph-io/dgo"
+	dataBaseAPI "github.com/dgraph-io/dgo/protos/api"
+	"github.com/hecatoncheir/Storage"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestExecutor_ReadVersions(t *testing.T) {
+	t.Skip("Database must be started")
+
+	DatabaseGateway := os.Getenv("DatabaseGateway")
+	if DatabaseGateway == "" {
+		DatabaseGateway = "localhost:9080"
+	}
+
+	databaseClient, err := connectToDatabase(DatabaseGateway)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	schema := `
+		path: string @index(term) .
+		itemSelector: string @index(term) .
+		pageInstructionVersion: int .
+		pageInstructionUpdatedAt: dateTime .
+		has_version: uid @count .
+		versionNumber: int .
+		versionActiveFrom: dateTime .
+		versionActiveUntil: dateTime .
+		versionSnapshot: string .
+	`
+
+	err = setUpSchema(schema, databaseClient)
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: DatabaseGateway}}
+
+	FakeEntityID := "0x12"
+
+	_, err = executor.ReadVersions("pageInstruction", FakeEntityID)
+	if err != ErrEntityDoesNotExist {
+		t.Fatalf("Expected: %v, actual: %v", ErrEntityDoesNotExist, err)
+	}
+
+	updatedAt := time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	createdEntityID, err := createEntity(map[string]interface{}{
+		"path":                     "catalog/",
+		"itemSelector":             ".product",
+		"pageInstructionVersion":   2,
+		"pageInstructionUpdatedAt": updatedAt,
+		"has_version": []map[string]interface{}{{
+			"versionNumber":      1,
+			"versionActiveUntil": updatedAt,
+			"versionSnapshot":    `{"itemSelector":".item","path":"catalog/"}`}}}, databaseClient)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if createdEntityID == "" {
+		t.Fatalf("Created entity id is empty")
+	}
+
+	defer func() {
+		err = deleteEntityByID(createdEntityID, databaseClient)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	}()
+
+	versions, err := executor.ReadVersions("pageInstruction", createdEntityID)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(versions) != 2 || versions[0].Number != 1 || versions[1].Number != 2 {
+		t.Fatalf("Expected first and current versions in storage, actual: %v", versions)
+	}
+
+	if versions[1].Snapshot != `{"itemSelector":".product","path":"catalog/"}` {
+		t.Fatalf("Expected selectors of current version in storage, actual: %v", versions[1].Snapshot)
+	}
+
+	version, err := executor.ReadVersionActiveAt("pageInstruction", createdEntityID, updatedAt.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if version.Number != 1 {
+		t.Fatalf("Expected first version active before update, actual: %v", version)
+	}
+}
+
+func connectToDatabase(databaseGateway string) (*dataBaseClient.Dgraph, error) {
+	conn, err := grpc.Dial(databaseGateway, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	baseConnection := dataBaseAPI.NewDgraphClient(conn)
+	databaseClient := dataBaseClient.NewDgraphClient(baseConnection)
+
+	return databaseClient, nil
+}
+
+func setUpSchema(schema string, databaseClient *dataBaseClient.Dgraph) error {
+	operation := &dataBaseAPI.Operation{Schema: schema}
+
+	err := databaseClient.Alter(context.Background(), operation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createEntity(entityForCreate map[string]interface{}, databaseClient *dataBaseClient.Dgraph) (string, error) {
+	encodedEntity, err := json.Marshal(entityForCreate)
+	if err != nil {
+		return "", err
+	}
+
+	mutation := &dataBaseAPI.Mutation{
+		SetJson:   encodedEntity,
+		CommitNow: true}
+
+	transaction := databaseClient.NewTxn()
+	assigned, err := transaction.Mutate(context.Background(), mutation)
+	if err != nil {
+		return "", err
+	}
+
+	uid := assigned.Uids["blank-0"]
+
+	return uid, nil
+}
+
+func deleteEntityByID(entityID string, databaseClient *dataBaseClient.Dgraph) error {
+	deleteEntityData, err := json.Marshal(map[string]string{"uid": entityID})
+	if err != nil {
+		return err
+	}
+
+	mutation := dataBaseAPI.Mutation{
+		DeleteJson: deleteEntityData,
+		CommitNow:  true}
+
+	transaction := databaseClient.NewTxn()
+
+	_, err = transaction.Mutate(context.Background(), &mutation)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
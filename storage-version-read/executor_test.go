@@ -0,0 +1,68 @@
+package function
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+func TestVersionActiveAtTimeCanBeRead(t *testing.T) {
+	executor := Executor{Store: MockStore{Response: `{"entities": [{
+		"uid": "0x12",
+		"path": "catalog/",
+		"itemSelector": ".product",
+		"pageInstructionVersion": 2,
+		"pageInstructionUpdatedAt": "2018-03-02T00:00:00Z",
+		"has_version": [{
+			"uid": "0x100",
+			"versionNumber": 1,
+			"versionActiveUntil": "2018-03-02T00:00:00Z",
+			"versionSnapshot": "{\"itemSelector\":\".item\",\"path\":\"catalog/\"}"}]}]}`}}
+
+	versions, err := executor.ReadVersions("pageInstruction", "0x12")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if len(versions) != 2 || versions[0].Number != 1 || versions[1].Number != 2 {
+		t.Fatalf("Expected first and current versions, actual: %v", versions)
+	}
+
+	if versions[1].Snapshot != `{"itemSelector":".product","path":"catalog/"}` {
+		t.Errorf("Expected selectors of current version, actual: %v", versions[1].Snapshot)
+	}
+
+	crawledAt := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	version, err := executor.ReadVersionActiveAt("pageInstruction", "0x12", crawledAt)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if version.Number != 1 || !strings.Contains(version.Snapshot, `".item"`) {
+		t.Errorf("Expected first version, actual: %v", version)
+	}
+
+	version, err = executor.ReadVersionActiveAt("pageInstruction", "0x12", crawledAt.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if version.Number != 2 {
+		t.Errorf("Expected current version, actual: %v", version)
+	}
+
+	_, err = executor.ReadVersions("price", "0x12")
+	if err != ErrEntityIsUnknown {
+		t.Errorf("Expected: %v, actual: %v", ErrEntityIsUnknown, err)
+	}
+}
+
+type MockStore struct {
+	Response string
+}
+
+func (store MockStore) Query(request string) ([]byte, error) {
+	return []byte(store.Response), nil
+}
@@ -0,0 +1,91 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hecatoncheir/Storage"
+	"time"
+)
+
+// Request for versions of entity, Entity is one of VersionedEntities.
+// If ActiveAt is set, only version which was active at this time is returned.
+type Request struct {
+	Entity, EntityID, DatabaseGateway string
+	ActiveAt                          *time.Time
+}
+
+type Response struct{ Message, Data, Error string }
+
+// Handle a serverless request
+func Handle(req []byte) string {
+	request := Request{}
+
+	err := json.Unmarshal(req, &request)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Unmarshal request error: %v. Error: %v", request, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	executor := Executor{Store: &storage.Store{DatabaseGateway: request.DatabaseGateway}}
+
+	var versions interface{}
+
+	if request.ActiveAt != nil {
+		versions, err = executor.ReadVersionActiveAt(request.Entity, request.EntityID, *request.ActiveAt)
+	} else {
+		versions, err = executor.ReadVersions(request.Entity, request.EntityID)
+	}
+
+	if err != nil {
+		warning := fmt.Sprintf(
+			"ReadVersions error: %v", err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	encodedVersions, err := json.Marshal(versions)
+	if err != nil {
+		warning := fmt.Sprintf(
+			"Marshal versions error: %v. Error: %v", versions, err)
+
+		fmt.Println(warning)
+
+		errorResponse := Response{Message: warning, Data: string(req), Error: err.Error()}
+
+		response, err := json.Marshal(errorResponse)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		return string(response)
+	}
+
+	response := Response{Data: string(encodedVersions)}
+
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return string(encodedResponse)
+}